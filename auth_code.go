@@ -0,0 +1,171 @@
+package gocloak
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// CodeChallengeMethodS256 is the PKCE code challenge method supported by GenerateCodeChallenge
+const CodeChallengeMethodS256 = "S256"
+
+// idTokenClaims holds the claims of an ID token which are checked after a code exchange
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce           string `json:"nonce,omitempty"`
+	AuthorizedParty string `json:"azp,omitempty"`
+}
+
+// randomString returns a url safe string of n random bytes
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GenerateState generates a random state to protect the Authorization Code flow against CSRF
+func GenerateState() (string, error) {
+	return randomString(32)
+}
+
+// GenerateNonce generates a random nonce to bind the ID token to the authorization request
+func GenerateNonce() (string, error) {
+	return randomString(32)
+}
+
+// GenerateCodeVerifier generates a random PKCE code verifier of 43 characters
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// GenerateCodeChallenge derives the S256 PKCE code challenge from the code verifier
+func GenerateCodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// VerifyCodeChallenge reports whether the code challenge was derived from the code verifier using the given method.
+// The methods "S256" and "plain" are supported.
+func VerifyCodeChallenge(codeVerifier, codeChallenge, method string) bool {
+	expected := codeVerifier
+	switch method {
+	case CodeChallengeMethodS256:
+		expected = GenerateCodeChallenge(codeVerifier)
+	case "", "plain":
+	default:
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// GetAuthCodeURL builds the URL the user agent is redirected to, to start the Authorization Code flow.
+// If the options contain a code challenge without a method, S256 is assumed.
+func (g *GoCloak) GetAuthCodeURL(ctx context.Context, realm string, options AuthCodeURLOptions) (string, error) {
+	const errMessage = "could not build authorization url"
+
+	ctx, span := g.startSpan(ctx, "GetAuthCodeURL")
+	defer span.End()
+
+	if NilOrEmpty(options.ClientID) {
+		return "", errors.New(errMessage + ": client id is required")
+	}
+
+	authorizationURL, err := g.getAuthorizationURL(ctx, realm)
+	if err != nil {
+		return "", errors.Wrap(err, errMessage)
+	}
+
+	parsedURL, err := url.Parse(authorizationURL)
+	if err != nil {
+		return "", errors.Wrap(err, errMessage)
+	}
+
+	query := parsedURL.Query()
+	for key, value := range options.FormData() {
+		query.Set(key, value)
+	}
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), nil
+}
+
+// ExchangeAuthCode exchanges the code of the Authorization Code flow for tokens.
+// options.CodeVerifier must be set if a code challenge was sent.
+// A returned ID token is validated: it must be signed by the realm, issued by the issuer of the
+// OpenID configuration of the realm and to the client. If nonce is not empty, an ID token
+// must be returned and its nonce claim must match.
+func (g *GoCloak) ExchangeAuthCode(ctx context.Context, realm string, options TokenOptions, nonce string) (*JWT, error) {
+	const errMessage = "could not exchange authorization code"
+
+	ctx, span := g.startSpan(ctx, "ExchangeAuthCode")
+	defer span.End()
+
+	if NilOrEmpty(options.Code) {
+		return nil, errors.New(errMessage + ": code is required")
+	}
+	options.GrantType = StringP("authorization_code")
+
+	token, err := g.GetToken(ctx, realm, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.IDToken == "" {
+		if nonce != "" {
+			return nil, errors.New(errMessage + ": no id token was returned")
+		}
+		return token, nil
+	}
+
+	claims, err := g.validateIDToken(ctx, realm, PString(options.ClientID), token.IDToken)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage+": invalid id token")
+	}
+
+	if nonce != "" && subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New(errMessage + ": id token nonce mismatch")
+	}
+
+	return token, nil
+}
+
+// validateIDToken validates the signature, issuer, audience, azp, type and lifetime of an ID token issued to the client
+func (g *GoCloak) validateIDToken(ctx context.Context, realm, clientID, idToken string) (*idTokenClaims, error) {
+	configuration, err := g.GetOpenIDConfiguration(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+	if NilOrEmpty(configuration.Issuer) {
+		return nil, errors.New("openid configuration has no issuer")
+	}
+
+	validator := NewTokenValidator(g, realm,
+		SetValidatorIssuer(*configuration.Issuer),
+		SetValidatorAudience(clientID),
+		SetValidatorTokenType("ID"))
+
+	var claims idTokenClaims
+	if _, err := validator.ValidateCustomClaims(ctx, idToken, &claims); err != nil {
+		return nil, err
+	}
+
+	// azp is required if the token has several audiences and must be the client if present
+	if claims.AuthorizedParty == "" && len(claims.Audience) > 1 {
+		return nil, validationError(TokenValidationCheckAuthorizedParty, nil, "token with several audiences has no authorized party")
+	}
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != clientID {
+		return nil, validationError(TokenValidationCheckAuthorizedParty, nil, "unexpected authorized party %q", claims.AuthorizedParty)
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,165 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func TestCodeChallenge(t *testing.T) {
+	t.Parallel()
+
+	// test vector of RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := gocloak.GenerateCodeChallenge(verifier)
+	require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", challenge)
+	require.True(t, gocloak.VerifyCodeChallenge(verifier, challenge, gocloak.CodeChallengeMethodS256))
+	require.True(t, gocloak.VerifyCodeChallenge(verifier, verifier, "plain"))
+	require.False(t, gocloak.VerifyCodeChallenge("other", challenge, gocloak.CodeChallengeMethodS256))
+	require.False(t, gocloak.VerifyCodeChallenge(verifier, challenge, "unknown"))
+
+	generated, err := gocloak.GenerateCodeVerifier()
+	require.NoError(t, err)
+	require.Len(t, generated, 43)
+}
+
+func TestGetAuthCodeURL(t *testing.T) {
+	t.Parallel()
+	client := gocloak.NewClient("https://keycloak.example.com")
+
+	authURL, err := client.GetAuthCodeURL(context.Background(), "test", gocloak.AuthCodeURLOptions{
+		ClientID:      gocloak.StringP("frontend"),
+		RedirectURI:   gocloak.StringP("https://app.example.com/callback"),
+		Scopes:        &[]string{"openid", "email"},
+		State:         gocloak.StringP("state"),
+		Nonce:         gocloak.StringP("nonce"),
+		CodeChallenge: gocloak.StringP("challenge"),
+		Prompt:        gocloak.StringP("login"),
+		LoginHint:     gocloak.StringP("user@example.com"),
+		IDPHint:       gocloak.StringP("github"),
+		MaxAge:        gocloak.IntP(300),
+	})
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "/realms/test/protocol/openid-connect/auth", parsed.Path)
+	require.Equal(t, url.Values{
+		"client_id":             {"frontend"},
+		"redirect_uri":          {"https://app.example.com/callback"},
+		"response_type":         {"code"},
+		"scope":                 {"openid email"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+		"prompt":                {"login"},
+		"login_hint":            {"user@example.com"},
+		"kc_idp_hint":           {"github"},
+		"max_age":               {"300"},
+	}, parsed.Query())
+
+	_, err = client.GetAuthCodeURL(context.Background(), "test", gocloak.AuthCodeURLOptions{})
+	require.Error(t, err)
+}
+
+// authCodeRealm returns a fake realm whose token endpoint answers a code exchange
+// with an ID token issued to the frontend client, modified by mutate
+func authCodeRealm(t *testing.T, mutate func(claims jwt.MapClaims)) *fakeRealm {
+	realm := newFakeRealm(t, "test")
+	realm.server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		claims := realm.claims()
+		claims["aud"] = "frontend"
+		claims["typ"] = "ID"
+		claims["nonce"] = "expected-nonce"
+		if mutate != nil {
+			mutate(claims)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "access", IDToken: realm.sign(t, claims)})
+	})
+
+	return realm
+}
+
+var authCodeOptions = gocloak.TokenOptions{
+	ClientID:     gocloak.StringP("frontend"),
+	Code:         gocloak.StringP("the-code"),
+	CodeVerifier: gocloak.StringP("the-verifier"),
+	RedirectURI:  gocloak.StringP("https://app.example.com/callback"),
+}
+
+func TestExchangeAuthCode(t *testing.T) {
+	t.Parallel()
+	realm := authCodeRealm(t, nil)
+	client := gocloak.NewClient(realm.server.URL)
+
+	token, err := client.ExchangeAuthCode(context.Background(), "test", authCodeOptions, "expected-nonce")
+	require.NoError(t, err)
+	require.Equal(t, "access", token.AccessToken)
+	form := realm.server.last(t, tokenPath).Form
+	require.Equal(t, "authorization_code", form.Get("grant_type"))
+	require.Equal(t, "the-code", form.Get("code"))
+	require.Equal(t, "the-verifier", form.Get("code_verifier"))
+
+	_, err = client.ExchangeAuthCode(context.Background(), "test", authCodeOptions, "other-nonce")
+	require.Error(t, err)
+}
+
+func TestExchangeAuthCode_InvalidIDToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name   string
+		Mutate func(claims jwt.MapClaims)
+		Check  gocloak.TokenValidationCheck
+	}{
+		{
+			Name:   "wrong audience",
+			Mutate: func(claims jwt.MapClaims) { claims["aud"] = "other-client" },
+			Check:  gocloak.TokenValidationCheckAudience,
+		},
+		{
+			Name: "wrong issuer",
+			Mutate: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://elsewhere/realms/test"
+			},
+			Check: gocloak.TokenValidationCheckIssuer,
+		},
+		{
+			Name:   "wrong azp",
+			Mutate: func(claims jwt.MapClaims) { claims["azp"] = "other-client" },
+			Check:  gocloak.TokenValidationCheckAuthorizedParty,
+		},
+		{
+			Name: "several audiences without azp",
+			Mutate: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{"frontend", "backend"}
+				delete(claims, "azp")
+			},
+			Check: gocloak.TokenValidationCheckAuthorizedParty,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+			realm := authCodeRealm(t, testCase.Mutate)
+			client := gocloak.NewClient(realm.server.URL)
+
+			_, err := client.ExchangeAuthCode(context.Background(), "test", authCodeOptions, "expected-nonce")
+			var validationErr *gocloak.TokenValidationError
+			require.True(t, errors.As(err, &validationErr), "unexpected error: %v", err)
+			require.Equal(t, testCase.Check, validationErr.Check)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package gocloak
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate go run ./internal/authgen -in gocloak_iface.go -out authenticated_client_gen.go
+
+// AuthenticatedClient wraps a GoCloakIface and obtains the access token of every call from a TokenSource.
+// It exposes the admin operations of GoCloakIface without the token argument. Calls which are
+// rejected with 401 Unauthorized are retried once with a fresh token, unless they upload an io.Reader.
+type AuthenticatedClient struct {
+	client GoCloakIface
+	tokens *TokenSource
+}
+
+// NewAuthenticatedClient creates a new AuthenticatedClient.
+// The credential strategy is defined by the TokenSource, e.g.
+// NewClientTokenSource, NewPasswordTokenSource or NewSignedJWTTokenSource.
+func NewAuthenticatedClient(client GoCloakIface, tokens *TokenSource) *AuthenticatedClient {
+	return &AuthenticatedClient{
+		client: client,
+		tokens: tokens,
+	}
+}
+
+// Client returns the wrapped client
+func (c *AuthenticatedClient) Client() GoCloakIface {
+	return c.client
+}
+
+// TokenSource returns the token source used to authenticate calls
+func (c *AuthenticatedClient) TokenSource() *TokenSource {
+	return c.tokens
+}
+
+func (c *AuthenticatedClient) do(ctx context.Context, call func(token string) error) error {
+	err := c.doOnce(ctx, call)
+	if !isUnauthorized(err) {
+		return err
+	}
+
+	return c.doOnce(ctx, call)
+}
+
+// doOnce is used instead of do for calls which consume a reader, as the reader can't be sent again.
+// A rejected token is still invalidated, so the next call uses a fresh one.
+func (c *AuthenticatedClient) doOnce(ctx context.Context, call func(token string) error) error {
+	token, err := c.tokens.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = call(token)
+	if isUnauthorized(err) {
+		c.tokens.InvalidateToken(token)
+	}
+
+	return err
+}
+
+func isUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
@@ -0,0 +1,2458 @@
+// Code generated by authgen; DO NOT EDIT.
+
+package gocloak
+
+import (
+	"context"
+	"io"
+)
+
+// GetServerInfo fetches the server info.
+func (c *AuthenticatedClient) GetServerInfo(ctx context.Context) (*ServerInfoRepresentation, error) {
+	var r0 *ServerInfoRepresentation
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetServerInfo(ctx, accessToken)
+		return err
+	})
+	return r0, err
+}
+
+// LogoutAllSessions logs out all sessions of a user given an id.
+func (c *AuthenticatedClient) LogoutAllSessions(ctx context.Context, realm, userID string) error {
+	return c.do(ctx, func(accessToken string) error {
+		return c.client.LogoutAllSessions(ctx, accessToken, realm, userID)
+	})
+}
+
+// RevokeUserConsents revokes the given user consent.
+func (c *AuthenticatedClient) RevokeUserConsents(ctx context.Context, realm, userID, clientID string) error {
+	return c.do(ctx, func(accessToken string) error {
+		return c.client.RevokeUserConsents(ctx, accessToken, realm, userID, clientID)
+	})
+}
+
+// LogoutUserSession logs out a single sessions of a user given a session id
+func (c *AuthenticatedClient) LogoutUserSession(ctx context.Context, realm, session string) error {
+	return c.do(ctx, func(accessToken string) error {
+		return c.client.LogoutUserSession(ctx, accessToken, realm, session)
+	})
+}
+
+// ExecuteActionsEmail executes an actions email
+func (c *AuthenticatedClient) ExecuteActionsEmail(ctx context.Context, realm string, params ExecuteActionsEmail) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.ExecuteActionsEmail(ctx, token, realm, params)
+	})
+}
+
+// SendVerifyEmail sends a verification e-mail to a user.
+func (c *AuthenticatedClient) SendVerifyEmail(ctx context.Context, userID, realm string, params ...SendVerificationMailParams) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.SendVerifyEmail(ctx, token, userID, realm, params...)
+	})
+}
+
+// CreateGroup creates a new group.
+func (c *AuthenticatedClient) CreateGroup(ctx context.Context, realm string, group Group) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateGroup(ctx, token, realm, group)
+		return err
+	})
+	return r0, err
+}
+
+// CreateChildGroup creates a new child group
+func (c *AuthenticatedClient) CreateChildGroup(ctx context.Context, realm, groupID string, group Group) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateChildGroup(ctx, token, realm, groupID, group)
+		return err
+	})
+	return r0, err
+}
+
+// CreateComponent creates the given component.
+func (c *AuthenticatedClient) CreateComponent(ctx context.Context, realm string, component Component) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateComponent(ctx, token, realm, component)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClient creates the given g.
+func (c *AuthenticatedClient) CreateClient(ctx context.Context, realm string, newClient Client) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.CreateClient(ctx, accessToken, realm, newClient)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientRepresentation creates a new client representation
+func (c *AuthenticatedClient) CreateClientRepresentation(ctx context.Context, realm string, newClient Client) (*Client, error) {
+	var r0 *Client
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateClientRepresentation(ctx, token, realm, newClient)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientRole creates a new role for a client
+func (c *AuthenticatedClient) CreateClientRole(ctx context.Context, realm, idOfClient string, role Role) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateClientRole(ctx, token, realm, idOfClient, role)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientScope creates a new client scope
+func (c *AuthenticatedClient) CreateClientScope(ctx context.Context, realm string, scope ClientScope) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateClientScope(ctx, token, realm, scope)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientScopeProtocolMapper creates a new protocolMapper under the given client scope
+func (c *AuthenticatedClient) CreateClientScopeProtocolMapper(ctx context.Context, realm, scopeID string, protocolMapper ProtocolMappers) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateClientScopeProtocolMapper(ctx, token, realm, scopeID, protocolMapper)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateGroup updates the given group.
+func (c *AuthenticatedClient) UpdateGroup(ctx context.Context, realm string, updatedGroup Group) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateGroup(ctx, token, realm, updatedGroup)
+	})
+}
+
+// UpdateGroupManagementPermissions updates the given group management permissions
+func (c *AuthenticatedClient) UpdateGroupManagementPermissions(ctx context.Context, realm, idOfGroup string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.UpdateGroupManagementPermissions(ctx, accessToken, realm, idOfGroup, managementPermissions)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateClient updates the given Client
+func (c *AuthenticatedClient) UpdateClient(ctx context.Context, realm string, updatedClient Client) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateClient(ctx, token, realm, updatedClient)
+	})
+}
+
+// UpdateClientRepresentation updates the given client representation
+func (c *AuthenticatedClient) UpdateClientRepresentation(ctx context.Context, realm string, updatedClient Client) (*Client, error) {
+	var r0 *Client
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.UpdateClientRepresentation(ctx, accessToken, realm, updatedClient)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateClientManagementPermissions updates the given client management permissions
+func (c *AuthenticatedClient) UpdateClientManagementPermissions(ctx context.Context, realm, idOfClient string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.UpdateClientManagementPermissions(ctx, accessToken, realm, idOfClient, managementPermissions)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateRole updates the given role.
+func (c *AuthenticatedClient) UpdateRole(ctx context.Context, realm, idOfClient string, role Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateRole(ctx, token, realm, idOfClient, role)
+	})
+}
+
+// UpdateClientScope updates the given client scope.
+func (c *AuthenticatedClient) UpdateClientScope(ctx context.Context, realm string, scope ClientScope) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateClientScope(ctx, token, realm, scope)
+	})
+}
+
+// UpdateClientScopeProtocolMapper updates the given protocol mapper for a client scope
+func (c *AuthenticatedClient) UpdateClientScopeProtocolMapper(ctx context.Context, realm, scopeID string, protocolMapper ProtocolMappers) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateClientScopeProtocolMapper(ctx, token, realm, scopeID, protocolMapper)
+	})
+}
+
+// DeleteGroup deletes the group with the given groupID.
+func (c *AuthenticatedClient) DeleteGroup(ctx context.Context, realm, groupID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteGroup(ctx, token, realm, groupID)
+	})
+}
+
+// DeleteClient deletes a given client
+func (c *AuthenticatedClient) DeleteClient(ctx context.Context, realm, idOfClient string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClient(ctx, token, realm, idOfClient)
+	})
+}
+
+// DeleteComponent deletes the component with the given id.
+func (c *AuthenticatedClient) DeleteComponent(ctx context.Context, realm, componentID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteComponent(ctx, token, realm, componentID)
+	})
+}
+
+// DeleteClientRepresentation deletes a given client representation.
+func (c *AuthenticatedClient) DeleteClientRepresentation(ctx context.Context, realm, clientID string) error {
+	return c.do(ctx, func(accessToken string) error {
+		return c.client.DeleteClientRepresentation(ctx, accessToken, realm, clientID)
+	})
+}
+
+// DeleteClientRole deletes a given role.
+func (c *AuthenticatedClient) DeleteClientRole(ctx context.Context, realm, idOfClient, roleName string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientRole(ctx, token, realm, idOfClient, roleName)
+	})
+}
+
+// DeleteClientScope deletes the scope with the given id.
+func (c *AuthenticatedClient) DeleteClientScope(ctx context.Context, realm, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScope(ctx, token, realm, scopeID)
+	})
+}
+
+// DeleteClientScopeProtocolMapper deletes the given protocol mapper from the client scope
+func (c *AuthenticatedClient) DeleteClientScopeProtocolMapper(ctx context.Context, realm, scopeID, protocolMapperID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScopeProtocolMapper(ctx, token, realm, scopeID, protocolMapperID)
+	})
+}
+
+// GetClient returns a client
+func (c *AuthenticatedClient) GetClient(ctx context.Context, realm, idOfClient string) (*Client, error) {
+	var r0 *Client
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClient(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRepresentation returns a client representation
+func (c *AuthenticatedClient) GetClientRepresentation(ctx context.Context, realm, clientID string) (*Client, error) {
+	var r0 *Client
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetClientRepresentation(ctx, accessToken, realm, clientID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAdapterConfiguration returns a adapter configuration
+func (c *AuthenticatedClient) GetAdapterConfiguration(ctx context.Context, realm, clientID string) (*AdapterConfiguration, error) {
+	var r0 *AdapterConfiguration
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetAdapterConfiguration(ctx, accessToken, realm, clientID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientsDefaultScopes returns a list of the client's default scopes
+func (c *AuthenticatedClient) GetClientsDefaultScopes(ctx context.Context, realm, idOfClient string) ([]*ClientScope, error) {
+	var r0 []*ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientsDefaultScopes(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// AddDefaultScopeToClient adds a client scope to the list of client's default scopes
+func (c *AuthenticatedClient) AddDefaultScopeToClient(ctx context.Context, realm, idOfClient, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddDefaultScopeToClient(ctx, token, realm, idOfClient, scopeID)
+	})
+}
+
+// RemoveDefaultScopeFromClient removes a client scope from the list of client's default scopes
+func (c *AuthenticatedClient) RemoveDefaultScopeFromClient(ctx context.Context, realm, idOfClient, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.RemoveDefaultScopeFromClient(ctx, token, realm, idOfClient, scopeID)
+	})
+}
+
+// GetClientsOptionalScopes returns a list of the client's optional scopes
+func (c *AuthenticatedClient) GetClientsOptionalScopes(ctx context.Context, realm, idOfClient string) ([]*ClientScope, error) {
+	var r0 []*ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientsOptionalScopes(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// AddOptionalScopeToClient adds a client scope to the list of client's optional scopes
+func (c *AuthenticatedClient) AddOptionalScopeToClient(ctx context.Context, realm, idOfClient, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddOptionalScopeToClient(ctx, token, realm, idOfClient, scopeID)
+	})
+}
+
+// RemoveOptionalScopeFromClient deletes a client scope from the list of client's optional scopes
+func (c *AuthenticatedClient) RemoveOptionalScopeFromClient(ctx context.Context, realm, idOfClient, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.RemoveOptionalScopeFromClient(ctx, token, realm, idOfClient, scopeID)
+	})
+}
+
+// GetDefaultOptionalClientScopes returns a list of default realm optional scopes
+func (c *AuthenticatedClient) GetDefaultOptionalClientScopes(ctx context.Context, realm string) ([]*ClientScope, error) {
+	var r0 []*ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetDefaultOptionalClientScopes(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetDefaultDefaultClientScopes returns a list of default realm default scopes
+func (c *AuthenticatedClient) GetDefaultDefaultClientScopes(ctx context.Context, realm string) ([]*ClientScope, error) {
+	var r0 []*ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetDefaultDefaultClientScopes(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScope returns a clientscope
+func (c *AuthenticatedClient) GetClientScope(ctx context.Context, realm, scopeID string) (*ClientScope, error) {
+	var r0 *ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScope(ctx, token, realm, scopeID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopes returns all client scopes
+func (c *AuthenticatedClient) GetClientScopes(ctx context.Context, realm string) ([]*ClientScope, error) {
+	var r0 []*ClientScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopes(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeProtocolMappers returns all protocol mappers of a client scope
+func (c *AuthenticatedClient) GetClientScopeProtocolMappers(ctx context.Context, realm, scopeID string) ([]*ProtocolMappers, error) {
+	var r0 []*ProtocolMappers
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeProtocolMappers(ctx, token, realm, scopeID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeProtocolMapper returns a protocol mapper of a client scope
+func (c *AuthenticatedClient) GetClientScopeProtocolMapper(ctx context.Context, realm, scopeID, protocolMapperID string) (*ProtocolMappers, error) {
+	var r0 *ProtocolMappers
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeProtocolMapper(ctx, token, realm, scopeID, protocolMapperID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeMappings returns all scope mappings for the client
+func (c *AuthenticatedClient) GetClientScopeMappings(ctx context.Context, realm, idOfClient string) (*MappingsRepresentation, error) {
+	var r0 *MappingsRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeMappings(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRoleGroups returns groups associated with the realm role
+func (c *AuthenticatedClient) GetRealmRoleGroups(ctx context.Context, roleName, realm string) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRoleGroups(ctx, token, roleName, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeMappingsRealmRoles returns realm-level roles associated with the client’s scope
+func (c *AuthenticatedClient) GetClientScopeMappingsRealmRoles(ctx context.Context, realm, idOfClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeMappingsRealmRoles(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeMappingsRealmRolesAvailable returns realm-level roles that are available to attach to this client’s scope
+func (c *AuthenticatedClient) GetClientScopeMappingsRealmRolesAvailable(ctx context.Context, realm, idOfClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeMappingsRealmRolesAvailable(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientScopeMappingsRealmRoles create realm-level roles to the client’s scope
+func (c *AuthenticatedClient) CreateClientScopeMappingsRealmRoles(ctx context.Context, realm, idOfClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateClientScopeMappingsRealmRoles(ctx, token, realm, idOfClient, roles)
+	})
+}
+
+// DeleteClientScopeMappingsRealmRoles deletes realm-level roles from the client’s scope
+func (c *AuthenticatedClient) DeleteClientScopeMappingsRealmRoles(ctx context.Context, realm, idOfClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScopeMappingsRealmRoles(ctx, token, realm, idOfClient, roles)
+	})
+}
+
+// GetClientScopeMappingsClientRoles returns roles associated with a client’s scope
+func (c *AuthenticatedClient) GetClientScopeMappingsClientRoles(ctx context.Context, realm, idOfClient, idOfSelectedClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeMappingsClientRoles(ctx, token, realm, idOfClient, idOfSelectedClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopeMappingsClientRolesAvailable returns available roles associated with a client’s scope
+func (c *AuthenticatedClient) GetClientScopeMappingsClientRolesAvailable(ctx context.Context, realm, idOfClient, idOfSelectedClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopeMappingsClientRolesAvailable(ctx, token, realm, idOfClient, idOfSelectedClient)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientScopeMappingsClientRoles creates client-level roles from the client’s scope
+func (c *AuthenticatedClient) CreateClientScopeMappingsClientRoles(ctx context.Context, realm, idOfClient, idOfSelectedClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateClientScopeMappingsClientRoles(ctx, token, realm, idOfClient, idOfSelectedClient, roles)
+	})
+}
+
+// DeleteClientScopeMappingsClientRoles deletes client-level roles from the client’s scope
+func (c *AuthenticatedClient) DeleteClientScopeMappingsClientRoles(ctx context.Context, realm, idOfClient, idOfSelectedClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScopeMappingsClientRoles(ctx, token, realm, idOfClient, idOfSelectedClient, roles)
+	})
+}
+
+// GetClientSecret returns a client's secret
+func (c *AuthenticatedClient) GetClientSecret(ctx context.Context, realm, idOfClient string) (*CredentialRepresentation, error) {
+	var r0 *CredentialRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientSecret(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientServiceAccount retrieves the service account "user" for a client if enabled
+func (c *AuthenticatedClient) GetClientServiceAccount(ctx context.Context, realm, idOfClient string) (*User, error) {
+	var r0 *User
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientServiceAccount(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// RegenerateClientSecret triggers the creation of the new client secret.
+func (c *AuthenticatedClient) RegenerateClientSecret(ctx context.Context, realm, idOfClient string) (*CredentialRepresentation, error) {
+	var r0 *CredentialRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.RegenerateClientSecret(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientOfflineSessions returns offline sessions associated with the client
+func (c *AuthenticatedClient) GetClientOfflineSessions(ctx context.Context, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
+	var r0 []*UserSessionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientOfflineSessions(ctx, token, realm, idOfClient, params...)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientUserSessions returns user sessions associated with the client
+func (c *AuthenticatedClient) GetClientUserSessions(ctx context.Context, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
+	var r0 []*UserSessionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientUserSessions(ctx, token, realm, idOfClient, params...)
+		return err
+	})
+	return r0, err
+}
+
+// CreateClientProtocolMapper creates a protocol mapper in client scope
+func (c *AuthenticatedClient) CreateClientProtocolMapper(ctx context.Context, realm, idOfClient string, mapper ProtocolMapperRepresentation) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateClientProtocolMapper(ctx, token, realm, idOfClient, mapper)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateClientProtocolMapper updates a protocol mapper in client scope
+func (c *AuthenticatedClient) UpdateClientProtocolMapper(ctx context.Context, realm, idOfClient, mapperID string, mapper ProtocolMapperRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateClientProtocolMapper(ctx, token, realm, idOfClient, mapperID, mapper)
+	})
+}
+
+// DeleteClientProtocolMapper deletes a protocol mapper in client scope
+func (c *AuthenticatedClient) DeleteClientProtocolMapper(ctx context.Context, realm, idOfClient, mapperID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientProtocolMapper(ctx, token, realm, idOfClient, mapperID)
+	})
+}
+
+// GetKeyStoreConfig get keystoreconfig of the realm
+func (c *AuthenticatedClient) GetKeyStoreConfig(ctx context.Context, realm string) (*KeyStoreConfig, error) {
+	var r0 *KeyStoreConfig
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetKeyStoreConfig(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetComponents get all components in realm
+func (c *AuthenticatedClient) GetComponents(ctx context.Context, realm string) ([]*Component, error) {
+	var r0 []*Component
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetComponents(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetComponentsWithParams get all components in realm with query params
+func (c *AuthenticatedClient) GetComponentsWithParams(ctx context.Context, realm string, params GetComponentsParams) ([]*Component, error) {
+	var r0 []*Component
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetComponentsWithParams(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetComponent get exactly one component by ID
+func (c *AuthenticatedClient) GetComponent(ctx context.Context, realm, componentID string) (*Component, error) {
+	var r0 *Component
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetComponent(ctx, token, realm, componentID)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateComponent updates the given component
+func (c *AuthenticatedClient) UpdateComponent(ctx context.Context, realm string, component Component) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateComponent(ctx, token, realm, component)
+	})
+}
+
+// GetDefaultGroups returns a list of default groups
+func (c *AuthenticatedClient) GetDefaultGroups(ctx context.Context, realm string) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetDefaultGroups(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// AddDefaultGroup adds group to the list of default groups
+func (c *AuthenticatedClient) AddDefaultGroup(ctx context.Context, realm, groupID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddDefaultGroup(ctx, token, realm, groupID)
+	})
+}
+
+// RemoveDefaultGroup removes group from the list of default groups
+func (c *AuthenticatedClient) RemoveDefaultGroup(ctx context.Context, realm, groupID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.RemoveDefaultGroup(ctx, token, realm, groupID)
+	})
+}
+
+// GetRoleMappingByGroupID gets the role mappings by group
+func (c *AuthenticatedClient) GetRoleMappingByGroupID(ctx context.Context, realm, groupID string) (*MappingsRepresentation, error) {
+	var r0 *MappingsRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRoleMappingByGroupID(ctx, token, realm, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetRoleMappingByUserID gets the role mappings by user
+func (c *AuthenticatedClient) GetRoleMappingByUserID(ctx context.Context, realm, userID string) (*MappingsRepresentation, error) {
+	var r0 *MappingsRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRoleMappingByUserID(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroup get group with id in realm
+func (c *AuthenticatedClient) GetGroup(ctx context.Context, realm, groupID string) (*Group, error) {
+	var r0 *Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroup(ctx, token, realm, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetChildGroups get child groups of group with id in realm
+func (c *AuthenticatedClient) GetChildGroups(ctx context.Context, realm, groupID string, params GetChildGroupsParams) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetChildGroups(ctx, token, realm, groupID, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupByPath get group with path in realm
+func (c *AuthenticatedClient) GetGroupByPath(ctx context.Context, realm, groupPath string) (*Group, error) {
+	var r0 *Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupByPath(ctx, token, realm, groupPath)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroups get all groups in realm
+func (c *AuthenticatedClient) GetGroups(ctx context.Context, realm string, params GetGroupsParams) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroups(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupManagementPermissions returns whether group Authorization permissions have been initialized or not and a reference
+// to the managed permissions
+func (c *AuthenticatedClient) GetGroupManagementPermissions(ctx context.Context, realm, idOfGroup string) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupManagementPermissions(ctx, token, realm, idOfGroup)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupsByRole gets groups assigned with a specific role of a realm
+func (c *AuthenticatedClient) GetGroupsByRole(ctx context.Context, realm, roleName string) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupsByRole(ctx, token, realm, roleName)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupsByClientRole gets groups with specified roles assigned of given client within a realm
+func (c *AuthenticatedClient) GetGroupsByClientRole(ctx context.Context, realm, roleName, clientID string) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupsByClientRole(ctx, token, realm, roleName, clientID)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupsCount gets the groups count in the realm
+func (c *AuthenticatedClient) GetGroupsCount(ctx context.Context, realm string, params GetGroupsParams) (int, error) {
+	var r0 int
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupsCount(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetGroupMembers get a list of users of group with id in realm
+func (c *AuthenticatedClient) GetGroupMembers(ctx context.Context, realm, groupID string, params GetGroupsParams) ([]*User, error) {
+	var r0 []*User
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetGroupMembers(ctx, token, realm, groupID, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRoles get all roles for the given client in realm
+func (c *AuthenticatedClient) GetClientRoles(ctx context.Context, realm, idOfClient string, params GetRoleParams) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientRoles(ctx, token, realm, idOfClient, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRoleByID gets role for the given client in realm using role ID
+func (c *AuthenticatedClient) GetClientRoleByID(ctx context.Context, realm, roleID string) (*Role, error) {
+	var r0 *Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientRoleByID(ctx, token, realm, roleID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRolesByUserID returns all client roles assigned to the given user
+func (c *AuthenticatedClient) GetClientRolesByUserID(ctx context.Context, realm, idOfClient, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientRolesByUserID(ctx, token, realm, idOfClient, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRolesByGroupID returns all client roles assigned to the given group
+func (c *AuthenticatedClient) GetClientRolesByGroupID(ctx context.Context, realm, idOfClient, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientRolesByGroupID(ctx, token, realm, idOfClient, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeClientRolesByRoleID returns all client composite roles associated with the given client role
+func (c *AuthenticatedClient) GetCompositeClientRolesByRoleID(ctx context.Context, realm, idOfClient, roleID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeClientRolesByRoleID(ctx, token, realm, idOfClient, roleID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeClientRolesByUserID returns all client roles and composite roles assigned to the given user
+func (c *AuthenticatedClient) GetCompositeClientRolesByUserID(ctx context.Context, realm, idOfClient, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeClientRolesByUserID(ctx, token, realm, idOfClient, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAvailableClientRolesByUserID returns all available client roles to the given user
+func (c *AuthenticatedClient) GetAvailableClientRolesByUserID(ctx context.Context, realm, idOfClient, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAvailableClientRolesByUserID(ctx, token, realm, idOfClient, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAvailableClientRolesByGroupID returns all available roles to the given group
+func (c *AuthenticatedClient) GetAvailableClientRolesByGroupID(ctx context.Context, realm, idOfClient, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAvailableClientRolesByGroupID(ctx, token, realm, idOfClient, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeClientRolesByGroupID returns all client roles and composite roles assigned to the given group
+func (c *AuthenticatedClient) GetCompositeClientRolesByGroupID(ctx context.Context, realm, idOfClient, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeClientRolesByGroupID(ctx, token, realm, idOfClient, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientRole get a role for the given client in a realm by role name
+func (c *AuthenticatedClient) GetClientRole(ctx context.Context, realm, idOfClient, roleName string) (*Role, error) {
+	var r0 *Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientRole(ctx, token, realm, idOfClient, roleName)
+		return err
+	})
+	return r0, err
+}
+
+// GetClients gets all clients in realm
+func (c *AuthenticatedClient) GetClients(ctx context.Context, realm string, params GetClientsParams) ([]*Client, error) {
+	var r0 []*Client
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClients(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientManagementPermissions returns whether client Authorization permissions have been initialized or not and a reference
+// to the managed permissions
+func (c *AuthenticatedClient) GetClientManagementPermissions(ctx context.Context, realm, idOfClient string) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientManagementPermissions(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// CreateRealmRole creates a role in a realm
+func (c *AuthenticatedClient) CreateRealmRole(ctx context.Context, realm string, role Role) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateRealmRole(ctx, token, realm, role)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRole returns a role from a realm by role's name
+func (c *AuthenticatedClient) GetRealmRole(ctx context.Context, realm, roleName string) (*Role, error) {
+	var r0 *Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRole(ctx, token, realm, roleName)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRoleByID returns a role from a realm by role's ID
+func (c *AuthenticatedClient) GetRealmRoleByID(ctx context.Context, realm, roleID string) (*Role, error) {
+	var r0 *Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRoleByID(ctx, token, realm, roleID)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRoles get all roles of the given realm.
+func (c *AuthenticatedClient) GetRealmRoles(ctx context.Context, realm string, params GetRoleParams) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRoles(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRolesByUserID returns all roles assigned to the given user
+func (c *AuthenticatedClient) GetRealmRolesByUserID(ctx context.Context, realm, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRolesByUserID(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealmRolesByGroupID returns all roles assigned to the given group
+func (c *AuthenticatedClient) GetRealmRolesByGroupID(ctx context.Context, realm, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealmRolesByGroupID(ctx, token, realm, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateRealmRole updates a role in a realm
+func (c *AuthenticatedClient) UpdateRealmRole(ctx context.Context, realm, roleName string, role Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateRealmRole(ctx, token, realm, roleName, role)
+	})
+}
+
+// UpdateRealmRoleByID updates a role in a realm by role's ID
+func (c *AuthenticatedClient) UpdateRealmRoleByID(ctx context.Context, realm, roleID string, role Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateRealmRoleByID(ctx, token, realm, roleID, role)
+	})
+}
+
+// DeleteRealmRole deletes a role in a realm by role's name
+func (c *AuthenticatedClient) DeleteRealmRole(ctx context.Context, realm, roleName string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRealmRole(ctx, token, realm, roleName)
+	})
+}
+
+// AddRealmRoleToUser adds realm-level role mappings
+func (c *AuthenticatedClient) AddRealmRoleToUser(ctx context.Context, realm, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddRealmRoleToUser(ctx, token, realm, userID, roles)
+	})
+}
+
+// DeleteRealmRoleFromUser deletes realm-level role mappings
+func (c *AuthenticatedClient) DeleteRealmRoleFromUser(ctx context.Context, realm, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRealmRoleFromUser(ctx, token, realm, userID, roles)
+	})
+}
+
+// AddRealmRoleToGroup adds realm-level role mappings
+func (c *AuthenticatedClient) AddRealmRoleToGroup(ctx context.Context, realm, groupID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddRealmRoleToGroup(ctx, token, realm, groupID, roles)
+	})
+}
+
+// DeleteRealmRoleFromGroup deletes realm-level role mappings
+func (c *AuthenticatedClient) DeleteRealmRoleFromGroup(ctx context.Context, realm, groupID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRealmRoleFromGroup(ctx, token, realm, groupID, roles)
+	})
+}
+
+// AddRealmRoleComposite adds a role to the composite.
+func (c *AuthenticatedClient) AddRealmRoleComposite(ctx context.Context, realm, roleName string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddRealmRoleComposite(ctx, token, realm, roleName, roles)
+	})
+}
+
+// DeleteRealmRoleComposite deletes a role from the composite.
+func (c *AuthenticatedClient) DeleteRealmRoleComposite(ctx context.Context, realm, roleName string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRealmRoleComposite(ctx, token, realm, roleName, roles)
+	})
+}
+
+// GetCompositeRealmRoles returns all realm composite roles associated with the given realm role
+func (c *AuthenticatedClient) GetCompositeRealmRoles(ctx context.Context, realm, roleName string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeRealmRoles(ctx, token, realm, roleName)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeRolesByRoleID returns all realm composite roles associated with the given client role
+func (c *AuthenticatedClient) GetCompositeRolesByRoleID(ctx context.Context, realm, roleID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeRolesByRoleID(ctx, token, realm, roleID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeRealmRolesByRoleID returns all realm composite roles associated with the given client role
+func (c *AuthenticatedClient) GetCompositeRealmRolesByRoleID(ctx context.Context, realm, roleID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeRealmRolesByRoleID(ctx, token, realm, roleID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeRealmRolesByUserID returns all realm roles and composite roles assigned to the given user
+func (c *AuthenticatedClient) GetCompositeRealmRolesByUserID(ctx context.Context, realm, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeRealmRolesByUserID(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCompositeRealmRolesByGroupID returns all realm roles and composite roles assigned to the given group
+func (c *AuthenticatedClient) GetCompositeRealmRolesByGroupID(ctx context.Context, realm, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCompositeRealmRolesByGroupID(ctx, token, realm, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAvailableRealmRolesByUserID returns all available realm roles to the given user
+func (c *AuthenticatedClient) GetAvailableRealmRolesByUserID(ctx context.Context, realm, userID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAvailableRealmRolesByUserID(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAvailableRealmRolesByGroupID returns all available realm roles to the given group
+func (c *AuthenticatedClient) GetAvailableRealmRolesByGroupID(ctx context.Context, realm, groupID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAvailableRealmRolesByGroupID(ctx, token, realm, groupID)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealm returns top-level representation of the realm
+func (c *AuthenticatedClient) GetRealm(ctx context.Context, realm string) (*RealmRepresentation, error) {
+	var r0 *RealmRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealm(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetRealms returns top-level representation of all realms
+func (c *AuthenticatedClient) GetRealms(ctx context.Context) ([]*RealmRepresentation, error) {
+	var r0 []*RealmRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRealms(ctx, token)
+		return err
+	})
+	return r0, err
+}
+
+// CreateRealm creates a realm
+func (c *AuthenticatedClient) CreateRealm(ctx context.Context, realm RealmRepresentation) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateRealm(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateRealm updates a given realm
+func (c *AuthenticatedClient) UpdateRealm(ctx context.Context, realm RealmRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateRealm(ctx, token, realm)
+	})
+}
+
+// DeleteRealm removes a realm
+func (c *AuthenticatedClient) DeleteRealm(ctx context.Context, realm string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRealm(ctx, token, realm)
+	})
+}
+
+// ClearRealmCache clears realm cache
+func (c *AuthenticatedClient) ClearRealmCache(ctx context.Context, realm string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.ClearRealmCache(ctx, token, realm)
+	})
+}
+
+// ClearUserCache clears realm cache
+func (c *AuthenticatedClient) ClearUserCache(ctx context.Context, realm string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.ClearUserCache(ctx, token, realm)
+	})
+}
+
+// ClearKeysCache clears realm cache
+func (c *AuthenticatedClient) ClearKeysCache(ctx context.Context, realm string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.ClearKeysCache(ctx, token, realm)
+	})
+}
+
+// GetAuthenticationFlows get all authentication flows from a realm
+func (c *AuthenticatedClient) GetAuthenticationFlows(ctx context.Context, realm string) ([]*AuthenticationFlowRepresentation, error) {
+	var r0 []*AuthenticationFlowRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthenticationFlows(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetAuthenticationFlow get an authentication flow with the given ID
+func (c *AuthenticatedClient) GetAuthenticationFlow(ctx context.Context, realm, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
+	var r0 *AuthenticationFlowRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthenticationFlow(ctx, token, realm, authenticationFlowID)
+		return err
+	})
+	return r0, err
+}
+
+// CreateAuthenticationFlow creates a new Authentication flow in a realm
+func (c *AuthenticatedClient) CreateAuthenticationFlow(ctx context.Context, realm string, flow AuthenticationFlowRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateAuthenticationFlow(ctx, token, realm, flow)
+	})
+}
+
+// UpdateAuthenticationFlow a given Authentication Flow
+func (c *AuthenticatedClient) UpdateAuthenticationFlow(ctx context.Context, realm string, flow AuthenticationFlowRepresentation, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
+	var r0 *AuthenticationFlowRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.UpdateAuthenticationFlow(ctx, token, realm, flow, authenticationFlowID)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteAuthenticationFlow deletes a flow in a realm with the given ID
+func (c *AuthenticatedClient) DeleteAuthenticationFlow(ctx context.Context, realm, flowID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteAuthenticationFlow(ctx, token, realm, flowID)
+	})
+}
+
+// GetAuthenticationExecutions retrieves all executions of a given flow
+func (c *AuthenticatedClient) GetAuthenticationExecutions(ctx context.Context, realm, flow string) ([]*ModifyAuthenticationExecutionRepresentation, error) {
+	var r0 []*ModifyAuthenticationExecutionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthenticationExecutions(ctx, token, realm, flow)
+		return err
+	})
+	return r0, err
+}
+
+// CreateAuthenticationExecution creates a new execution for the given flow name in the given realm
+func (c *AuthenticatedClient) CreateAuthenticationExecution(ctx context.Context, realm, flow string, execution CreateAuthenticationExecutionRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateAuthenticationExecution(ctx, token, realm, flow, execution)
+	})
+}
+
+// UpdateAuthenticationExecution updates an authentication execution for the given flow in the given realm
+func (c *AuthenticatedClient) UpdateAuthenticationExecution(ctx context.Context, realm, flow string, execution ModifyAuthenticationExecutionRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateAuthenticationExecution(ctx, token, realm, flow, execution)
+	})
+}
+
+// DeleteAuthenticationExecution delete a single execution with the given ID
+func (c *AuthenticatedClient) DeleteAuthenticationExecution(ctx context.Context, realm, executionID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteAuthenticationExecution(ctx, token, realm, executionID)
+	})
+}
+
+// CreateAuthenticationExecutionFlow creates a new execution for the given flow name in the given realm
+func (c *AuthenticatedClient) CreateAuthenticationExecutionFlow(ctx context.Context, realm, flow string, executionFlow CreateAuthenticationExecutionFlowRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateAuthenticationExecutionFlow(ctx, token, realm, flow, executionFlow)
+	})
+}
+
+// CreateUser creates the given user in the given realm and returns it's userID
+// Note: Keycloak has not documented what members of the User object are actually being accepted, when creating a user.
+// Things like RealmRoles must be attached using followup calls to the respective functions.
+func (c *AuthenticatedClient) CreateUser(ctx context.Context, realm string, user User) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateUser(ctx, token, realm, user)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteUser delete a given user
+func (c *AuthenticatedClient) DeleteUser(ctx context.Context, realm, userID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteUser(ctx, token, realm, userID)
+	})
+}
+
+// GetUserByID fetches a user from the given realm with the given userID
+func (c *AuthenticatedClient) GetUserByID(ctx context.Context, realm, userID string) (*User, error) {
+	var r0 *User
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetUserByID(ctx, accessToken, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetUserCount gets the user count in the realm
+func (c *AuthenticatedClient) GetUserCount(ctx context.Context, realm string, params GetUsersParams) (int, error) {
+	var r0 int
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserCount(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetUserGroups get all groups for user
+func (c *AuthenticatedClient) GetUserGroups(ctx context.Context, realm, userID string, params GetGroupsParams) ([]*Group, error) {
+	var r0 []*Group
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserGroups(ctx, token, realm, userID, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetUsers get all users in realm
+func (c *AuthenticatedClient) GetUsers(ctx context.Context, realm string, params GetUsersParams) ([]*User, error) {
+	var r0 []*User
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUsers(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetUsersByRoleName returns all users have a given role
+func (c *AuthenticatedClient) GetUsersByRoleName(ctx context.Context, realm, roleName string, params GetUsersByRoleParams) ([]*User, error) {
+	var r0 []*User
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUsersByRoleName(ctx, token, realm, roleName, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetUsersByClientRoleName returns all users have a given client role
+func (c *AuthenticatedClient) GetUsersByClientRoleName(ctx context.Context, realm, idOfClient, roleName string, params GetUsersByRoleParams) ([]*User, error) {
+	var r0 []*User
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUsersByClientRoleName(ctx, token, realm, idOfClient, roleName, params)
+		return err
+	})
+	return r0, err
+}
+
+// SetPassword sets a new password for the user with the given id. Needs elevated privileges
+func (c *AuthenticatedClient) SetPassword(ctx context.Context, userID, realm, password string, temporary bool) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.SetPassword(ctx, token, userID, realm, password, temporary)
+	})
+}
+
+// UpdateUser updates a given user
+func (c *AuthenticatedClient) UpdateUser(ctx context.Context, realm string, user User) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateUser(ctx, token, realm, user)
+	})
+}
+
+// AddUserToGroup puts given user to given group
+func (c *AuthenticatedClient) AddUserToGroup(ctx context.Context, realm, userID, groupID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddUserToGroup(ctx, token, realm, userID, groupID)
+	})
+}
+
+// DeleteUserFromGroup deletes given user from given group
+func (c *AuthenticatedClient) DeleteUserFromGroup(ctx context.Context, realm, userID, groupID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteUserFromGroup(ctx, token, realm, userID, groupID)
+	})
+}
+
+// GetUserSessions returns user sessions associated with the user
+func (c *AuthenticatedClient) GetUserSessions(ctx context.Context, realm, userID string) ([]*UserSessionRepresentation, error) {
+	var r0 []*UserSessionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserSessions(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetUserOfflineSessionsForClient returns offline sessions associated with the user and client
+func (c *AuthenticatedClient) GetUserOfflineSessionsForClient(ctx context.Context, realm, userID, idOfClient string) ([]*UserSessionRepresentation, error) {
+	var r0 []*UserSessionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserOfflineSessionsForClient(ctx, token, realm, userID, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// AddClientRolesToUser adds client-level role mappings
+func (c *AuthenticatedClient) AddClientRolesToUser(ctx context.Context, realm, idOfClient, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddClientRolesToUser(ctx, token, realm, idOfClient, userID, roles)
+	})
+}
+
+// AddClientRoleToUser adds client-level role mappings
+//
+// Deprecated: replaced by AddClientRolesToUser
+func (c *AuthenticatedClient) AddClientRoleToUser(ctx context.Context, realm, idOfClient, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddClientRoleToUser(ctx, token, realm, idOfClient, userID, roles)
+	})
+}
+
+// AddClientRolesToGroup adds a client role to the group
+func (c *AuthenticatedClient) AddClientRolesToGroup(ctx context.Context, realm, idOfClient, groupID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddClientRolesToGroup(ctx, token, realm, idOfClient, groupID, roles)
+	})
+}
+
+// AddClientRoleToGroup adds a client role to the group
+//
+// Deprecated: replaced by AddClientRolesToGroup
+func (c *AuthenticatedClient) AddClientRoleToGroup(ctx context.Context, realm, idOfClient, groupID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddClientRoleToGroup(ctx, token, realm, idOfClient, groupID, roles)
+	})
+}
+
+// DeleteClientRolesFromUser adds client-level role mappings
+func (c *AuthenticatedClient) DeleteClientRolesFromUser(ctx context.Context, realm, idOfClient, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientRolesFromUser(ctx, token, realm, idOfClient, userID, roles)
+	})
+}
+
+// DeleteClientRoleFromUser adds client-level role mappings
+//
+// Deprecated: replaced by DeleteClientRolesFrom
+func (c *AuthenticatedClient) DeleteClientRoleFromUser(ctx context.Context, realm, idOfClient, userID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientRoleFromUser(ctx, token, realm, idOfClient, userID, roles)
+	})
+}
+
+// DeleteClientRoleFromGroup removes a client role from from the group
+func (c *AuthenticatedClient) DeleteClientRoleFromGroup(ctx context.Context, realm, idOfClient, groupID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientRoleFromGroup(ctx, token, realm, idOfClient, groupID, roles)
+	})
+}
+
+// AddClientRoleComposite adds roles as composite
+func (c *AuthenticatedClient) AddClientRoleComposite(ctx context.Context, realm, roleID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddClientRoleComposite(ctx, token, realm, roleID, roles)
+	})
+}
+
+// DeleteClientRoleComposite deletes composites from a role
+func (c *AuthenticatedClient) DeleteClientRoleComposite(ctx context.Context, realm, roleID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientRoleComposite(ctx, token, realm, roleID, roles)
+	})
+}
+
+// GetUserFederatedIdentities gets all user federated identities
+func (c *AuthenticatedClient) GetUserFederatedIdentities(ctx context.Context, realm, userID string) ([]*FederatedIdentityRepresentation, error) {
+	var r0 []*FederatedIdentityRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserFederatedIdentities(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// CreateUserFederatedIdentity creates an user federated identity
+func (c *AuthenticatedClient) CreateUserFederatedIdentity(ctx context.Context, realm, userID, providerID string, federatedIdentityRep FederatedIdentityRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateUserFederatedIdentity(ctx, token, realm, userID, providerID, federatedIdentityRep)
+	})
+}
+
+// DeleteUserFederatedIdentity deletes an user federated identity
+func (c *AuthenticatedClient) DeleteUserFederatedIdentity(ctx context.Context, realm, userID, providerID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteUserFederatedIdentity(ctx, token, realm, userID, providerID)
+	})
+}
+
+// GetUserBruteForceDetectionStatus fetches a user status regarding brute force protection
+func (c *AuthenticatedClient) GetUserBruteForceDetectionStatus(ctx context.Context, realm, userID string) (*BruteForceStatus, error) {
+	var r0 *BruteForceStatus
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetUserBruteForceDetectionStatus(ctx, accessToken, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// CreateIdentityProvider creates an identity provider in a realm
+func (c *AuthenticatedClient) CreateIdentityProvider(ctx context.Context, realm string, providerRep IdentityProviderRepresentation) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateIdentityProvider(ctx, token, realm, providerRep)
+		return err
+	})
+	return r0, err
+}
+
+// GetIdentityProviders returns list of identity providers in a realm
+func (c *AuthenticatedClient) GetIdentityProviders(ctx context.Context, realm string) ([]*IdentityProviderRepresentation, error) {
+	var r0 []*IdentityProviderRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetIdentityProviders(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetIdentityProvider gets the identity provider in a realm
+func (c *AuthenticatedClient) GetIdentityProvider(ctx context.Context, realm, alias string) (*IdentityProviderRepresentation, error) {
+	var r0 *IdentityProviderRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetIdentityProvider(ctx, token, realm, alias)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateIdentityProvider updates the identity provider in a realm
+func (c *AuthenticatedClient) UpdateIdentityProvider(ctx context.Context, realm, alias string, providerRep IdentityProviderRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateIdentityProvider(ctx, token, realm, alias, providerRep)
+	})
+}
+
+// DeleteIdentityProvider deletes the identity provider in a realm
+func (c *AuthenticatedClient) DeleteIdentityProvider(ctx context.Context, realm, alias string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteIdentityProvider(ctx, token, realm, alias)
+	})
+}
+
+// ExportIDPPublicBrokerConfig exports the broker config for a given alias
+func (c *AuthenticatedClient) ExportIDPPublicBrokerConfig(ctx context.Context, realm, alias string) (*string, error) {
+	var r0 *string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.ExportIDPPublicBrokerConfig(ctx, token, realm, alias)
+		return err
+	})
+	return r0, err
+}
+
+// ImportIdentityProviderConfig parses and returns the identity provider config at a given URL
+func (c *AuthenticatedClient) ImportIdentityProviderConfig(ctx context.Context, realm, fromURL, providerID string) (map[string]string, error) {
+	var r0 map[string]string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.ImportIdentityProviderConfig(ctx, token, realm, fromURL, providerID)
+		return err
+	})
+	return r0, err
+}
+
+// ImportIdentityProviderConfigFromFile parses and returns the identity provider config from a given file
+func (c *AuthenticatedClient) ImportIdentityProviderConfigFromFile(ctx context.Context, realm, providerID, fileName string, fileBody io.Reader) (map[string]string, error) {
+	var r0 map[string]string
+	err := c.doOnce(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.ImportIdentityProviderConfigFromFile(ctx, token, realm, providerID, fileName, fileBody)
+		return err
+	})
+	return r0, err
+}
+
+// CreateIdentityProviderMapper creates an instance of an identity provider mapper associated with the given alias
+func (c *AuthenticatedClient) CreateIdentityProviderMapper(ctx context.Context, realm, alias string, mapper IdentityProviderMapper) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateIdentityProviderMapper(ctx, token, realm, alias, mapper)
+		return err
+	})
+	return r0, err
+}
+
+// GetIdentityProviderMapper gets the mapper by id for the given identity provider alias in a realm
+func (c *AuthenticatedClient) GetIdentityProviderMapper(ctx context.Context, realm, alias, mapperID string) (*IdentityProviderMapper, error) {
+	var r0 *IdentityProviderMapper
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetIdentityProviderMapper(ctx, token, realm, alias, mapperID)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteIdentityProviderMapper deletes an instance of an identity provider mapper associated with the given alias and mapper ID
+func (c *AuthenticatedClient) DeleteIdentityProviderMapper(ctx context.Context, realm, alias, mapperID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteIdentityProviderMapper(ctx, token, realm, alias, mapperID)
+	})
+}
+
+// GetIdentityProviderMappers returns list of mappers associated with an identity provider
+func (c *AuthenticatedClient) GetIdentityProviderMappers(ctx context.Context, realm, alias string) ([]*IdentityProviderMapper, error) {
+	var r0 []*IdentityProviderMapper
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetIdentityProviderMappers(ctx, token, realm, alias)
+		return err
+	})
+	return r0, err
+}
+
+// GetIdentityProviderMapperByID gets the mapper of an identity provider
+func (c *AuthenticatedClient) GetIdentityProviderMapperByID(ctx context.Context, realm, alias, mapperID string) (*IdentityProviderMapper, error) {
+	var r0 *IdentityProviderMapper
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetIdentityProviderMapperByID(ctx, token, realm, alias, mapperID)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateIdentityProviderMapper updates mapper of an identity provider
+func (c *AuthenticatedClient) UpdateIdentityProviderMapper(ctx context.Context, realm, alias string, mapper IdentityProviderMapper) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateIdentityProviderMapper(ctx, token, realm, alias, mapper)
+	})
+}
+
+// GetResource returns a client's resource with the given id, using access token from admin
+func (c *AuthenticatedClient) GetResource(ctx context.Context, realm, idOfClient, resourceID string) (*ResourceRepresentation, error) {
+	var r0 *ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResource(ctx, token, realm, idOfClient, resourceID)
+		return err
+	})
+	return r0, err
+}
+
+// GetResourceClient returns a client's resource with the given id, using access token from client
+func (c *AuthenticatedClient) GetResourceClient(ctx context.Context, realm, resourceID string) (*ResourceRepresentation, error) {
+	var r0 *ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResourceClient(ctx, token, realm, resourceID)
+		return err
+	})
+	return r0, err
+}
+
+// GetResources returns resources associated with the client, using access token from admin
+func (c *AuthenticatedClient) GetResources(ctx context.Context, realm, idOfClient string, params GetResourceParams) ([]*ResourceRepresentation, error) {
+	var r0 []*ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResources(ctx, token, realm, idOfClient, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetResourcesClient returns resources associated with the client, using access token from client
+func (c *AuthenticatedClient) GetResourcesClient(ctx context.Context, realm string, params GetResourceParams) ([]*ResourceRepresentation, error) {
+	var r0 []*ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResourcesClient(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetResourceServer returns resource server settings.
+// The access token must have the realm view_clients role on its service
+// account to be allowed to call this endpoint.
+func (c *AuthenticatedClient) GetResourceServer(ctx context.Context, realm, idOfClient string) (*ResourceServerRepresentation, error) {
+	var r0 *ResourceServerRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResourceServer(ctx, token, realm, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateResource updates a resource associated with the client, using access token from admin
+func (c *AuthenticatedClient) UpdateResource(ctx context.Context, realm, idOfClient string, resource ResourceRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateResource(ctx, token, realm, idOfClient, resource)
+	})
+}
+
+// UpdateResourceClient updates a resource associated with the client, using access token from client
+func (c *AuthenticatedClient) UpdateResourceClient(ctx context.Context, realm string, resource ResourceRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateResourceClient(ctx, token, realm, resource)
+	})
+}
+
+// CreateResource creates a resource associated with the client, using access token from admin
+func (c *AuthenticatedClient) CreateResource(ctx context.Context, realm, idOfClient string, resource ResourceRepresentation) (*ResourceRepresentation, error) {
+	var r0 *ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateResource(ctx, token, realm, idOfClient, resource)
+		return err
+	})
+	return r0, err
+}
+
+// CreateResourceClient creates a resource associated with the client, using access token from client
+func (c *AuthenticatedClient) CreateResourceClient(ctx context.Context, realm string, resource ResourceRepresentation) (*ResourceRepresentation, error) {
+	var r0 *ResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateResourceClient(ctx, token, realm, resource)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteResource deletes a resource associated with the client (using an admin token)
+func (c *AuthenticatedClient) DeleteResource(ctx context.Context, realm, idOfClient, resourceID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteResource(ctx, token, realm, idOfClient, resourceID)
+	})
+}
+
+// DeleteResourceClient deletes a resource associated with the client (using a client token)
+func (c *AuthenticatedClient) DeleteResourceClient(ctx context.Context, realm, resourceID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteResourceClient(ctx, token, realm, resourceID)
+	})
+}
+
+// GetScope returns a client's scope with the given id
+func (c *AuthenticatedClient) GetScope(ctx context.Context, realm, idOfClient, scopeID string) (*ScopeRepresentation, error) {
+	var r0 *ScopeRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetScope(ctx, token, realm, idOfClient, scopeID)
+		return err
+	})
+	return r0, err
+}
+
+// GetScopes returns scopes associated with the client
+func (c *AuthenticatedClient) GetScopes(ctx context.Context, realm, idOfClient string, params GetScopeParams) ([]*ScopeRepresentation, error) {
+	var r0 []*ScopeRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetScopes(ctx, token, realm, idOfClient, params)
+		return err
+	})
+	return r0, err
+}
+
+// CreateScope creates a scope associated with the client
+func (c *AuthenticatedClient) CreateScope(ctx context.Context, realm, idOfClient string, scope ScopeRepresentation) (*ScopeRepresentation, error) {
+	var r0 *ScopeRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateScope(ctx, token, realm, idOfClient, scope)
+		return err
+	})
+	return r0, err
+}
+
+// GetPermissionScope gets the permission scope associated with the client
+func (c *AuthenticatedClient) GetPermissionScope(ctx context.Context, realm, idOfClient, idOfScope string) (*PolicyRepresentation, error) {
+	var r0 *PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPermissionScope(ctx, token, realm, idOfClient, idOfScope)
+		return err
+	})
+	return r0, err
+}
+
+// UpdatePermissionScope updates a permission scope associated with the client
+func (c *AuthenticatedClient) UpdatePermissionScope(ctx context.Context, realm, idOfClient, idOfScope string, policy PolicyRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdatePermissionScope(ctx, token, realm, idOfClient, idOfScope, policy)
+	})
+}
+
+// UpdateScope updates a scope associated with the client
+func (c *AuthenticatedClient) UpdateScope(ctx context.Context, realm, idOfClient string, scope ScopeRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateScope(ctx, token, realm, idOfClient, scope)
+	})
+}
+
+// DeleteScope deletes a scope associated with the client
+func (c *AuthenticatedClient) DeleteScope(ctx context.Context, realm, idOfClient, scopeID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteScope(ctx, token, realm, idOfClient, scopeID)
+	})
+}
+
+// GetPolicy returns a client's policy with the given id
+func (c *AuthenticatedClient) GetPolicy(ctx context.Context, realm, idOfClient, policyID string) (*PolicyRepresentation, error) {
+	var r0 *PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPolicy(ctx, token, realm, idOfClient, policyID)
+		return err
+	})
+	return r0, err
+}
+
+// GetPolicies returns policies associated with the client
+func (c *AuthenticatedClient) GetPolicies(ctx context.Context, realm, idOfClient string, params GetPolicyParams) ([]*PolicyRepresentation, error) {
+	var r0 []*PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPolicies(ctx, token, realm, idOfClient, params)
+		return err
+	})
+	return r0, err
+}
+
+// CreatePolicy creates a policy associated with the client
+func (c *AuthenticatedClient) CreatePolicy(ctx context.Context, realm, idOfClient string, policy PolicyRepresentation) (*PolicyRepresentation, error) {
+	var r0 *PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreatePolicy(ctx, token, realm, idOfClient, policy)
+		return err
+	})
+	return r0, err
+}
+
+// UpdatePolicy updates a policy associated with the client
+func (c *AuthenticatedClient) UpdatePolicy(ctx context.Context, realm, idOfClient string, policy PolicyRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdatePolicy(ctx, token, realm, idOfClient, policy)
+	})
+}
+
+// DeletePolicy deletes a policy associated with the client
+func (c *AuthenticatedClient) DeletePolicy(ctx context.Context, realm, idOfClient, policyID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeletePolicy(ctx, token, realm, idOfClient, policyID)
+	})
+}
+
+// GetAuthorizationPolicyAssociatedPolicies returns a client's associated policies of specific policy with the given policy id, using access token from admin
+func (c *AuthenticatedClient) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context, realm, idOfClient, policyID string) ([]*PolicyRepresentation, error) {
+	var r0 []*PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthorizationPolicyAssociatedPolicies(ctx, token, realm, idOfClient, policyID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAuthorizationPolicyResources returns a client's resources of specific policy with the given policy id, using access token from admin
+func (c *AuthenticatedClient) GetAuthorizationPolicyResources(ctx context.Context, realm, idOfClient, policyID string) ([]*PolicyResourceRepresentation, error) {
+	var r0 []*PolicyResourceRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthorizationPolicyResources(ctx, token, realm, idOfClient, policyID)
+		return err
+	})
+	return r0, err
+}
+
+// GetAuthorizationPolicyScopes returns a client's scopes of specific policy with the given policy id, using access token from admin
+func (c *AuthenticatedClient) GetAuthorizationPolicyScopes(ctx context.Context, realm, idOfClient, policyID string) ([]*PolicyScopeRepresentation, error) {
+	var r0 []*PolicyScopeRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetAuthorizationPolicyScopes(ctx, token, realm, idOfClient, policyID)
+		return err
+	})
+	return r0, err
+}
+
+// GetResourcePolicy updates a permission for a specific resource, using token obtained by Resource Owner Password Credentials Grant or Token exchange
+func (c *AuthenticatedClient) GetResourcePolicy(ctx context.Context, realm, permissionID string) (*ResourcePolicyRepresentation, error) {
+	var r0 *ResourcePolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResourcePolicy(ctx, token, realm, permissionID)
+		return err
+	})
+	return r0, err
+}
+
+// GetResourcePolicies returns resources associated with the client, using token obtained by Resource Owner Password Credentials Grant or Token exchange
+func (c *AuthenticatedClient) GetResourcePolicies(ctx context.Context, realm string, params GetResourcePoliciesParams) ([]*ResourcePolicyRepresentation, error) {
+	var r0 []*ResourcePolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetResourcePolicies(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// CreateResourcePolicy associates a permission with a specific resource, using token obtained by Resource Owner Password Credentials Grant or Token exchange
+func (c *AuthenticatedClient) CreateResourcePolicy(ctx context.Context, realm, resourceID string, policy ResourcePolicyRepresentation) (*ResourcePolicyRepresentation, error) {
+	var r0 *ResourcePolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateResourcePolicy(ctx, token, realm, resourceID, policy)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateResourcePolicy updates a permission for a specific resource, using token obtained by Resource Owner Password Credentials Grant or Token exchange
+func (c *AuthenticatedClient) UpdateResourcePolicy(ctx context.Context, realm, permissionID string, policy ResourcePolicyRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateResourcePolicy(ctx, token, realm, permissionID, policy)
+	})
+}
+
+// DeleteResourcePolicy deletes a permission for a specific resource, using token obtained by Resource Owner Password Credentials Grant or Token exchange
+func (c *AuthenticatedClient) DeleteResourcePolicy(ctx context.Context, realm, permissionID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteResourcePolicy(ctx, token, realm, permissionID)
+	})
+}
+
+// GetPermission returns a client's permission with the given id
+func (c *AuthenticatedClient) GetPermission(ctx context.Context, realm, idOfClient, permissionID string) (*PermissionRepresentation, error) {
+	var r0 *PermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPermission(ctx, token, realm, idOfClient, permissionID)
+		return err
+	})
+	return r0, err
+}
+
+// GetDependentPermissions returns a client's permission with the given policy id
+func (c *AuthenticatedClient) GetDependentPermissions(ctx context.Context, realm, idOfClient, policyID string) ([]*PermissionRepresentation, error) {
+	var r0 []*PermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetDependentPermissions(ctx, token, realm, idOfClient, policyID)
+		return err
+	})
+	return r0, err
+}
+
+// GetPermissionResources returns a client's resource attached for the given permission id
+func (c *AuthenticatedClient) GetPermissionResources(ctx context.Context, realm, idOfClient, permissionID string) ([]*PermissionResource, error) {
+	var r0 []*PermissionResource
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPermissionResources(ctx, token, realm, idOfClient, permissionID)
+		return err
+	})
+	return r0, err
+}
+
+// GetScopePermissions returns permissions associated with the client scope
+func (c *AuthenticatedClient) GetScopePermissions(ctx context.Context, realm, idOfClient, idOfScope string) ([]*PolicyRepresentation, error) {
+	var r0 []*PolicyRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetScopePermissions(ctx, token, realm, idOfClient, idOfScope)
+		return err
+	})
+	return r0, err
+}
+
+// GetPermissionScopes returns a client's scopes configured for the given permission id
+func (c *AuthenticatedClient) GetPermissionScopes(ctx context.Context, realm, idOfClient, permissionID string) ([]*PermissionScope, error) {
+	var r0 []*PermissionScope
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPermissionScopes(ctx, token, realm, idOfClient, permissionID)
+		return err
+	})
+	return r0, err
+}
+
+// GetPermissions returns permissions associated with the client
+func (c *AuthenticatedClient) GetPermissions(ctx context.Context, realm, idOfClient string, params GetPermissionParams) ([]*PermissionRepresentation, error) {
+	var r0 []*PermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetPermissions(ctx, token, realm, idOfClient, params)
+		return err
+	})
+	return r0, err
+}
+
+// CreatePermissionTicket creates a permission ticket, using access token from client
+func (c *AuthenticatedClient) CreatePermissionTicket(ctx context.Context, realm string, permissions []CreatePermissionTicketParams) (*PermissionTicketResponseRepresentation, error) {
+	var r0 *PermissionTicketResponseRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreatePermissionTicket(ctx, token, realm, permissions)
+		return err
+	})
+	return r0, err
+}
+
+// GrantUserPermission lets resource owner grant permission for specific resource ID to specific user ID
+func (c *AuthenticatedClient) GrantUserPermission(ctx context.Context, realm string, permission PermissionGrantParams) (*PermissionGrantResponseRepresentation, error) {
+	var r0 *PermissionGrantResponseRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GrantUserPermission(ctx, token, realm, permission)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateUserPermission updates user permissions.
+func (c *AuthenticatedClient) UpdateUserPermission(ctx context.Context, realm string, permission PermissionGrantParams) (*PermissionGrantResponseRepresentation, error) {
+	var r0 *PermissionGrantResponseRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.UpdateUserPermission(ctx, token, realm, permission)
+		return err
+	})
+	return r0, err
+}
+
+// GetUserPermissions gets granted permissions according query parameters
+func (c *AuthenticatedClient) GetUserPermissions(ctx context.Context, realm string, params GetUserPermissionParams) ([]*PermissionGrantResponseRepresentation, error) {
+	var r0 []*PermissionGrantResponseRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUserPermissions(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteUserPermission revokes permissions according query parameters
+func (c *AuthenticatedClient) DeleteUserPermission(ctx context.Context, realm, ticketID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteUserPermission(ctx, token, realm, ticketID)
+	})
+}
+
+// CreatePermission creates a permission associated with the client
+func (c *AuthenticatedClient) CreatePermission(ctx context.Context, realm, idOfClient string, permission PermissionRepresentation) (*PermissionRepresentation, error) {
+	var r0 *PermissionRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreatePermission(ctx, token, realm, idOfClient, permission)
+		return err
+	})
+	return r0, err
+}
+
+// UpdatePermission updates a permission associated with the client
+func (c *AuthenticatedClient) UpdatePermission(ctx context.Context, realm, idOfClient string, permission PermissionRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdatePermission(ctx, token, realm, idOfClient, permission)
+	})
+}
+
+// DeletePermission deletes a policy associated with the client
+func (c *AuthenticatedClient) DeletePermission(ctx context.Context, realm, idOfClient, permissionID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeletePermission(ctx, token, realm, idOfClient, permissionID)
+	})
+}
+
+// GetCredentialRegistrators returns credentials registrators
+func (c *AuthenticatedClient) GetCredentialRegistrators(ctx context.Context, realm string) ([]string, error) {
+	var r0 []string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCredentialRegistrators(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetConfiguredUserStorageCredentialTypes returns credential types, which are provided by the user storage where user is stored
+func (c *AuthenticatedClient) GetConfiguredUserStorageCredentialTypes(ctx context.Context, realm, userID string) ([]string, error) {
+	var r0 []string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetConfiguredUserStorageCredentialTypes(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// GetCredentials returns credentials available for a given user
+func (c *AuthenticatedClient) GetCredentials(ctx context.Context, realm, userID string) ([]*CredentialRepresentation, error) {
+	var r0 []*CredentialRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetCredentials(ctx, token, realm, userID)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteCredentials deletes the given credential for a given user
+func (c *AuthenticatedClient) DeleteCredentials(ctx context.Context, realm, userID, credentialID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteCredentials(ctx, token, realm, userID, credentialID)
+	})
+}
+
+// UpdateCredentialUserLabel updates label for the given credential for the given user
+func (c *AuthenticatedClient) UpdateCredentialUserLabel(ctx context.Context, realm, userID, credentialID, userLabel string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateCredentialUserLabel(ctx, token, realm, userID, credentialID, userLabel)
+	})
+}
+
+// DisableAllCredentialsByType disables all credentials for a user of a specific type
+func (c *AuthenticatedClient) DisableAllCredentialsByType(ctx context.Context, realm, userID string, types []string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DisableAllCredentialsByType(ctx, token, realm, userID, types)
+	})
+}
+
+// MoveCredentialBehind move a credential to a position behind another credential
+func (c *AuthenticatedClient) MoveCredentialBehind(ctx context.Context, realm, userID, credentialID, newPreviousCredentialID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.MoveCredentialBehind(ctx, token, realm, userID, credentialID, newPreviousCredentialID)
+	})
+}
+
+// MoveCredentialToFirst move a credential to a first position in the credentials list of the user
+func (c *AuthenticatedClient) MoveCredentialToFirst(ctx context.Context, realm, userID, credentialID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.MoveCredentialToFirst(ctx, token, realm, userID, credentialID)
+	})
+}
+
+// GetEvents returns events
+func (c *AuthenticatedClient) GetEvents(ctx context.Context, realm string, params GetEventsParams) ([]*EventRepresentation, error) {
+	var r0 []*EventRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetEvents(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopesScopeMappingsRealmRolesAvailable returns realm-level roles that are available to attach to this client scope
+func (c *AuthenticatedClient) GetClientScopesScopeMappingsRealmRolesAvailable(ctx context.Context, realm, clientScopeID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopesScopeMappingsRealmRolesAvailable(ctx, token, realm, clientScopeID)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopesScopeMappingsRealmRoles returns roles associated with a client-scope
+func (c *AuthenticatedClient) GetClientScopesScopeMappingsRealmRoles(ctx context.Context, realm, clientScopeID string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopesScopeMappingsRealmRoles(ctx, token, realm, clientScopeID)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteClientScopesScopeMappingsRealmRoles deletes realm-level roles from the client-scope
+func (c *AuthenticatedClient) DeleteClientScopesScopeMappingsRealmRoles(ctx context.Context, realm, clientScopeID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScopesScopeMappingsRealmRoles(ctx, token, realm, clientScopeID, roles)
+	})
+}
+
+// CreateClientScopesScopeMappingsRealmRoles creates realm-level roles to the client scope
+func (c *AuthenticatedClient) CreateClientScopesScopeMappingsRealmRoles(ctx context.Context, realm, clientScopeID string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateClientScopesScopeMappingsRealmRoles(ctx, token, realm, clientScopeID, roles)
+	})
+}
+
+// RegisterRequiredAction creates a required action for a given realm
+func (c *AuthenticatedClient) RegisterRequiredAction(ctx context.Context, realm string, requiredAction RequiredActionProviderRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.RegisterRequiredAction(ctx, token, realm, requiredAction)
+	})
+}
+
+// GetUnregisteredRequiredActions gets a list of unregistered required actions for a given realm
+func (c *AuthenticatedClient) GetUnregisteredRequiredActions(ctx context.Context, realm string) ([]*UnregisteredRequiredActionProviderRepresentation, error) {
+	var r0 []*UnregisteredRequiredActionProviderRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetUnregisteredRequiredActions(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetRequiredActions gets a list of required actions for a given realm
+func (c *AuthenticatedClient) GetRequiredActions(ctx context.Context, realm string) ([]*RequiredActionProviderRepresentation, error) {
+	var r0 []*RequiredActionProviderRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRequiredActions(ctx, token, realm)
+		return err
+	})
+	return r0, err
+}
+
+// GetRequiredAction gets a required action for a given realm
+func (c *AuthenticatedClient) GetRequiredAction(ctx context.Context, realm, alias string) (*RequiredActionProviderRepresentation, error) {
+	var r0 *RequiredActionProviderRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetRequiredAction(ctx, token, realm, alias)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateRequiredAction updates a required action for a given realm
+func (c *AuthenticatedClient) UpdateRequiredAction(ctx context.Context, realm string, requiredAction RequiredActionProviderRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateRequiredAction(ctx, token, realm, requiredAction)
+	})
+}
+
+// DeleteRequiredAction updates a required action for a given realm
+func (c *AuthenticatedClient) DeleteRequiredAction(ctx context.Context, realm, alias string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteRequiredAction(ctx, token, realm, alias)
+	})
+}
+
+// CreateClientScopesScopeMappingsClientRoles attaches a client role to a client scope (not client's scope)
+func (c *AuthenticatedClient) CreateClientScopesScopeMappingsClientRoles(ctx context.Context, realm, idOfClientScope, idOfClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.CreateClientScopesScopeMappingsClientRoles(ctx, token, realm, idOfClientScope, idOfClient, roles)
+	})
+}
+
+// GetClientScopesScopeMappingsClientRolesAvailable returns available (i.e. not attached via
+// CreateClientScopesScopeMappingsClientRoles) client roles for a specific client, for a client scope
+// (not client's scope).
+func (c *AuthenticatedClient) GetClientScopesScopeMappingsClientRolesAvailable(ctx context.Context, realm, idOfClientScope, idOfClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopesScopeMappingsClientRolesAvailable(ctx, token, realm, idOfClientScope, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// GetClientScopesScopeMappingsClientRoles returns attached client roles for a specific client, for a client scope
+// (not client's scope).
+func (c *AuthenticatedClient) GetClientScopesScopeMappingsClientRoles(ctx context.Context, realm, idOfClientScope, idOfClient string) ([]*Role, error) {
+	var r0 []*Role
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetClientScopesScopeMappingsClientRoles(ctx, token, realm, idOfClientScope, idOfClient)
+		return err
+	})
+	return r0, err
+}
+
+// DeleteClientScopesScopeMappingsClientRoles removes attachment of client roles from a client scope
+// (not client's scope).
+func (c *AuthenticatedClient) DeleteClientScopesScopeMappingsClientRoles(ctx context.Context, realm, idOfClientScope, idOfClient string, roles []Role) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteClientScopesScopeMappingsClientRoles(ctx, token, realm, idOfClientScope, idOfClient, roles)
+	})
+}
+
+// UpdateUsersManagementPermissions updates the management permissions for users
+func (c *AuthenticatedClient) UpdateUsersManagementPermissions(ctx context.Context, realm string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.UpdateUsersManagementPermissions(ctx, accessToken, realm, managementPermissions)
+		return err
+	})
+	return r0, err
+}
+
+// GetUsersManagementPermissions returns the management permissions for users
+func (c *AuthenticatedClient) GetUsersManagementPermissions(ctx context.Context, realm string) (*ManagementPermissionRepresentation, error) {
+	var r0 *ManagementPermissionRepresentation
+	err := c.do(ctx, func(accessToken string) error {
+		var err error
+		r0, err = c.client.GetUsersManagementPermissions(ctx, accessToken, realm)
+		return err
+	})
+	return r0, err
+}
+
+// CreateOrganization creates a new Organization
+func (c *AuthenticatedClient) CreateOrganization(ctx context.Context, realm string, organization OrganizationRepresentation) (string, error) {
+	var r0 string
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.CreateOrganization(ctx, token, realm, organization)
+		return err
+	})
+	return r0, err
+}
+
+// GetOrganizations returns a paginated list of organizations filtered according to the specified parameters
+func (c *AuthenticatedClient) GetOrganizations(ctx context.Context, realm string, params GetOrganizationsParams) ([]*OrganizationRepresentation, error) {
+	var r0 []*OrganizationRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetOrganizations(ctx, token, realm, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetOrganizationByID returns the organization representation of the organization with provided ID
+func (c *AuthenticatedClient) GetOrganizationByID(ctx context.Context, realm, idOfOrganization string) (*OrganizationRepresentation, error) {
+	var r0 *OrganizationRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetOrganizationByID(ctx, token, realm, idOfOrganization)
+		return err
+	})
+	return r0, err
+}
+
+// UpdateOrganization updates the given organization
+func (c *AuthenticatedClient) UpdateOrganization(ctx context.Context, realm string, organization OrganizationRepresentation) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.UpdateOrganization(ctx, token, realm, organization)
+	})
+}
+
+// DeleteOrganization deletes the organization
+func (c *AuthenticatedClient) DeleteOrganization(ctx context.Context, realm, idOfOrganization string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.DeleteOrganization(ctx, token, realm, idOfOrganization)
+	})
+}
+
+// InviteUserToOrganization invites an existing user or sends a registration link to a new user, based on the provided e-mail address.
+// If the user with the given e-mail address exists, it sends an invitation link, otherwise it sends a registration link.
+// An invitation email will be sent to the user so SMTP settings are required in keycloak
+func (c *AuthenticatedClient) InviteUserToOrganization(ctx context.Context, realm, idOfOrganization string, user OrganizationInviteUserParams) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.InviteUserToOrganization(ctx, token, realm, idOfOrganization, user)
+	})
+}
+
+// InviteUserToOrganizationByID invites an existing user to the organization, using the specified user id
+// An invitation email will be sent to the user so SMTP settings are required in keycloak
+func (c *AuthenticatedClient) InviteUserToOrganizationByID(ctx context.Context, realm, idOfOrganization, userID string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.InviteUserToOrganizationByID(ctx, token, realm, idOfOrganization, userID)
+	})
+}
+
+// AddUserToOrganization adds the user with the specified id as a member of the organization
+// Adds, or associates, an existing user with the organization. If no user is found, or if it is already associated with the organization, an error response is returned
+// No invitation email is sent to the user
+func (c *AuthenticatedClient) AddUserToOrganization(ctx context.Context, realm, idOfOrganization, idOfUser string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddUserToOrganization(ctx, token, realm, idOfOrganization, idOfUser)
+	})
+}
+
+// GetOrganizationMemberCount returns number of members in the organization.
+func (c *AuthenticatedClient) GetOrganizationMemberCount(ctx context.Context, realm, idOfOrganization string) (int, error) {
+	var r0 int
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetOrganizationMemberCount(ctx, token, realm, idOfOrganization)
+		return err
+	})
+	return r0, err
+}
+
+// GetOrganizationMembers returns a paginated list of organization members filtered according to the specified parameters
+func (c *AuthenticatedClient) GetOrganizationMembers(ctx context.Context, realm, idOfOrganization string, params GetMembersParams) ([]*MemberRepresentation, error) {
+	var r0 []*MemberRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetOrganizationMembers(ctx, token, realm, idOfOrganization, params)
+		return err
+	})
+	return r0, err
+}
+
+// GetOrganizationMemberByID returns the member of the organization with the specified id
+// Searches for auser with the given id. If one is found, and is currently a member of the organization, returns it.
+// Otherwise,an error response with status NOT_FOUND is returned
+func (c *AuthenticatedClient) GetOrganizationMemberByID(ctx context.Context, realm, idOfOrganization, idOfUser string) (*MemberRepresentation, error) {
+	var r0 *MemberRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetOrganizationMemberByID(ctx, token, realm, idOfOrganization, idOfUser)
+		return err
+	})
+	return r0, err
+}
+
+// GetMemberAssociatedOrganizations returns the organizations associated with the user that has the specified id
+func (c *AuthenticatedClient) GetMemberAssociatedOrganizations(ctx context.Context, realm, idOfUser string) ([]*OrganizationRepresentation, error) {
+	var r0 []*OrganizationRepresentation
+	err := c.do(ctx, func(token string) error {
+		var err error
+		r0, err = c.client.GetMemberAssociatedOrganizations(ctx, token, realm, idOfUser)
+		return err
+	})
+	return r0, err
+}
+
+// RemoveUserFromOrganization removes the user with the specified id from the organization
+func (c *AuthenticatedClient) RemoveUserFromOrganization(ctx context.Context, realm, idOfOrganization, idOfUser string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.RemoveUserFromOrganization(ctx, token, realm, idOfOrganization, idOfUser)
+	})
+}
+
+// Adds the identity provider with the specified id to the organization
+// POST /admin/realms/{realm}/organizations/{id}/identity-providers
+func (c *AuthenticatedClient) AddIdentityProviderToOrganization(ctx context.Context, realm, idOfOrganization, identityProviderAlias string) error {
+	return c.do(ctx, func(token string) error {
+		return c.client.AddIdentityProviderToOrganization(ctx, token, realm, idOfOrganization, identityProviderAlias)
+	})
+}
@@ -0,0 +1,124 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const (
+	groupPath        = "/admin/realms/test/groups/group-id"
+	importConfigPath = "/admin/realms/test/identity-provider/import-config"
+)
+
+func TestAuthenticatedClient_InjectsToken(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+	server.handle("/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "admin-token", ExpiresIn: 300})
+	})
+	server.handle("/admin/realms/test/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]*gocloak.User{{Username: gocloak.StringP("user")}})
+	})
+	client := gocloak.NewClient(server.URL)
+	authenticated := gocloak.NewAuthenticatedClient(client,
+		gocloak.NewAdminTokenSource(client, "admin", "secret", "master"))
+
+	for i := 0; i < 2; i++ {
+		users, err := authenticated.GetUsers(context.Background(), "test", gocloak.GetUsersParams{})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, "user", gocloak.PString(users[0].Username))
+		require.Equal(t, "Bearer admin-token", server.last(t, "/admin/realms/test/users").Header.Get("Authorization"))
+	}
+	require.Len(t, server.recorded("/realms/master/protocol/openid-connect/token"), 1)
+}
+
+func TestAuthenticatedClient_RetriesOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		token := "revoked-token"
+		if len(server.recorded(tokenPath)) > 1 {
+			token = "fresh-token"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: token, ExpiresIn: 300})
+	})
+	server.handle(groupPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := gocloak.NewClient(server.URL)
+	authenticated := gocloak.NewAuthenticatedClient(client,
+		gocloak.NewClientTokenSource(client, "client", "secret", "test"))
+
+	err := authenticated.DeleteGroup(context.Background(), "test", "group-id")
+	require.NoError(t, err)
+	require.Len(t, server.recorded(tokenPath), 2)
+	require.Len(t, server.recorded(groupPath), 2)
+}
+
+func TestAuthenticatedClient_RetriesOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "token", ExpiresIn: 300})
+	})
+	server.handle(groupPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client := gocloak.NewClient(server.URL)
+	authenticated := gocloak.NewAuthenticatedClient(client,
+		gocloak.NewClientTokenSource(client, "client", "secret", "test"))
+
+	err := authenticated.DeleteGroup(context.Background(), "test", "group-id")
+	require.Error(t, err)
+	apiErr, ok := err.(*gocloak.APIError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusUnauthorized, apiErr.Code)
+	require.Len(t, server.recorded(groupPath), 2)
+}
+
+func TestAuthenticatedClient_DoesNotRetryReaders(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "revoked-token", ExpiresIn: 300})
+	})
+	server.handle(importConfigPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client := gocloak.NewClient(server.URL)
+	authenticated := gocloak.NewAuthenticatedClient(client,
+		gocloak.NewClientTokenSource(client, "client", "secret", "test"))
+
+	// the reader is consumed by the first attempt, a retry would upload an empty file
+	_, err := authenticated.ImportIdentityProviderConfigFromFile(context.Background(), "test", "oidc", "config.json",
+		strings.NewReader(`{"issuer":"https://idp.example.com"}`))
+	require.ErrorIs(t, err, gocloak.ErrUnauthorized)
+	require.Len(t, server.recorded(importConfigPath), 1)
+
+	// the rejected token was dropped, the next call logs in again
+	_, err = authenticated.ImportIdentityProviderConfigFromFile(context.Background(), "test", "oidc", "config.json",
+		strings.NewReader(`{}`))
+	require.Error(t, err)
+	require.Len(t, server.recorded(tokenPath), 2)
+}
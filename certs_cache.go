@@ -0,0 +1,160 @@
+package gocloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// certsRefreshTimeout bounds background refreshes, which are not tied to the context of a caller
+const certsRefreshTimeout = 30 * time.Second
+
+var errCertKeyNotFound = errors.New("cannot find a key to decode the token")
+
+// certsCache caches the certs of every realm.
+// Entries are never evicted: after CertsInvalidateTime the certs are refetched,
+// and if that fails the stale certs are served until Keycloak is reachable again.
+type certsCache struct {
+	lock    sync.Mutex
+	entries map[string]*certsEntry
+}
+
+// certsEntry holds the certs of a single realm, its fields are guarded by certsCache.lock
+type certsEntry struct {
+	fetchLock   sync.Mutex
+	certs       *CertResponse
+	fetchedAt   time.Time
+	refetchedAt time.Time
+	failedAt    time.Time
+	refreshing  bool
+}
+
+func (c *certsCache) entry(realm string) *certsEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]*certsEntry)
+	}
+
+	entry, ok := c.entries[realm]
+	if !ok {
+		entry = &certsEntry{}
+		c.entries[realm] = entry
+	}
+
+	return entry
+}
+
+func (c *certsCache) load(entry *certsEntry) (*CertResponse, time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return entry.certs, entry.fetchedAt
+}
+
+func (c *certsCache) store(entry *certsEntry, certs *CertResponse, fetchedAt time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry.certs = certs
+	entry.fetchedAt = fetchedAt
+	entry.failedAt = time.Time{}
+}
+
+// startRefresh reports whether a background refresh should be started for the entry
+func (c *certsCache) startRefresh(entry *certsEntry) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry.refreshing {
+		return false
+	}
+	entry.refreshing = true
+
+	return true
+}
+
+func (c *certsCache) finishRefresh(entry *certsEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry.refreshing = false
+}
+
+// allowRefetch rate limits the refetches triggered by unknown key ids
+func (c *certsCache) allowRefetch(entry *certsEntry, now time.Time, interval time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if now.Sub(entry.refetchedAt) < interval {
+		return false
+	}
+	entry.refetchedAt = now
+
+	return true
+}
+
+// recordFailure remembers when fetching the certs of the entry failed
+func (c *certsCache) recordFailure(entry *certsEntry, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry.failedAt = now
+}
+
+// failedWithin reports whether fetching the certs of the entry failed less than interval ago
+func (c *certsCache) failedWithin(entry *certsEntry, now time.Time, interval time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return !entry.failedAt.IsZero() && now.Sub(entry.failedAt) < interval
+}
+
+// refreshCerts fetches the certs of the realm unless another caller already refreshed them.
+// If the certs can't be fetched, previously cached certs are returned, and for CertsMinRefetchInterval
+// after a failed fetch they are returned without trying again, so callers don't queue up behind timeouts.
+func (g *GoCloak) refreshCerts(ctx context.Context, realm string, entry *certsEntry, stale func(fetchedAt time.Time) bool) (*CertResponse, error) {
+	entry.fetchLock.Lock()
+	defer entry.fetchLock.Unlock()
+
+	certs, fetchedAt := g.certsCache.load(entry)
+	if certs != nil && !stale(fetchedAt) {
+		return certs, nil
+	}
+	if certs != nil && g.certsCache.failedWithin(entry, time.Now(), g.Config.CertsMinRefetchInterval) {
+		return certs, nil
+	}
+
+	newCerts, err := g.getNewCerts(ctx, realm)
+	if err != nil {
+		g.certsCache.recordFailure(entry, time.Now())
+		if certs != nil {
+			return certs, nil
+		}
+		return nil, err
+	}
+
+	g.certsCache.store(entry, newCerts, time.Now())
+	g.observeCertsCache(ctx, realm, CertsCacheRefresh)
+
+	return newCerts, nil
+}
+
+// refreshCertsInBackground refreshes the certs of the realm before they expire
+func (g *GoCloak) refreshCertsInBackground(ctx context.Context, realm string, entry *certsEntry, stale func(fetchedAt time.Time) bool) {
+	if !g.certsCache.startRefresh(entry) {
+		return
+	}
+
+	go func() {
+		defer g.certsCache.finishRefresh(entry)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), certsRefreshTimeout)
+		defer cancel()
+
+		_, _ = g.refreshCerts(ctx, realm, entry, stale)
+	}()
+}
@@ -0,0 +1,258 @@
+package gocloak_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+type fakeCertsEndpoint struct {
+	lock    sync.Mutex
+	kids    []string
+	failing bool
+	fetches int32
+}
+
+func (f *fakeCertsEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&f.fetches, 1)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failing {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	keys := make([]gocloak.CertResponseKey, 0, len(f.kids))
+	for _, kid := range f.kids {
+		keys = append(keys, gocloak.CertResponseKey{Kid: gocloak.StringP(kid)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &keys})
+}
+
+func (f *fakeCertsEndpoint) set(failing bool, kids ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.failing = failing
+	if len(kids) > 0 {
+		f.kids = kids
+	}
+}
+
+func newFakeCertsClient(t *testing.T, endpoint *fakeCertsEndpoint, options ...func(*gocloak.GoCloak)) *gocloak.GoCloak {
+	mux := http.NewServeMux()
+	mux.Handle("/realms/test/protocol/openid-connect/certs", endpoint)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return gocloak.NewClient(server.URL, options...)
+}
+
+func TestGetCertKey_RefetchesUnknownKeyID(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"old"}}
+	client := newFakeCertsClient(t, endpoint, gocloak.SetCertsMinRefetchInterval(time.Hour))
+	ctx := context.Background()
+
+	key, err := client.GetCertKey(ctx, "test", "old")
+	require.NoError(t, err)
+	require.Equal(t, "old", gocloak.PString(key.Kid))
+
+	// the keys of the realm were rotated
+	endpoint.set(false, "old", "new")
+	key, err = client.GetCertKey(ctx, "test", "new")
+	require.NoError(t, err)
+	require.Equal(t, "new", gocloak.PString(key.Kid))
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.fetches))
+
+	// refetches are rate limited
+	for i := 0; i < 3; i++ {
+		_, err = client.GetCertKey(ctx, "test", "unknown")
+		require.Error(t, err)
+	}
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.fetches))
+}
+
+func TestGetCerts_ServesStaleCerts(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"kid"}}
+	client := newFakeCertsClient(t, endpoint, gocloak.SetCertCacheInvalidationTime(20*time.Millisecond))
+	ctx := context.Background()
+
+	_, err := client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+
+	endpoint.set(true)
+	time.Sleep(30 * time.Millisecond)
+
+	certs, err := client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, *certs.Keys, 1)
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.fetches))
+
+	_, err = client.GetCerts(ctx, "other")
+	require.Error(t, err)
+}
+
+func TestGetCerts_BacksOffAfterFailure(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"kid"}}
+	client := newFakeCertsClient(t, endpoint,
+		gocloak.SetCertCacheInvalidationTime(20*time.Millisecond),
+		gocloak.SetCertsMinRefetchInterval(200*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	_, err := client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+
+	endpoint.set(true)
+	time.Sleep(30 * time.Millisecond)
+
+	// only one caller tries to fetch the stale certs, the others are served the stale certs within the window
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			certs, err := client.GetCerts(ctx, "test")
+			assert.NoError(t, err)
+			assert.Len(t, *certs.Keys, 1)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.fetches))
+
+	// after the window the certs are fetched again
+	time.Sleep(220 * time.Millisecond)
+	_, err = client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+	require.EqualValues(t, 3, atomic.LoadInt32(&endpoint.fetches))
+}
+
+func TestGetCertKey_RefetchesAfterRecovery(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"old"}, failing: true}
+	client := newFakeCertsClient(t, endpoint, gocloak.SetCertsMinRefetchInterval(time.Hour))
+	ctx := context.Background()
+
+	_, err := client.GetCerts(ctx, "test")
+	require.Error(t, err)
+
+	endpoint.set(false)
+	_, err = client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+
+	// the failure before the successful fetch doesn't hold back the refetch of the unknown key id
+	endpoint.set(false, "old", "new")
+	key, err := client.GetCertKey(ctx, "test", "new")
+	require.NoError(t, err)
+	require.Equal(t, "new", gocloak.PString(key.Kid))
+	require.EqualValues(t, 3, atomic.LoadInt32(&endpoint.fetches))
+}
+
+func TestGetCerts_RefreshesInBackground(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"old"}}
+	client := newFakeCertsClient(t, endpoint, gocloak.SetCertCacheInvalidationTime(200*time.Millisecond))
+	ctx := context.Background()
+
+	_, err := client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+
+	endpoint.set(false, "new")
+	time.Sleep(160 * time.Millisecond)
+
+	// the cached certs are served while they are refreshed
+	certs, err := client.GetCerts(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "old", gocloak.PString((*certs.Keys)[0].Kid))
+
+	require.Eventually(t, func() bool {
+		certs, err := client.GetCerts(ctx, "test")
+		return err == nil && gocloak.PString((*certs.Keys)[0].Kid) == "new"
+	}, time.Second, 5*time.Millisecond)
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.fetches))
+}
+
+func TestDecodeAccessToken_Algorithms(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hmacSecret := []byte("a-very-secret-key-of-sufficient-length")
+
+	n := base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())
+	x := base64.RawURLEncoding.EncodeToString(edPublic)
+	keys := []gocloak.CertResponseKey{
+		{Kid: gocloak.StringP("ps"), Kty: gocloak.StringP("RSA"), Alg: gocloak.StringP("PS256"), Use: gocloak.StringP("sig"), N: &n, E: &e},
+		{Kid: gocloak.StringP("ed"), Kty: gocloak.StringP("OKP"), Alg: gocloak.StringP("EdDSA"), Crv: gocloak.StringP("Ed25519"), X: &x},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/test/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &keys})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := gocloak.NewClient(server.URL, gocloak.SetHMACKeyFunc(func(ctx context.Context, realm, kid string) ([]byte, error) {
+		if realm == "test" && kid == "hs" {
+			return hmacSecret, nil
+		}
+		return nil, nil
+	}))
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{"sub": "user-id"})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	testCases := []struct {
+		Name  string
+		Token string
+		Valid bool
+	}{
+		{Name: "PS256", Token: sign(jwt.SigningMethodPS256, "ps", rsaKey), Valid: true},
+		{Name: "EdDSA", Token: sign(jwt.SigningMethodEdDSA, "ed", edPrivate), Valid: true},
+		{Name: "HS256", Token: sign(jwt.SigningMethodHS256, "hs", hmacSecret), Valid: true},
+		{Name: "alg mismatch", Token: sign(jwt.SigningMethodRS256, "ps", rsaKey)},
+		{Name: "key type mismatch", Token: sign(jwt.SigningMethodHS256, "ps", []byte(n))},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, claims, err := client.DecodeAccessToken(context.Background(), testCase.Token, "test")
+			if !testCase.Valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "user-id", (*claims)["sub"])
+		})
+	}
+}
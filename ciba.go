@@ -0,0 +1,74 @@
+package gocloak
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const cibaGrantType = "urn:openid:params:grant-type:ciba"
+
+// GetBackchannelAuthentication starts a Client-Initiated Backchannel Authentication (CIBA).
+// Keycloak asks the user identified by the login hint to approve the authentication on the authentication device.
+func (g *GoCloak) GetBackchannelAuthentication(ctx context.Context, realm string, options BackchannelAuthenticationOptions) (*BackchannelAuthenticationResponse, error) {
+	const errMessage = "could not get backchannel authentication"
+
+	ctx, span := g.startSpan(ctx, "GetBackchannelAuthentication")
+	defer span.End()
+
+	if NilOrEmpty(options.ClientID) {
+		return nil, errors.New(errMessage + ": client id is required")
+	}
+
+	cibaURL, err := g.getBackchannelAuthenticationURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, *options.ClientID, PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	var result BackchannelAuthenticationResponse
+	resp, err := req.SetFormData(options.FormData()).
+		SetResult(&result).
+		Post(cibaURL)
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBackchannelToken asks the token endpoint once for the token of a backchannel authentication.
+// ErrAuthorizationPending and ErrSlowDown are returned while the user has not approved the authentication.
+func (g *GoCloak) GetBackchannelToken(ctx context.Context, realm string, options TokenOptions, authReqID string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "GetBackchannelToken")
+	defer span.End()
+
+	options.GrantType = StringP(cibaGrantType)
+	options.AuthReqID = &authReqID
+
+	return g.getPolledToken(ctx, realm, options)
+}
+
+// PollBackchannelToken polls the token endpoint until the user approved the backchannel authentication.
+// The interval of the authentication is respected and increased on slow_down responses.
+// ErrAuthorizationExpired or ErrAccessDenied is returned if the authentication can't complete anymore.
+func (g *GoCloak) PollBackchannelToken(ctx context.Context, realm string, options TokenOptions, authentication *BackchannelAuthenticationResponse) (*JWT, error) {
+	const errMessage = "could not get backchannel token"
+
+	ctx, span := g.startSpan(ctx, "PollBackchannelToken")
+	defer span.End()
+
+	if authentication == nil || authentication.AuthReqID == "" {
+		return nil, errors.New(errMessage + ": auth_req_id is required")
+	}
+
+	options.GrantType = StringP(cibaGrantType)
+	options.AuthReqID = StringP(authentication.AuthReqID)
+
+	return g.pollToken(ctx, realm, options, authentication.Interval, authentication.ExpiresIn)
+}
@@ -0,0 +1,63 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const cibaPath = "/realms/test/protocol/openid-connect/ext/ciba/auth"
+
+func TestBackchannelAuthentication(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle(cibaPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.BackchannelAuthenticationResponse{AuthReqID: "req-id", ExpiresIn: 120, Interval: 1})
+	})
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if len(server.recorded(tokenPath)) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "access"})
+	})
+
+	client := gocloak.NewClient(server.URL)
+	ctx := context.Background()
+	options := gocloak.TokenOptions{ClientID: gocloak.StringP("backend"), ClientSecret: gocloak.StringP("secret")}
+
+	authentication, err := client.GetBackchannelAuthentication(ctx, "test", gocloak.BackchannelAuthenticationOptions{
+		ClientID:       gocloak.StringP("backend"),
+		ClientSecret:   gocloak.StringP("secret"),
+		LoginHint:      gocloak.StringP("user@example.com"),
+		BindingMessage: gocloak.StringP("approve transfer"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "req-id", authentication.AuthReqID)
+	request := server.last(t, cibaPath)
+	require.Equal(t, "user@example.com", request.Form.Get("login_hint"))
+	require.Equal(t, "approve transfer", request.Form.Get("binding_message"))
+	require.Equal(t, "openid", request.Form.Get("scope"))
+	user, _, ok := request.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "backend", user)
+
+	_, err = client.GetBackchannelToken(ctx, "test", options, authentication.AuthReqID)
+	require.ErrorIs(t, err, gocloak.ErrAuthorizationPending)
+
+	token, err := client.PollBackchannelToken(ctx, "test", options, authentication)
+	require.NoError(t, err)
+	require.Equal(t, "access", token.AccessToken)
+	for _, poll := range server.recorded(tokenPath) {
+		require.Equal(t, "urn:openid:params:grant-type:ciba", poll.Form.Get("grant_type"))
+		require.Equal(t, "req-id", poll.Form.Get("auth_req_id"))
+	}
+}
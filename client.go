@@ -3,20 +3,22 @@ package gocloak
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/segmentio/ksuid"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/mod/semver"
 
 	"github.com/Nerzal/gocloak/v13/pkg/jwx"
@@ -24,20 +26,32 @@ import (
 
 // GoCloak provides functionalities to talk to Keycloak.
 type GoCloak struct {
-	basePath    string
-	certsCache  sync.Map
-	certsLock   sync.Mutex
-	restyClient *resty.Client
-	Config      struct {
-		CertsInvalidateTime time.Duration
-		authAdminRealms     string
-		authRealms          string
-		tokenEndpoint       string
-		revokeEndpoint      string
-		logoutEndpoint      string
-		openIDConnect       string
-		attackDetection     string
-		version             string
+	basePath           string
+	certsCache         certsCache
+	discoveryCache     discoveryCache
+	introspectionCache introspectionCache
+	hooks              clientHooks
+	restyClient        *resty.Client
+	Config             struct {
+		CertsInvalidateTime     time.Duration
+		CertsMinRefetchInterval time.Duration
+		DiscoveryInvalidateTime time.Duration
+		IntrospectionCacheTTL   time.Duration
+		authAdminRealms         string
+		authRealms              string
+		tokenEndpoint           string
+		revokeEndpoint          string
+		logoutEndpoint          string
+		openIDConnect           string
+		attackDetection         string
+		version                 string
+		hmacKeyFunc             func(ctx context.Context, realm, kid string) ([]byte, error)
+		openIDDiscovery         bool
+		mtls                    bool
+		clientAuthenticators    map[string]ClientAuthenticator
+		tracerProvider          trace.TracerProvider
+		propagator              propagation.TextMapPropagator
+		observer                Observer
 	}
 }
 
@@ -103,7 +117,7 @@ func (g *GoCloak) getServerVersion(ctx context.Context, token string) (string, e
 // GetRequest returns a request for calling endpoints.
 func (g *GoCloak) GetRequest(ctx context.Context) *resty.Request {
 	var err HTTPErrorResponse
-	return injectTracingHeaders(
+	return g.injectTraceContext(
 		ctx, g.restyClient.R().
 			SetContext(ctx).
 			SetError(&err),
@@ -146,20 +160,38 @@ func (g *GoCloak) GetRequestWithBasicAuth(ctx context.Context, clientID, clientS
 }
 
 func (g *GoCloak) getRequestingParty(ctx context.Context, token string, realm string, options RequestingPartyTokenOptions, res interface{}) (*resty.Response, error) {
+	tokenURL, err := g.getTokenURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get token endpoint")
+	}
+
 	return g.GetRequestWithBearerAuth(ctx, token).
 		SetFormData(options.FormData()).
 		SetFormDataFromValues(url.Values{"permission": PStringSlice(options.Permissions)}).
 		SetResult(&res).
-		Post(g.getRealmURL(realm, g.Config.tokenEndpoint))
+		Post(tokenURL)
 }
 
 func checkForError(resp *resty.Response, err error, errMessage string) error {
+	apiError := responseError(resp, err, errMessage)
+	traceResponse(resp, apiError)
+	if apiError == nil {
+		return nil
+	}
+
+	return apiError
+}
+
+func responseError(resp *resty.Response, err error, errMessage string) *APIError {
 	if err != nil {
-		return &APIError{
+		apiError := &APIError{
 			Code:    0,
 			Message: errors.Wrap(err, errMessage).Error(),
 			Type:    ParseAPIErrType(err),
+			err:     err,
 		}
+		setRequestDetails(apiError, resp)
+		return apiError
 	}
 
 	if resp == nil {
@@ -170,19 +202,12 @@ func checkForError(resp *resty.Response, err error, errMessage string) error {
 	}
 
 	if resp.IsError() {
-		var msg string
-
-		if e, ok := resp.Error().(*HTTPErrorResponse); ok && e.NotEmpty() {
-			msg = fmt.Sprintf("%s: %s", resp.Status(), e)
-		} else {
-			msg = resp.Status()
+		e, ok := resp.Error().(*HTTPErrorResponse)
+		if !ok || !e.NotEmpty() {
+			return newAPIError(resp, resp.Status(), nil)
 		}
 
-		return &APIError{
-			Code:    resp.StatusCode(),
-			Message: msg,
-			Type:    ParseAPIErrType(err),
-		}
+		return newAPIError(resp, fmt.Sprintf("%s: %s", resp.Status(), e), e)
 	}
 
 	return nil
@@ -197,7 +222,7 @@ func getID(resp *resty.Response) string {
 
 func findUsedKey(usedKeyID string, keys []CertResponseKey) *CertResponseKey {
 	for _, key := range keys {
-		if *(key.Kid) == usedKeyID {
+		if PString(key.Kid) == usedKeyID {
 			return &key
 		}
 	}
@@ -205,28 +230,6 @@ func findUsedKey(usedKeyID string, keys []CertResponseKey) *CertResponseKey {
 	return nil
 }
 
-func injectTracingHeaders(ctx context.Context, req *resty.Request) *resty.Request {
-	// look for span in context, do nothing if span is not found
-	span := opentracing.SpanFromContext(ctx)
-	if span == nil {
-		return req
-	}
-
-	// look for tracer in context, use global tracer if not found
-	tracer, ok := ctx.Value(tracerContextKey).(opentracing.Tracer)
-	if !ok || tracer == nil {
-		tracer = opentracing.GlobalTracer()
-	}
-
-	// inject tracing header into request
-	err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
-	if err != nil {
-		return req
-	}
-
-	return req
-}
-
 // ===============
 // Keycloak client
 // ===============
@@ -239,6 +242,8 @@ func NewClient(basePath string, options ...func(*GoCloak)) *GoCloak {
 	}
 
 	c.Config.CertsInvalidateTime = 10 * time.Minute
+	c.Config.CertsMinRefetchInterval = 10 * time.Second
+	c.Config.DiscoveryInvalidateTime = time.Hour
 	c.Config.authAdminRealms = makeURL("admin", "realms")
 	c.Config.authRealms = makeURL("realms")
 	c.Config.tokenEndpoint = makeURL("protocol", "openid-connect", "token")
@@ -250,6 +255,7 @@ func NewClient(basePath string, options ...func(*GoCloak)) *GoCloak {
 	for _, option := range options {
 		option(&c)
 	}
+	c.registerHooks()
 
 	return &c
 }
@@ -261,8 +267,10 @@ func (g *GoCloak) RestyClient() *resty.Client {
 }
 
 // SetRestyClient overwrites the internal resty g.
+// The hooks of the options, e.g. of SetDPoP, SetRetryPolicy or SetLogger, are registered on the new client.
 func (g *GoCloak) SetRestyClient(restyClient *resty.Client) {
 	g.restyClient = restyClient
+	g.registerHooks()
 }
 
 func (g *GoCloak) getRealmURL(realm string, path ...string) string {
@@ -339,9 +347,156 @@ func SetCertCacheInvalidationTime(duration time.Duration) func(g *GoCloak) {
 	}
 }
 
+// SetCertsMinRefetchInterval sets the minimum interval between refetches of a realm's certs
+// triggered by tokens signed with an unknown key id, and between attempts to refresh stale certs after a failed fetch
+func SetCertsMinRefetchInterval(interval time.Duration) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.CertsMinRefetchInterval = interval
+	}
+}
+
+// SetOpenIDDiscovery makes the token, userinfo, introspection, revocation, logout and certs calls
+// use the endpoints advertised in the .well-known/openid-configuration of the realm.
+func SetOpenIDDiscovery() func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.openIDDiscovery = true
+	}
+}
+
+// SetDiscoveryCacheInvalidationTime sets how long discovery documents are cached
+func SetDiscoveryCacheInvalidationTime(duration time.Duration) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.DiscoveryInvalidateTime = duration
+	}
+}
+
+// SetHMACKeyFunc sets the function which provides the secrets to verify HMAC signed tokens (HS256, HS384, HS512).
+// HMAC secrets are not published in the certs of a realm. The function returns nil if it doesn't know the key id.
+func SetHMACKeyFunc(keyFunc func(ctx context.Context, realm, kid string) ([]byte, error)) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.hmacKeyFunc = keyFunc
+	}
+}
+
+// SetIntrospectionCache caches the results of IntrospectToken for the given ttl,
+// active results at most until the token expires
+func SetIntrospectionCache(ttl time.Duration) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.IntrospectionCacheTTL = ttl
+	}
+}
+
+// SetClientAuthenticator sets how the confidential client with the given id authenticates, e.g. with ClientSecretJWT or PrivateKeyJWT.
+// It is used by every call which authenticates this client, unless the options of the call carry a ClientAuthenticator.
+// Other clients keep authenticating with their secret.
+func SetClientAuthenticator(clientID string, authenticator ClientAuthenticator) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		if g.Config.clientAuthenticators == nil {
+			g.Config.clientAuthenticators = make(map[string]ClientAuthenticator)
+		}
+		g.Config.clientAuthenticators[clientID] = authenticator
+	}
+}
+
+// SetClientCertificate presents the certificate in every TLS handshake, e.g. for mutual TLS client authentication.
+// If OpenID discovery is enabled, the mtls_endpoint_aliases of the realm are used.
+// Certificates of PKCS#12 keystores can be loaded with LoadPKCS12Certificate.
+func SetClientCertificate(certificate tls.Certificate) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.restyClient.SetCertificates(certificate)
+		g.Config.mtls = true
+	}
+}
+
+// SetDPoP sends a DPoP proof created by the signer with every request, so Keycloak issues DPoP-bound tokens.
+// Access tokens are sent with the DPoP authorization scheme. A request rejected for a missing DPoP nonce
+// is retried with the nonce issued by the server, other failed requests are only retried with SetRetryPolicy.
+// Proofs are created in the pre-request hook of the resty client after the hooks of AddPreRequestHook,
+// so it must not be replaced with resty.Client.SetPreRequestHook.
+func SetDPoP(signer *DPoPSigner) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.hooks.dpop = signer
+	}
+}
+
+// AddPreRequestHook runs the hook right before every request is sent, after the hooks added before.
+// Use it instead of resty.Client.SetPreRequestHook, which would replace the hook creating DPoP proofs.
+func AddPreRequestHook(hook resty.PreRequestHook) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.hooks.preRequest = append(g.hooks.preRequest, hook)
+	}
+}
+
+// SetRetryPolicy retries failed requests as configured by the policy, see RetryPolicy.
+// Retries are logged as events on the span of the request, if the context carries one.
+func SetRetryPolicy(policy RetryPolicy) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		policy = policy.withDefaults()
+		g.hooks.retryPolicy = &policy
+	}
+}
+
+// SetRateLimit limits the requests of the client, see RateLimit.
+func SetRateLimit(limit RateLimit) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.getRateLimiter().global = newLimiter(limit)
+	}
+}
+
+// SetRealmRateLimit limits the requests to the endpoints of the realm on top of the limits of the client
+func SetRealmRateLimit(realm string, limit RateLimit) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.getRateLimiter().realms[realm] = newLimiter(limit)
+	}
+}
+
+// SetEndpointRateLimit limits the requests to the endpoint class on top of the limits of the client
+func SetEndpointRateLimit(class EndpointClass, limit RateLimit) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.getRateLimiter().classes[class] = newLimiter(limit)
+	}
+}
+
+// SetTracerProvider sets the OpenTelemetry tracer provider for the spans of gocloak calls.
+// The global tracer provider is used by default.
+func SetTracerProvider(provider trace.TracerProvider) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.tracerProvider = provider
+	}
+}
+
+// SetTextMapPropagator sets the propagator injecting the trace context into the requests to Keycloak.
+// W3C trace context headers are sent by default.
+func SetTextMapPropagator(propagator propagation.TextMapPropagator) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.propagator = propagator
+	}
+}
+
+// SetObserver reports the metrics of every request to Keycloak and the events of the certs cache to the observer.
+func SetObserver(observer Observer) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.Config.observer = observer
+		g.hooks.observer = &requestObserver{observer: observer}
+	}
+}
+
+// SetLogger logs the requests to Keycloak and their responses at debug level and failed requests at warn level.
+// Credentials in headers, form fields, query parameters and JSON bodies are redacted, other bodies are only logged with their size.
+// Unlike the debug mode of the resty client, this is safe to use in production.
+func SetLogger(logger *slog.Logger) func(g *GoCloak) {
+	return func(g *GoCloak) {
+		g.hooks.logger = &requestLogger{logger: logger}
+	}
+}
+
 // GetServerInfo fetches the server info.
 func (g *GoCloak) GetServerInfo(ctx context.Context, accessToken string) (*ServerInfoRepresentation, error) {
 	errMessage := "could not get server info"
+
+	ctx, span := g.startSpan(ctx, "GetServerInfo")
+	defer span.End()
+
 	var result *ServerInfoRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -359,10 +514,18 @@ func (g *GoCloak) GetServerInfo(ctx context.Context, accessToken string) (*Serve
 func (g *GoCloak) GetUserInfo(ctx context.Context, accessToken, realm string) (*UserInfo, error) {
 	const errMessage = "could not get user info"
 
+	ctx, span := g.startSpan(ctx, "GetUserInfo")
+	defer span.End()
+
+	userInfoURL, err := g.getUserInfoURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
 	var result UserInfo
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		SetResult(&result).
-		Get(g.getRealmURL(realm, g.Config.openIDConnect, "userinfo"))
+		Get(userInfoURL)
 
 	if err := checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -375,10 +538,18 @@ func (g *GoCloak) GetUserInfo(ctx context.Context, accessToken, realm string) (*
 func (g *GoCloak) GetRawUserInfo(ctx context.Context, accessToken, realm string) (map[string]interface{}, error) {
 	const errMessage = "could not get user info"
 
+	ctx, span := g.startSpan(ctx, "GetRawUserInfo")
+	defer span.End()
+
+	userInfoURL, err := g.getUserInfoURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
 	var result map[string]interface{}
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		SetResult(&result).
-		Get(g.getRealmURL(realm, g.Config.openIDConnect, "userinfo"))
+		Get(userInfoURL)
 
 	if err := checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -390,10 +561,15 @@ func (g *GoCloak) GetRawUserInfo(ctx context.Context, accessToken, realm string)
 func (g *GoCloak) getNewCerts(ctx context.Context, realm string) (*CertResponse, error) {
 	const errMessage = "could not get newCerts"
 
+	jwksURL, err := g.getJWKSURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
 	var result CertResponse
 	resp, err := g.GetRequest(ctx).
 		SetResult(&result).
-		Get(g.getRealmURL(realm, g.Config.openIDConnect, "certs"))
+		Get(jwksURL)
 
 	if err := checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -403,69 +579,181 @@ func (g *GoCloak) getNewCerts(ctx context.Context, realm string) (*CertResponse,
 }
 
 // GetCerts fetches certificates for the given realm from the public /open-id-connect/certs endpoint
+// The certs are cached and refreshed in the background shortly before CertsInvalidateTime has passed.
+// If the certs can't be refreshed, the cached certs are returned.
 func (g *GoCloak) GetCerts(ctx context.Context, realm string) (*CertResponse, error) {
 	const errMessage = "could not get certs"
 
-	if cert, ok := g.certsCache.Load(realm); ok {
-		return cert.(*CertResponse), nil
-	}
+	ctx, span := g.startSpan(ctx, "GetCerts")
+	defer span.End()
 
-	g.certsLock.Lock()
-	defer g.certsLock.Unlock()
+	invalidateTime := g.Config.CertsInvalidateTime
+	entry := g.certsCache.entry(realm)
 
-	if cert, ok := g.certsCache.Load(realm); ok {
-		return cert.(*CertResponse), nil
+	cert, fetchedAt := g.certsCache.load(entry)
+	if cert != nil && time.Since(fetchedAt) < invalidateTime {
+		g.observeCertsCache(ctx, realm, CertsCacheHit)
+		refreshAfter := invalidateTime - invalidateTime/4
+		if time.Since(fetchedAt) >= refreshAfter {
+			g.refreshCertsInBackground(ctx, realm, entry, func(fetchedAt time.Time) bool {
+				return time.Since(fetchedAt) >= refreshAfter
+			})
+		}
+		return cert, nil
 	}
 
-	cert, err := g.getNewCerts(ctx, realm)
+	g.observeCertsCache(ctx, realm, CertsCacheMiss)
+	cert, err := g.refreshCerts(ctx, realm, entry, func(fetchedAt time.Time) bool {
+		return time.Since(fetchedAt) >= invalidateTime
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
 	}
 
-	g.certsCache.Store(realm, cert)
-	time.AfterFunc(g.Config.CertsInvalidateTime, func() {
-		g.certsCache.Delete(realm)
+	return cert, nil
+}
+
+// GetCertKey returns the key with the given key id from the certs of the realm.
+// If the key is unknown, e.g. after a key rotation, the certs are refetched at most once per CertsMinRefetchInterval.
+func (g *GoCloak) GetCertKey(ctx context.Context, realm, kid string) (*CertResponseKey, error) {
+	const errMessage = "could not get cert key"
+
+	ctx, span := g.startSpan(ctx, "GetCertKey")
+	defer span.End()
+
+	cert, err := g.GetCerts(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	if cert.Keys != nil {
+		if key := findUsedKey(kid, *cert.Keys); key != nil {
+			return key, nil
+		}
+	}
+
+	// HMAC keys are not published in the certs of the realm
+	if g.Config.hmacKeyFunc != nil {
+		secret, err := g.Config.hmacKeyFunc(ctx, realm, kid)
+		if err != nil {
+			return nil, errors.Wrap(err, errMessage)
+		}
+		if secret != nil {
+			return &CertResponseKey{
+				Kid: &kid,
+				Kty: StringP("oct"),
+				K:   StringP(base64.RawURLEncoding.EncodeToString(secret)),
+			}, nil
+		}
+	}
+
+	missedAt := time.Now()
+	entry := g.certsCache.entry(realm)
+	if !g.certsCache.allowRefetch(entry, missedAt, g.Config.CertsMinRefetchInterval) {
+		return nil, errors.Wrap(errCertKeyNotFound, errMessage)
+	}
+
+	cert, err = g.refreshCerts(ctx, realm, entry, func(fetchedAt time.Time) bool {
+		return fetchedAt.Before(missedAt)
 	})
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	if cert.Keys != nil {
+		if key := findUsedKey(kid, *cert.Keys); key != nil {
+			return key, nil
+		}
+	}
 
-	return cert, nil
+	return nil, errors.Wrap(errCertKeyNotFound, errMessage)
 }
 
-// GetIssuer gets the issuer of the given realm
-func (g *GoCloak) GetIssuer(ctx context.Context, realm string) (*IssuerResponse, error) {
-	const errMessage = "could not get issuer"
+// GetOpenIDConfiguration fetches the OpenID Connect discovery document of the given realm.
+// The document is cached for DiscoveryInvalidateTime.
+func (g *GoCloak) GetOpenIDConfiguration(ctx context.Context, realm string) (*OpenIDConfiguration, error) {
+	const errMessage = "could not get openid configuration"
 
-	var result IssuerResponse
+	ctx, span := g.startSpan(ctx, "GetOpenIDConfiguration")
+	defer span.End()
+
+	cacheKey := makeURL(realm, "openid-configuration")
+	if cached, ok := g.discoveryCache.load(cacheKey, g.Config.DiscoveryInvalidateTime); ok {
+		return cached.(*OpenIDConfiguration), nil
+	}
+
+	var result OpenIDConfiguration
 	resp, err := g.GetRequest(ctx).
 		SetResult(&result).
-		Get(g.getRealmURL(realm))
+		Get(g.getRealmURL(realm, ".well-known", "openid-configuration"))
 
 	if err := checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
+	g.discoveryCache.store(cacheKey, &result)
+
 	return &result, nil
 }
 
-// RetrospectToken calls the openid-connect introspect endpoint
-func (g *GoCloak) RetrospectToken(ctx context.Context, accessToken, clientID, clientSecret, realm string) (*IntroSpectTokenResult, error) {
-	const errMessage = "could not introspect requesting party token"
+// GetUMA2Configuration fetches the UMA 2.0 discovery document of the given realm.
+// The document is cached for DiscoveryInvalidateTime.
+func (g *GoCloak) GetUMA2Configuration(ctx context.Context, realm string) (*UMA2Configuration, error) {
+	const errMessage = "could not get uma2 configuration"
 
-	var result IntroSpectTokenResult
-	resp, err := g.GetRequestWithBasicAuth(ctx, clientID, clientSecret).
-		SetFormData(map[string]string{
-			"token_type_hint": "requesting_party_token",
-			"token":           accessToken,
-		}).
+	ctx, span := g.startSpan(ctx, "GetUMA2Configuration")
+	defer span.End()
+
+	cacheKey := makeURL(realm, "uma2-configuration")
+	if cached, ok := g.discoveryCache.load(cacheKey, g.Config.DiscoveryInvalidateTime); ok {
+		return cached.(*UMA2Configuration), nil
+	}
+
+	var result UMA2Configuration
+	resp, err := g.GetRequest(ctx).
 		SetResult(&result).
-		Post(g.getRealmURL(realm, g.Config.tokenEndpoint, "introspect"))
+		Get(g.getRealmURL(realm, ".well-known", "uma2-configuration"))
 
 	if err := checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
+	g.discoveryCache.store(cacheKey, &result)
+
 	return &result, nil
 }
 
+// GetIssuer gets the issuer of the given realm
+func (g *GoCloak) GetIssuer(ctx context.Context, realm string) (*IssuerResponse, error) {
+	const errMessage = "could not get issuer"
+
+	ctx, span := g.startSpan(ctx, "GetIssuer")
+	defer span.End()
+
+	var result IssuerResponse
+	resp, err := g.GetRequest(ctx).
+		SetResult(&result).
+		Get(g.getRealmURL(realm))
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RetrospectToken calls the openid-connect introspect endpoint with a requesting party token.
+// Use IntrospectToken to introspect other token types.
+func (g *GoCloak) RetrospectToken(ctx context.Context, accessToken, clientID, clientSecret, realm string) (*IntroSpectTokenResult, error) {
+	ctx, span := g.startSpan(ctx, "RetrospectToken")
+	defer span.End()
+
+	return g.IntrospectToken(ctx, realm, IntrospectTokenOptions{
+		ClientID:      &clientID,
+		ClientSecret:  &clientSecret,
+		Token:         &accessToken,
+		TokenTypeHint: StringP(TokenTypeHintRequestingPartyToken),
+	})
+}
+
 func (g *GoCloak) decodeAccessTokenWithClaims(ctx context.Context, accessToken, realm string, claims jwt.Claims) (*jwt.Token, error) {
 	const errMessage = "could not decode access token"
 	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
@@ -475,28 +763,42 @@ func (g *GoCloak) decodeAccessTokenWithClaims(ctx context.Context, accessToken,
 		return nil, errors.Wrap(err, errMessage)
 	}
 
-	certResult, err := g.GetCerts(ctx, realm)
+	usedKey, err := g.GetCertKey(ctx, realm, decodedHeader.Kid)
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
 	}
-	if certResult.Keys == nil {
-		return nil, errors.Wrap(errors.New("there is no keys to decode the token"), errMessage)
+
+	return decodeAccessTokenWithKey(accessToken, decodedHeader.Alg, usedKey, claims)
+}
+
+func decodeAccessTokenWithKey(accessToken, alg string, key *CertResponseKey, claims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	if use := PString(key.Use); use != "" && use != "sig" {
+		return nil, fmt.Errorf("key is not meant for signatures: %s", use)
 	}
-	usedKey := findUsedKey(decodedHeader.Kid, *certResult.Keys)
-	if usedKey == nil {
-		return nil, errors.Wrap(errors.New("cannot find a key to decode the token"), errMessage)
+	if err := jwx.CheckKeyAlgorithm(alg, PString(key.Alg), PString(key.Kty), PString(key.Crv)); err != nil {
+		return nil, err
 	}
 
-	if strings.HasPrefix(decodedHeader.Alg, "ES") {
-		return jwx.DecodeAccessTokenECDSACustomClaims(accessToken, usedKey.X, usedKey.Y, usedKey.Crv, claims)
-	} else if strings.HasPrefix(decodedHeader.Alg, "RS") {
-		return jwx.DecodeAccessTokenRSACustomClaims(accessToken, usedKey.E, usedKey.N, claims)
+	switch {
+	case strings.HasPrefix(alg, "ES"):
+		return jwx.DecodeAccessTokenECDSACustomClaims(accessToken, key.X, key.Y, key.Crv, claims, opts...)
+	case strings.HasPrefix(alg, "RS"):
+		return jwx.DecodeAccessTokenRSACustomClaims(accessToken, key.E, key.N, claims, opts...)
+	case strings.HasPrefix(alg, "PS"):
+		return jwx.DecodeAccessTokenRSAPSSCustomClaims(accessToken, key.E, key.N, claims, opts...)
+	case strings.HasPrefix(alg, "HS"):
+		return jwx.DecodeAccessTokenHMACCustomClaims(accessToken, key.K, claims, opts...)
+	case alg == "EdDSA":
+		return jwx.DecodeAccessTokenEdDSACustomClaims(accessToken, key.X, key.Crv, claims, opts...)
 	}
 	return nil, fmt.Errorf("unsupported algorithm")
 }
 
 // DecodeAccessToken decodes the accessToken
 func (g *GoCloak) DecodeAccessToken(ctx context.Context, accessToken, realm string) (*jwt.Token, *jwt.MapClaims, error) {
+	ctx, span := g.startSpan(ctx, "DecodeAccessToken")
+	defer span.End()
+
 	claims := jwt.MapClaims{}
 	token, err := g.decodeAccessTokenWithClaims(ctx, accessToken, realm, claims)
 	if err != nil {
@@ -507,6 +809,9 @@ func (g *GoCloak) DecodeAccessToken(ctx context.Context, accessToken, realm stri
 
 // DecodeAccessTokenCustomClaims decodes the accessToken and writes claims into the given claims
 func (g *GoCloak) DecodeAccessTokenCustomClaims(ctx context.Context, accessToken, realm string, claims jwt.Claims) (*jwt.Token, error) {
+	ctx, span := g.startSpan(ctx, "DecodeAccessTokenCustomClaims")
+	defer span.End()
+
 	return g.decodeAccessTokenWithClaims(ctx, accessToken, realm, claims)
 }
 
@@ -514,30 +819,44 @@ func (g *GoCloak) DecodeAccessTokenCustomClaims(ctx context.Context, accessToken
 func (g *GoCloak) GetToken(ctx context.Context, realm string, options TokenOptions) (*JWT, error) {
 	const errMessage = "could not get token"
 
-	var token JWT
-	var req *resty.Request
+	ctx, span := g.startSpan(ctx, "GetToken")
+	defer span.End()
 
-	if !NilOrEmpty(options.ClientSecret) {
-		req = g.GetRequestWithBasicAuth(ctx, *options.ClientID, *options.ClientSecret)
-	} else {
-		req = g.GetRequest(ctx)
+	token, resp, err := g.postToken(ctx, realm, options)
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
 	}
 
-	resp, err := req.SetFormData(options.FormData()).
-		SetResult(&token).
-		Post(g.getRealmURL(realm, g.Config.tokenEndpoint))
+	return token, nil
+}
 
-	if err := checkForError(resp, err, errMessage); err != nil {
-		return nil, err
+// postToken sends the options to the token endpoint of the realm and leaves the error handling to the caller
+func (g *GoCloak) postToken(ctx context.Context, realm string, options TokenOptions) (*JWT, *resty.Response, error) {
+	tokenURL, err := g.getTokenURL(ctx, realm)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get token endpoint")
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, PString(options.ClientID), PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return &token, nil
+	var token JWT
+	resp, err := req.SetFormData(options.FormData()).
+		SetResult(&token).
+		Post(tokenURL)
+
+	return &token, resp, err
 }
 
 // GetRequestingPartyToken returns a requesting party token with permissions granted by the server
 func (g *GoCloak) GetRequestingPartyToken(ctx context.Context, token, realm string, options RequestingPartyTokenOptions) (*JWT, error) {
 	const errMessage = "could not get requesting party token"
 
+	ctx, span := g.startSpan(ctx, "GetRequestingPartyToken")
+	defer span.End()
+
 	var res JWT
 
 	resp, err := g.getRequestingParty(ctx, token, realm, options, &res)
@@ -552,6 +871,9 @@ func (g *GoCloak) GetRequestingPartyToken(ctx context.Context, token, realm stri
 func (g *GoCloak) GetRequestingPartyPermissions(ctx context.Context, token, realm string, options RequestingPartyTokenOptions) (*[]RequestingPartyPermission, error) {
 	const errMessage = "could not get requesting party token"
 
+	ctx, span := g.startSpan(ctx, "GetRequestingPartyPermissions")
+	defer span.End()
+
 	var res []RequestingPartyPermission
 
 	options.ResponseMode = StringP("permissions")
@@ -567,6 +889,9 @@ func (g *GoCloak) GetRequestingPartyPermissions(ctx context.Context, token, real
 func (g *GoCloak) GetRequestingPartyPermissionDecision(ctx context.Context, token, realm string, options RequestingPartyTokenOptions) (*RequestingPartyPermissionDecision, error) {
 	const errMessage = "could not get requesting party token"
 
+	ctx, span := g.startSpan(ctx, "GetRequestingPartyPermissionDecision")
+	defer span.End()
+
 	var res RequestingPartyPermissionDecision
 
 	options.ResponseMode = StringP("decision")
@@ -582,6 +907,9 @@ func (g *GoCloak) GetRequestingPartyPermissionDecision(ctx context.Context, toke
 // RefreshToken refreshes the given token.
 // May return a *APIError with further details about the issue.
 func (g *GoCloak) RefreshToken(ctx context.Context, refreshToken, clientID, clientSecret, realm string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "RefreshToken")
+	defer span.End()
+
 	return g.GetToken(ctx, realm, TokenOptions{
 		ClientID:     &clientID,
 		ClientSecret: &clientSecret,
@@ -592,6 +920,9 @@ func (g *GoCloak) RefreshToken(ctx context.Context, refreshToken, clientID, clie
 
 // LoginAdmin performs a login with Admin client
 func (g *GoCloak) LoginAdmin(ctx context.Context, username, password, realm string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "LoginAdmin")
+	defer span.End()
+
 	return g.GetToken(ctx, realm, TokenOptions{
 		ClientID:  StringP(adminClientID),
 		GrantType: StringP("password"),
@@ -602,6 +933,9 @@ func (g *GoCloak) LoginAdmin(ctx context.Context, username, password, realm stri
 
 // LoginClient performs a login with client credentials
 func (g *GoCloak) LoginClient(ctx context.Context, clientID, clientSecret, realm string, scopes ...string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "LoginClient")
+	defer span.End()
+
 	opts := TokenOptions{
 		ClientID:     &clientID,
 		ClientSecret: &clientSecret,
@@ -617,35 +951,42 @@ func (g *GoCloak) LoginClient(ctx context.Context, clientID, clientSecret, realm
 
 // LoginClientTokenExchange will exchange the presented token for a user's token
 // Requires Token-Exchange is enabled: https://www.keycloak.org/docs/latest/securing_apps/index.html#_token-exchange
+// Use ExchangeToken for other token types, scopes or multiple audiences.
 func (g *GoCloak) LoginClientTokenExchange(ctx context.Context, clientID, token, clientSecret, realm, targetClient, userID string) (*JWT, error) {
-	tokenOptions := TokenOptions{
+	ctx, span := g.startSpan(ctx, "LoginClientTokenExchange")
+	defer span.End()
+
+	options := TokenExchangeOptions{
 		ClientID:           &clientID,
 		ClientSecret:       &clientSecret,
-		GrantType:          StringP("urn:ietf:params:oauth:grant-type:token-exchange"),
 		SubjectToken:       &token,
-		RequestedTokenType: StringP("urn:ietf:params:oauth:token-type:refresh_token"),
-		Audience:           &targetClient,
+		RequestedTokenType: StringP(TokenTypeRefreshToken),
+	}
+	if targetClient != "" {
+		options.Audiences = &[]string{targetClient}
 	}
 	if userID != "" {
-		tokenOptions.RequestedSubject = &userID
+		options.RequestedSubject = &userID
 	}
-	return g.GetToken(ctx, realm, tokenOptions)
+	return g.ExchangeToken(ctx, realm, options)
 }
 
 // DirectNakedImpersonationTokenExchange performs "Direct Naked Impersonation"
 // See: https://www.keycloak.org/docs/latest/securing_apps/index.html#direct-naked-impersonation
 func (g *GoCloak) DirectNakedImpersonationTokenExchange(ctx context.Context, clientID, clientSecret, realm, userID string) (*JWT, error) {
-	tokenOptions := TokenOptions{
+	ctx, span := g.startSpan(ctx, "DirectNakedImpersonationTokenExchange")
+	defer span.End()
+
+	return g.ExchangeToken(ctx, realm, TokenExchangeOptions{
 		ClientID:           &clientID,
 		ClientSecret:       &clientSecret,
-		GrantType:          StringP("urn:ietf:params:oauth:grant-type:token-exchange"),
-		RequestedTokenType: StringP("urn:ietf:params:oauth:token-type:refresh_token"),
-		RequestedSubject:   StringP(userID),
-	}
-	return g.GetToken(ctx, realm, tokenOptions)
+		RequestedTokenType: StringP(TokenTypeRefreshToken),
+		RequestedSubject:   &userID,
+	})
 }
 
 // LoginClientSignedJWT performs a login with client credentials and signed jwt claims
+// For other grants or signing options use PrivateKeyJWT as ClientAuthenticator.
 func (g *GoCloak) LoginClientSignedJWT(
 	ctx context.Context,
 	clientID,
@@ -654,6 +995,9 @@ func (g *GoCloak) LoginClientSignedJWT(
 	signedMethod jwt.SigningMethod,
 	expiresAt *jwt.NumericDate,
 ) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "LoginClientSignedJWT")
+	defer span.End()
+
 	claims := jwt.RegisteredClaims{
 		ExpiresAt: expiresAt,
 		Issuer:    clientID,
@@ -676,8 +1020,29 @@ func (g *GoCloak) LoginClientSignedJWT(
 	})
 }
 
+// LoginClientTLS performs a login with client credentials, authenticating the client with its TLS certificate
+// (tls_client_auth or self_signed_tls_client_auth). The certificate is set with SetClientCertificate.
+func (g *GoCloak) LoginClientTLS(ctx context.Context, clientID, realm string, scopes ...string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "LoginClientTLS")
+	defer span.End()
+
+	opts := TokenOptions{
+		ClientID:  &clientID,
+		GrantType: StringP("client_credentials"),
+	}
+
+	if len(scopes) > 0 {
+		opts.Scope = StringP(strings.Join(scopes, " "))
+	}
+
+	return g.GetToken(ctx, realm, opts)
+}
+
 // Login performs a login with user credentials and a client
 func (g *GoCloak) Login(ctx context.Context, clientID, clientSecret, realm, username, password string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "Login")
+	defer span.End()
+
 	return g.GetToken(ctx, realm, TokenOptions{
 		ClientID:     &clientID,
 		ClientSecret: &clientSecret,
@@ -690,6 +1055,9 @@ func (g *GoCloak) Login(ctx context.Context, clientID, clientSecret, realm, user
 
 // LoginOtp performs a login with user credentials and otp token
 func (g *GoCloak) LoginOtp(ctx context.Context, clientID, clientSecret, realm, username, password, totp string) (*JWT, error) {
+	ctx, span := g.startSpan(ctx, "LoginOtp")
+	defer span.End()
+
 	return g.GetToken(ctx, realm, TokenOptions{
 		ClientID:     &clientID,
 		ClientSecret: &clientSecret,
@@ -704,12 +1072,24 @@ func (g *GoCloak) LoginOtp(ctx context.Context, clientID, clientSecret, realm, u
 func (g *GoCloak) Logout(ctx context.Context, clientID, clientSecret, realm, refreshToken string) error {
 	const errMessage = "could not logout"
 
-	resp, err := g.GetRequestWithBasicAuth(ctx, clientID, clientSecret).
-		SetFormData(map[string]string{
-			"client_id":     clientID,
-			"refresh_token": refreshToken,
-		}).
-		Post(g.getRealmURL(realm, g.Config.logoutEndpoint))
+	ctx, span := g.startSpan(ctx, "Logout")
+	defer span.End()
+
+	endSessionURL, err := g.getEndSessionURL(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, clientID, clientSecret, nil)
+	if err != nil {
+		return errors.Wrap(err, errMessage)
+	}
+
+	resp, err := req.SetFormData(map[string]string{
+		"client_id":     clientID,
+		"refresh_token": refreshToken,
+	}).
+		Post(endSessionURL)
 
 	return checkForError(resp, err, errMessage)
 }
@@ -718,12 +1098,20 @@ func (g *GoCloak) Logout(ctx context.Context, clientID, clientSecret, realm, ref
 func (g *GoCloak) LogoutPublicClient(ctx context.Context, clientID, realm, accessToken, refreshToken string) error {
 	const errMessage = "could not logout public client"
 
+	ctx, span := g.startSpan(ctx, "LogoutPublicClient")
+	defer span.End()
+
+	endSessionURL, err := g.getEndSessionURL(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, errMessage)
+	}
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		SetFormData(map[string]string{
 			"client_id":     clientID,
 			"refresh_token": refreshToken,
 		}).
-		Post(g.getRealmURL(realm, g.Config.logoutEndpoint))
+		Post(endSessionURL)
 
 	return checkForError(resp, err, errMessage)
 }
@@ -732,6 +1120,9 @@ func (g *GoCloak) LogoutPublicClient(ctx context.Context, clientID, realm, acces
 func (g *GoCloak) LogoutAllSessions(ctx context.Context, accessToken, realm, userID string) error {
 	const errMessage = "could not logout"
 
+	ctx, span := g.startSpan(ctx, "LogoutAllSessions")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		Post(g.getAdminRealmURL(realm, "users", userID, "logout"))
 
@@ -742,6 +1133,9 @@ func (g *GoCloak) LogoutAllSessions(ctx context.Context, accessToken, realm, use
 func (g *GoCloak) RevokeUserConsents(ctx context.Context, accessToken, realm, userID, clientID string) error {
 	const errMessage = "could not revoke consents"
 
+	ctx, span := g.startSpan(ctx, "RevokeUserConsents")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "consents", clientID))
 
@@ -752,6 +1146,9 @@ func (g *GoCloak) RevokeUserConsents(ctx context.Context, accessToken, realm, us
 func (g *GoCloak) LogoutUserSession(ctx context.Context, accessToken, realm, session string) error {
 	const errMessage = "could not logout"
 
+	ctx, span := g.startSpan(ctx, "LogoutUserSession")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		Delete(g.getAdminRealmURL(realm, "sessions", session))
 
@@ -762,6 +1159,9 @@ func (g *GoCloak) LogoutUserSession(ctx context.Context, accessToken, realm, ses
 func (g *GoCloak) ExecuteActionsEmail(ctx context.Context, token, realm string, params ExecuteActionsEmail) error {
 	const errMessage = "could not execute actions email"
 
+	ctx, span := g.startSpan(ctx, "ExecuteActionsEmail")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return errors.Wrap(err, errMessage)
@@ -779,6 +1179,9 @@ func (g *GoCloak) ExecuteActionsEmail(ctx context.Context, token, realm string,
 func (g *GoCloak) SendVerifyEmail(ctx context.Context, token, userID, realm string, params ...SendVerificationMailParams) error {
 	const errMessage = "failed to send verify email"
 
+	ctx, span := g.startSpan(ctx, "SendVerifyEmail")
+	defer span.End()
+
 	queryParams := map[string]string{}
 	if params != nil {
 		if params[0].ClientID != nil {
@@ -801,6 +1204,9 @@ func (g *GoCloak) SendVerifyEmail(ctx context.Context, token, userID, realm stri
 func (g *GoCloak) CreateGroup(ctx context.Context, token, realm string, group Group) (string, error) {
 	const errMessage = "could not create group"
 
+	ctx, span := g.startSpan(ctx, "CreateGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(group).
 		Post(g.getAdminRealmURL(realm, "groups"))
@@ -815,6 +1221,9 @@ func (g *GoCloak) CreateGroup(ctx context.Context, token, realm string, group Gr
 func (g *GoCloak) CreateChildGroup(ctx context.Context, token, realm, groupID string, group Group) (string, error) {
 	const errMessage = "could not create child group"
 
+	ctx, span := g.startSpan(ctx, "CreateChildGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(group).
 		Post(g.getAdminRealmURL(realm, "groups", groupID, "children"))
@@ -830,6 +1239,9 @@ func (g *GoCloak) CreateChildGroup(ctx context.Context, token, realm, groupID st
 func (g *GoCloak) CreateComponent(ctx context.Context, token, realm string, component Component) (string, error) {
 	const errMessage = "could not create component"
 
+	ctx, span := g.startSpan(ctx, "CreateComponent")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(component).
 		Post(g.getAdminRealmURL(realm, "components"))
@@ -845,6 +1257,9 @@ func (g *GoCloak) CreateComponent(ctx context.Context, token, realm string, comp
 func (g *GoCloak) CreateClient(ctx context.Context, accessToken, realm string, newClient Client) (string, error) {
 	const errMessage = "could not create client"
 
+	ctx, span := g.startSpan(ctx, "CreateClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		SetBody(newClient).
 		Post(g.getAdminRealmURL(realm, "clients"))
@@ -860,6 +1275,9 @@ func (g *GoCloak) CreateClient(ctx context.Context, accessToken, realm string, n
 func (g *GoCloak) CreateClientRepresentation(ctx context.Context, token, realm string, newClient Client) (*Client, error) {
 	const errMessage = "could not create client representation"
 
+	ctx, span := g.startSpan(ctx, "CreateClientRepresentation")
+	defer span.End()
+
 	var result Client
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -878,6 +1296,9 @@ func (g *GoCloak) CreateClientRepresentation(ctx context.Context, token, realm s
 func (g *GoCloak) CreateClientRole(ctx context.Context, token, realm, idOfClient string, role Role) (string, error) {
 	const errMessage = "could not create client role"
 
+	ctx, span := g.startSpan(ctx, "CreateClientRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(role).
 		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "roles"))
@@ -893,6 +1314,9 @@ func (g *GoCloak) CreateClientRole(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) CreateClientScope(ctx context.Context, token, realm string, scope ClientScope) (string, error) {
 	const errMessage = "could not create client scope"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScope")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(scope).
 		Post(g.getAdminRealmURL(realm, "client-scopes"))
@@ -908,6 +1332,9 @@ func (g *GoCloak) CreateClientScope(ctx context.Context, token, realm string, sc
 func (g *GoCloak) CreateClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID string, protocolMapper ProtocolMappers) (string, error) {
 	const errMessage = "could not create client scope protocol mapper"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScopeProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(protocolMapper).
 		Post(g.getAdminRealmURL(realm, "client-scopes", scopeID, "protocol-mappers", "models"))
@@ -923,6 +1350,9 @@ func (g *GoCloak) CreateClientScopeProtocolMapper(ctx context.Context, token, re
 func (g *GoCloak) UpdateGroup(ctx context.Context, token, realm string, updatedGroup Group) error {
 	const errMessage = "could not update group"
 
+	ctx, span := g.startSpan(ctx, "UpdateGroup")
+	defer span.End()
+
 	if NilOrEmpty(updatedGroup.ID) {
 		return errors.Wrap(errors.New("ID of a group required"), errMessage)
 	}
@@ -937,6 +1367,9 @@ func (g *GoCloak) UpdateGroup(ctx context.Context, token, realm string, updatedG
 func (g *GoCloak) UpdateGroupManagementPermissions(ctx context.Context, accessToken, realm string, idOfGroup string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not update group management permissions"
 
+	ctx, span := g.startSpan(ctx, "UpdateGroupManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -955,6 +1388,9 @@ func (g *GoCloak) UpdateGroupManagementPermissions(ctx context.Context, accessTo
 func (g *GoCloak) UpdateClient(ctx context.Context, token, realm string, updatedClient Client) error {
 	const errMessage = "could not update client"
 
+	ctx, span := g.startSpan(ctx, "UpdateClient")
+	defer span.End()
+
 	if NilOrEmpty(updatedClient.ID) {
 		return errors.Wrap(errors.New("ID of a client required"), errMessage)
 	}
@@ -970,6 +1406,9 @@ func (g *GoCloak) UpdateClient(ctx context.Context, token, realm string, updated
 func (g *GoCloak) UpdateClientRepresentation(ctx context.Context, accessToken, realm string, updatedClient Client) (*Client, error) {
 	const errMessage = "could not update client representation"
 
+	ctx, span := g.startSpan(ctx, "UpdateClientRepresentation")
+	defer span.End()
+
 	if NilOrEmpty(updatedClient.ID) {
 		return nil, errors.Wrap(errors.New("ID of a client required"), errMessage)
 	}
@@ -992,6 +1431,9 @@ func (g *GoCloak) UpdateClientRepresentation(ctx context.Context, accessToken, r
 func (g *GoCloak) UpdateClientManagementPermissions(ctx context.Context, accessToken, realm string, idOfClient string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not update client management permissions"
 
+	ctx, span := g.startSpan(ctx, "UpdateClientManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -1010,6 +1452,9 @@ func (g *GoCloak) UpdateClientManagementPermissions(ctx context.Context, accessT
 func (g *GoCloak) UpdateRole(ctx context.Context, token, realm, idOfClient string, role Role) error {
 	const errMessage = "could not update role"
 
+	ctx, span := g.startSpan(ctx, "UpdateRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(role).
 		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "roles", PString(role.Name)))
@@ -1021,6 +1466,9 @@ func (g *GoCloak) UpdateRole(ctx context.Context, token, realm, idOfClient strin
 func (g *GoCloak) UpdateClientScope(ctx context.Context, token, realm string, scope ClientScope) error {
 	const errMessage = "could not update client scope"
 
+	ctx, span := g.startSpan(ctx, "UpdateClientScope")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(scope).
 		Put(g.getAdminRealmURL(realm, "client-scopes", PString(scope.ID)))
@@ -1032,6 +1480,9 @@ func (g *GoCloak) UpdateClientScope(ctx context.Context, token, realm string, sc
 func (g *GoCloak) UpdateClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID string, protocolMapper ProtocolMappers) error {
 	const errMessage = "could not update client scope"
 
+	ctx, span := g.startSpan(ctx, "UpdateClientScopeProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(protocolMapper).
 		Put(g.getAdminRealmURL(realm, "client-scopes", scopeID, "protocol-mappers", "models", PString(protocolMapper.ID)))
@@ -1043,6 +1494,9 @@ func (g *GoCloak) UpdateClientScopeProtocolMapper(ctx context.Context, token, re
 func (g *GoCloak) DeleteGroup(ctx context.Context, token, realm, groupID string) error {
 	const errMessage = "could not delete group"
 
+	ctx, span := g.startSpan(ctx, "DeleteGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "groups", groupID))
 
@@ -1053,6 +1507,9 @@ func (g *GoCloak) DeleteGroup(ctx context.Context, token, realm, groupID string)
 func (g *GoCloak) DeleteClient(ctx context.Context, token, realm, idOfClient string) error {
 	const errMessage = "could not delete client"
 
+	ctx, span := g.startSpan(ctx, "DeleteClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient))
 
@@ -1063,6 +1520,9 @@ func (g *GoCloak) DeleteClient(ctx context.Context, token, realm, idOfClient str
 func (g *GoCloak) DeleteComponent(ctx context.Context, token, realm, componentID string) error {
 	const errMessage = "could not delete component"
 
+	ctx, span := g.startSpan(ctx, "DeleteComponent")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "components", componentID))
 
@@ -1073,6 +1533,9 @@ func (g *GoCloak) DeleteComponent(ctx context.Context, token, realm, componentID
 func (g *GoCloak) DeleteClientRepresentation(ctx context.Context, accessToken, realm, clientID string) error {
 	const errMessage = "could not delete client representation"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientRepresentation")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
 		Delete(g.getRealmURL(realm, "clients-registrations", "default", clientID))
 
@@ -1083,6 +1546,9 @@ func (g *GoCloak) DeleteClientRepresentation(ctx context.Context, accessToken, r
 func (g *GoCloak) DeleteClientRole(ctx context.Context, token, realm, idOfClient, roleName string) error {
 	const errMessage = "could not delete client role"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "roles", roleName))
 
@@ -1093,6 +1559,9 @@ func (g *GoCloak) DeleteClientRole(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) DeleteClientScope(ctx context.Context, token, realm, scopeID string) error {
 	const errMessage = "could not delete client scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScope")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "client-scopes", scopeID))
 
@@ -1103,6 +1572,9 @@ func (g *GoCloak) DeleteClientScope(ctx context.Context, token, realm, scopeID s
 func (g *GoCloak) DeleteClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID, protocolMapperID string) error {
 	const errMessage = "could not delete client scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScopeProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "client-scopes", scopeID, "protocol-mappers", "models", protocolMapperID))
 
@@ -1113,6 +1585,9 @@ func (g *GoCloak) DeleteClientScopeProtocolMapper(ctx context.Context, token, re
 func (g *GoCloak) GetClient(ctx context.Context, token, realm, idOfClient string) (*Client, error) {
 	const errMessage = "could not get client"
 
+	ctx, span := g.startSpan(ctx, "GetClient")
+	defer span.End()
+
 	var result Client
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1130,6 +1605,9 @@ func (g *GoCloak) GetClient(ctx context.Context, token, realm, idOfClient string
 func (g *GoCloak) GetClientRepresentation(ctx context.Context, accessToken, realm, clientID string) (*Client, error) {
 	const errMessage = "could not get client representation"
 
+	ctx, span := g.startSpan(ctx, "GetClientRepresentation")
+	defer span.End()
+
 	var result Client
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -1147,6 +1625,9 @@ func (g *GoCloak) GetClientRepresentation(ctx context.Context, accessToken, real
 func (g *GoCloak) GetAdapterConfiguration(ctx context.Context, accessToken, realm, clientID string) (*AdapterConfiguration, error) {
 	const errMessage = "could not get adapter configuration"
 
+	ctx, span := g.startSpan(ctx, "GetAdapterConfiguration")
+	defer span.End()
+
 	var result AdapterConfiguration
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -1164,6 +1645,9 @@ func (g *GoCloak) GetAdapterConfiguration(ctx context.Context, accessToken, real
 func (g *GoCloak) GetClientsDefaultScopes(ctx context.Context, token, realm, idOfClient string) ([]*ClientScope, error) {
 	const errMessage = "could not get clients default scopes"
 
+	ctx, span := g.startSpan(ctx, "GetClientsDefaultScopes")
+	defer span.End()
+
 	var result []*ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1181,6 +1665,9 @@ func (g *GoCloak) GetClientsDefaultScopes(ctx context.Context, token, realm, idO
 func (g *GoCloak) AddDefaultScopeToClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
 	const errMessage = "could not add default scope to client"
 
+	ctx, span := g.startSpan(ctx, "AddDefaultScopeToClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "default-client-scopes", scopeID))
 
@@ -1191,6 +1678,9 @@ func (g *GoCloak) AddDefaultScopeToClient(ctx context.Context, token, realm, idO
 func (g *GoCloak) RemoveDefaultScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
 	const errMessage = "could not remove default scope from client"
 
+	ctx, span := g.startSpan(ctx, "RemoveDefaultScopeFromClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "default-client-scopes", scopeID))
 
@@ -1201,6 +1691,9 @@ func (g *GoCloak) RemoveDefaultScopeFromClient(ctx context.Context, token, realm
 func (g *GoCloak) GetClientsOptionalScopes(ctx context.Context, token, realm, idOfClient string) ([]*ClientScope, error) {
 	const errMessage = "could not get clients optional scopes"
 
+	ctx, span := g.startSpan(ctx, "GetClientsOptionalScopes")
+	defer span.End()
+
 	var result []*ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1218,6 +1711,9 @@ func (g *GoCloak) GetClientsOptionalScopes(ctx context.Context, token, realm, id
 func (g *GoCloak) AddOptionalScopeToClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
 	const errMessage = "could not add optional scope to client"
 
+	ctx, span := g.startSpan(ctx, "AddOptionalScopeToClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "optional-client-scopes", scopeID))
 
@@ -1228,6 +1724,9 @@ func (g *GoCloak) AddOptionalScopeToClient(ctx context.Context, token, realm, id
 func (g *GoCloak) RemoveOptionalScopeFromClient(ctx context.Context, token, realm, idOfClient, scopeID string) error {
 	const errMessage = "could not remove optional scope from client"
 
+	ctx, span := g.startSpan(ctx, "RemoveOptionalScopeFromClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "optional-client-scopes", scopeID))
 
@@ -1238,6 +1737,9 @@ func (g *GoCloak) RemoveOptionalScopeFromClient(ctx context.Context, token, real
 func (g *GoCloak) GetDefaultOptionalClientScopes(ctx context.Context, token, realm string) ([]*ClientScope, error) {
 	const errMessage = "could not get default optional client scopes"
 
+	ctx, span := g.startSpan(ctx, "GetDefaultOptionalClientScopes")
+	defer span.End()
+
 	var result []*ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1255,6 +1757,9 @@ func (g *GoCloak) GetDefaultOptionalClientScopes(ctx context.Context, token, rea
 func (g *GoCloak) GetDefaultDefaultClientScopes(ctx context.Context, token, realm string) ([]*ClientScope, error) {
 	const errMessage = "could not get default client scopes"
 
+	ctx, span := g.startSpan(ctx, "GetDefaultDefaultClientScopes")
+	defer span.End()
+
 	var result []*ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1272,6 +1777,9 @@ func (g *GoCloak) GetDefaultDefaultClientScopes(ctx context.Context, token, real
 func (g *GoCloak) GetClientScope(ctx context.Context, token, realm, scopeID string) (*ClientScope, error) {
 	const errMessage = "could not get client scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScope")
+	defer span.End()
+
 	var result ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1289,6 +1797,9 @@ func (g *GoCloak) GetClientScope(ctx context.Context, token, realm, scopeID stri
 func (g *GoCloak) GetClientScopes(ctx context.Context, token, realm string) ([]*ClientScope, error) {
 	const errMessage = "could not get client scopes"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopes")
+	defer span.End()
+
 	var result []*ClientScope
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1306,6 +1817,9 @@ func (g *GoCloak) GetClientScopes(ctx context.Context, token, realm string) ([]*
 func (g *GoCloak) GetClientScopeProtocolMappers(ctx context.Context, token, realm, scopeID string) ([]*ProtocolMappers, error) {
 	const errMessage = "could not get client scope protocol mappers"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeProtocolMappers")
+	defer span.End()
+
 	var result []*ProtocolMappers
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1323,6 +1837,9 @@ func (g *GoCloak) GetClientScopeProtocolMappers(ctx context.Context, token, real
 func (g *GoCloak) GetClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID, protocolMapperID string) (*ProtocolMappers, error) {
 	const errMessage = "could not get client scope protocol mappers"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeProtocolMapper")
+	defer span.End()
+
 	var result *ProtocolMappers
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1340,6 +1857,9 @@ func (g *GoCloak) GetClientScopeProtocolMapper(ctx context.Context, token, realm
 func (g *GoCloak) GetClientScopeMappings(ctx context.Context, token, realm, idOfClient string) (*MappingsRepresentation, error) {
 	const errMessage = "could not get all scope mappings for the client"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeMappings")
+	defer span.End()
+
 	var result *MappingsRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1357,6 +1877,9 @@ func (g *GoCloak) GetClientScopeMappings(ctx context.Context, token, realm, idOf
 func (g *GoCloak) GetRealmRoleGroups(ctx context.Context, token, roleName, realm string) ([]*Group, error) {
 	const errMessage = "could not get groups by realm roleName"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRoleGroups")
+	defer span.End()
+
 	var result []*Group
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1373,6 +1896,9 @@ func (g *GoCloak) GetRealmRoleGroups(ctx context.Context, token, roleName, realm
 func (g *GoCloak) GetClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string) ([]*Role, error) {
 	const errMessage = "could not get realm-level roles with the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeMappingsRealmRoles")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1390,6 +1916,9 @@ func (g *GoCloak) GetClientScopeMappingsRealmRoles(ctx context.Context, token, r
 func (g *GoCloak) GetClientScopeMappingsRealmRolesAvailable(ctx context.Context, token, realm, idOfClient string) ([]*Role, error) {
 	const errMessage = "could not get available realm-level roles with the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeMappingsRealmRolesAvailable")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1407,6 +1936,9 @@ func (g *GoCloak) GetClientScopeMappingsRealmRolesAvailable(ctx context.Context,
 func (g *GoCloak) CreateClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []Role) error {
 	const errMessage = "could not create realm-level roles to the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScopeMappingsRealmRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "scope-mappings", "realm"))
@@ -1418,6 +1950,9 @@ func (g *GoCloak) CreateClientScopeMappingsRealmRoles(ctx context.Context, token
 func (g *GoCloak) DeleteClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []Role) error {
 	const errMessage = "could not delete realm-level roles from the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScopeMappingsRealmRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "scope-mappings", "realm"))
@@ -1429,6 +1964,9 @@ func (g *GoCloak) DeleteClientScopeMappingsRealmRoles(ctx context.Context, token
 func (g *GoCloak) GetClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string) ([]*Role, error) {
 	const errMessage = "could not get roles associated with a client’s scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeMappingsClientRoles")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1446,6 +1984,9 @@ func (g *GoCloak) GetClientScopeMappingsClientRoles(ctx context.Context, token,
 func (g *GoCloak) GetClientScopeMappingsClientRolesAvailable(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string) ([]*Role, error) {
 	const errMessage = "could not get available roles associated with a client’s scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopeMappingsClientRolesAvailable")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1463,6 +2004,9 @@ func (g *GoCloak) GetClientScopeMappingsClientRolesAvailable(ctx context.Context
 func (g *GoCloak) CreateClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string, roles []Role) error {
 	const errMessage = "could not create client-level roles from the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScopeMappingsClientRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "scope-mappings", "clients", idOfSelectedClient))
@@ -1474,6 +2018,9 @@ func (g *GoCloak) CreateClientScopeMappingsClientRoles(ctx context.Context, toke
 func (g *GoCloak) DeleteClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string, roles []Role) error {
 	const errMessage = "could not delete client-level roles from the client’s scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScopeMappingsClientRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "scope-mappings", "clients", idOfSelectedClient))
@@ -1485,6 +2032,9 @@ func (g *GoCloak) DeleteClientScopeMappingsClientRoles(ctx context.Context, toke
 func (g *GoCloak) GetClientSecret(ctx context.Context, token, realm, idOfClient string) (*CredentialRepresentation, error) {
 	const errMessage = "could not get client secret"
 
+	ctx, span := g.startSpan(ctx, "GetClientSecret")
+	defer span.End()
+
 	var result CredentialRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1502,6 +2052,9 @@ func (g *GoCloak) GetClientSecret(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) GetClientServiceAccount(ctx context.Context, token, realm, idOfClient string) (*User, error) {
 	const errMessage = "could not get client service account"
 
+	ctx, span := g.startSpan(ctx, "GetClientServiceAccount")
+	defer span.End()
+
 	var result User
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1518,6 +2071,9 @@ func (g *GoCloak) GetClientServiceAccount(ctx context.Context, token, realm, idO
 func (g *GoCloak) RegenerateClientSecret(ctx context.Context, token, realm, idOfClient string) (*CredentialRepresentation, error) {
 	const errMessage = "could not regenerate client secret"
 
+	ctx, span := g.startSpan(ctx, "RegenerateClientSecret")
+	defer span.End()
+
 	var result CredentialRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1533,6 +2089,10 @@ func (g *GoCloak) RegenerateClientSecret(ctx context.Context, token, realm, idOf
 // GetClientOfflineSessions returns offline sessions associated with the client
 func (g *GoCloak) GetClientOfflineSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
 	const errMessage = "could not get client offline sessions"
+
+	ctx, span := g.startSpan(ctx, "GetClientOfflineSessions")
+	defer span.End()
+
 	var res []*UserSessionRepresentation
 
 	queryParams := map[string]string{}
@@ -1560,6 +2120,10 @@ func (g *GoCloak) GetClientOfflineSessions(ctx context.Context, token, realm, id
 // GetClientUserSessions returns user sessions associated with the client
 func (g *GoCloak) GetClientUserSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
 	const errMessage = "could not get client user sessions"
+
+	ctx, span := g.startSpan(ctx, "GetClientUserSessions")
+	defer span.End()
+
 	var res []*UserSessionRepresentation
 
 	queryParams := map[string]string{}
@@ -1588,6 +2152,9 @@ func (g *GoCloak) GetClientUserSessions(ctx context.Context, token, realm, idOfC
 func (g *GoCloak) CreateClientProtocolMapper(ctx context.Context, token, realm, idOfClient string, mapper ProtocolMapperRepresentation) (string, error) {
 	const errMessage = "could not create client protocol mapper"
 
+	ctx, span := g.startSpan(ctx, "CreateClientProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(mapper).
 		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models"))
@@ -1603,6 +2170,9 @@ func (g *GoCloak) CreateClientProtocolMapper(ctx context.Context, token, realm,
 func (g *GoCloak) UpdateClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string, mapper ProtocolMapperRepresentation) error {
 	const errMessage = "could not update client protocol mapper"
 
+	ctx, span := g.startSpan(ctx, "UpdateClientProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(mapper).
 		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
@@ -1614,6 +2184,9 @@ func (g *GoCloak) UpdateClientProtocolMapper(ctx context.Context, token, realm,
 func (g *GoCloak) DeleteClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string) error {
 	const errMessage = "could not delete client protocol mapper"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientProtocolMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
 
@@ -1624,6 +2197,9 @@ func (g *GoCloak) DeleteClientProtocolMapper(ctx context.Context, token, realm,
 func (g *GoCloak) GetKeyStoreConfig(ctx context.Context, token, realm string) (*KeyStoreConfig, error) {
 	const errMessage = "could not get key store config"
 
+	ctx, span := g.startSpan(ctx, "GetKeyStoreConfig")
+	defer span.End()
+
 	var result KeyStoreConfig
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1640,6 +2216,9 @@ func (g *GoCloak) GetKeyStoreConfig(ctx context.Context, token, realm string) (*
 func (g *GoCloak) GetComponents(ctx context.Context, token, realm string) ([]*Component, error) {
 	const errMessage = "could not get components"
 
+	ctx, span := g.startSpan(ctx, "GetComponents")
+	defer span.End()
+
 	var result []*Component
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1655,6 +2234,10 @@ func (g *GoCloak) GetComponents(ctx context.Context, token, realm string) ([]*Co
 // GetComponentsWithParams get all components in realm with query params
 func (g *GoCloak) GetComponentsWithParams(ctx context.Context, token, realm string, params GetComponentsParams) ([]*Component, error) {
 	const errMessage = "could not get components"
+
+	ctx, span := g.startSpan(ctx, "GetComponentsWithParams")
+	defer span.End()
+
 	var result []*Component
 
 	queryParams, err := GetQueryParams(params)
@@ -1676,6 +2259,10 @@ func (g *GoCloak) GetComponentsWithParams(ctx context.Context, token, realm stri
 // GetComponent get exactly one component by ID
 func (g *GoCloak) GetComponent(ctx context.Context, token, realm string, componentID string) (*Component, error) {
 	const errMessage = "could not get components"
+
+	ctx, span := g.startSpan(ctx, "GetComponent")
+	defer span.End()
+
 	var result *Component
 
 	componentURL := fmt.Sprintf("components/%s", componentID)
@@ -1695,6 +2282,9 @@ func (g *GoCloak) GetComponent(ctx context.Context, token, realm string, compone
 func (g *GoCloak) UpdateComponent(ctx context.Context, token, realm string, component Component) error {
 	const errMessage = "could not update component"
 
+	ctx, span := g.startSpan(ctx, "UpdateComponent")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(component).
 		Put(g.getAdminRealmURL(realm, "components", PString(component.ID)))
@@ -1706,6 +2296,9 @@ func (g *GoCloak) UpdateComponent(ctx context.Context, token, realm string, comp
 func (g *GoCloak) GetDefaultGroups(ctx context.Context, token, realm string) ([]*Group, error) {
 	const errMessage = "could not get default groups"
 
+	ctx, span := g.startSpan(ctx, "GetDefaultGroups")
+	defer span.End()
+
 	var result []*Group
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1723,6 +2316,9 @@ func (g *GoCloak) GetDefaultGroups(ctx context.Context, token, realm string) ([]
 func (g *GoCloak) AddDefaultGroup(ctx context.Context, token, realm, groupID string) error {
 	const errMessage = "could not add default group"
 
+	ctx, span := g.startSpan(ctx, "AddDefaultGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Put(g.getAdminRealmURL(realm, "default-groups", groupID))
 
@@ -1733,6 +2329,9 @@ func (g *GoCloak) AddDefaultGroup(ctx context.Context, token, realm, groupID str
 func (g *GoCloak) RemoveDefaultGroup(ctx context.Context, token, realm, groupID string) error {
 	const errMessage = "could not remove default group"
 
+	ctx, span := g.startSpan(ctx, "RemoveDefaultGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "default-groups", groupID))
 
@@ -1756,11 +2355,17 @@ func (g *GoCloak) getRoleMappings(ctx context.Context, token, realm, path, objec
 
 // GetRoleMappingByGroupID gets the role mappings by group
 func (g *GoCloak) GetRoleMappingByGroupID(ctx context.Context, token, realm, groupID string) (*MappingsRepresentation, error) {
+	ctx, span := g.startSpan(ctx, "GetRoleMappingByGroupID")
+	defer span.End()
+
 	return g.getRoleMappings(ctx, token, realm, "groups", groupID)
 }
 
 // GetRoleMappingByUserID gets the role mappings by user
 func (g *GoCloak) GetRoleMappingByUserID(ctx context.Context, token, realm, userID string) (*MappingsRepresentation, error) {
+	ctx, span := g.startSpan(ctx, "GetRoleMappingByUserID")
+	defer span.End()
+
 	return g.getRoleMappings(ctx, token, realm, "users", userID)
 }
 
@@ -1768,6 +2373,9 @@ func (g *GoCloak) GetRoleMappingByUserID(ctx context.Context, token, realm, user
 func (g *GoCloak) GetGroup(ctx context.Context, token, realm, groupID string) (*Group, error) {
 	const errMessage = "could not get group"
 
+	ctx, span := g.startSpan(ctx, "GetGroup")
+	defer span.End()
+
 	var result Group
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1785,6 +2393,9 @@ func (g *GoCloak) GetGroup(ctx context.Context, token, realm, groupID string) (*
 func (g *GoCloak) GetChildGroups(ctx context.Context, token, realm, groupID string, params GetChildGroupsParams) ([]*Group, error) {
 	const errMessage = "could not get child groups"
 
+	ctx, span := g.startSpan(ctx, "GetChildGroups")
+	defer span.End()
+
 	var result []*Group
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -1807,6 +2418,9 @@ func (g *GoCloak) GetChildGroups(ctx context.Context, token, realm, groupID stri
 func (g *GoCloak) GetGroupByPath(ctx context.Context, token, realm, groupPath string) (*Group, error) {
 	const errMessage = "could not get group"
 
+	ctx, span := g.startSpan(ctx, "GetGroupByPath")
+	defer span.End()
+
 	var result Group
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -1824,6 +2438,9 @@ func (g *GoCloak) GetGroupByPath(ctx context.Context, token, realm, groupPath st
 func (g *GoCloak) GetGroups(ctx context.Context, token, realm string, params GetGroupsParams) ([]*Group, error) {
 	const errMessage = "could not get groups"
 
+	ctx, span := g.startSpan(ctx, "GetGroups")
+	defer span.End()
+
 	var result []*Group
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -1847,6 +2464,9 @@ func (g *GoCloak) GetGroups(ctx context.Context, token, realm string, params Get
 func (g *GoCloak) GetGroupManagementPermissions(ctx context.Context, token, realm string, idOfGroup string) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not get management permissions"
 
+	ctx, span := g.startSpan(ctx, "GetGroupManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1863,6 +2483,9 @@ func (g *GoCloak) GetGroupManagementPermissions(ctx context.Context, token, real
 func (g *GoCloak) GetGroupsByRole(ctx context.Context, token, realm string, roleName string) ([]*Group, error) {
 	const errMessage = "could not get groups"
 
+	ctx, span := g.startSpan(ctx, "GetGroupsByRole")
+	defer span.End()
+
 	var result []*Group
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1879,6 +2502,9 @@ func (g *GoCloak) GetGroupsByRole(ctx context.Context, token, realm string, role
 func (g *GoCloak) GetGroupsByClientRole(ctx context.Context, token, realm string, roleName string, clientID string) ([]*Group, error) {
 	const errMessage = "could not get groups"
 
+	ctx, span := g.startSpan(ctx, "GetGroupsByClientRole")
+	defer span.End()
+
 	var result []*Group
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1895,6 +2521,9 @@ func (g *GoCloak) GetGroupsByClientRole(ctx context.Context, token, realm string
 func (g *GoCloak) GetGroupsCount(ctx context.Context, token, realm string, params GetGroupsParams) (int, error) {
 	const errMessage = "could not get groups count"
 
+	ctx, span := g.startSpan(ctx, "GetGroupsCount")
+	defer span.End()
+
 	var result GroupsCount
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -1916,6 +2545,9 @@ func (g *GoCloak) GetGroupsCount(ctx context.Context, token, realm string, param
 func (g *GoCloak) GetGroupMembers(ctx context.Context, token, realm, groupID string, params GetGroupsParams) ([]*User, error) {
 	const errMessage = "could not get group members"
 
+	ctx, span := g.startSpan(ctx, "GetGroupMembers")
+	defer span.End()
+
 	var result []*User
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -1938,6 +2570,9 @@ func (g *GoCloak) GetGroupMembers(ctx context.Context, token, realm, groupID str
 func (g *GoCloak) GetClientRoles(ctx context.Context, token, realm, idOfClient string, params GetRoleParams) ([]*Role, error) {
 	const errMessage = "could not get client roles"
 
+	ctx, span := g.startSpan(ctx, "GetClientRoles")
+	defer span.End()
+
 	var result []*Role
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -1960,6 +2595,9 @@ func (g *GoCloak) GetClientRoles(ctx context.Context, token, realm, idOfClient s
 func (g *GoCloak) GetClientRoleByID(ctx context.Context, token, realm, roleID string) (*Role, error) {
 	const errMessage = "could not get client role"
 
+	ctx, span := g.startSpan(ctx, "GetClientRoleByID")
+	defer span.End()
+
 	var result Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1976,6 +2614,9 @@ func (g *GoCloak) GetClientRoleByID(ctx context.Context, token, realm, roleID st
 func (g *GoCloak) GetClientRolesByUserID(ctx context.Context, token, realm, idOfClient, userID string) ([]*Role, error) {
 	const errMessage = "could not client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetClientRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -1992,6 +2633,9 @@ func (g *GoCloak) GetClientRolesByUserID(ctx context.Context, token, realm, idOf
 func (g *GoCloak) GetClientRolesByGroupID(ctx context.Context, token, realm, idOfClient, groupID string) ([]*Role, error) {
 	const errMessage = "could not get client roles by group id"
 
+	ctx, span := g.startSpan(ctx, "GetClientRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2008,6 +2652,9 @@ func (g *GoCloak) GetClientRolesByGroupID(ctx context.Context, token, realm, idO
 func (g *GoCloak) GetCompositeClientRolesByRoleID(ctx context.Context, token, realm, idOfClient, roleID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by role id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeClientRolesByRoleID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2024,6 +2671,9 @@ func (g *GoCloak) GetCompositeClientRolesByRoleID(ctx context.Context, token, re
 func (g *GoCloak) GetCompositeClientRolesByUserID(ctx context.Context, token, realm, idOfClient, userID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeClientRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2040,6 +2690,9 @@ func (g *GoCloak) GetCompositeClientRolesByUserID(ctx context.Context, token, re
 func (g *GoCloak) GetAvailableClientRolesByUserID(ctx context.Context, token, realm, idOfClient, userID string) ([]*Role, error) {
 	const errMessage = "could not get available client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetAvailableClientRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2056,6 +2709,9 @@ func (g *GoCloak) GetAvailableClientRolesByUserID(ctx context.Context, token, re
 func (g *GoCloak) GetAvailableClientRolesByGroupID(ctx context.Context, token, realm, idOfClient, groupID string) ([]*Role, error) {
 	const errMessage = "could not get available client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetAvailableClientRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2072,6 +2728,9 @@ func (g *GoCloak) GetAvailableClientRolesByGroupID(ctx context.Context, token, r
 func (g *GoCloak) GetCompositeClientRolesByGroupID(ctx context.Context, token, realm, idOfClient, groupID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by group id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeClientRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2088,6 +2747,9 @@ func (g *GoCloak) GetCompositeClientRolesByGroupID(ctx context.Context, token, r
 func (g *GoCloak) GetClientRole(ctx context.Context, token, realm, idOfClient, roleName string) (*Role, error) {
 	const errMessage = "could not get client role"
 
+	ctx, span := g.startSpan(ctx, "GetClientRole")
+	defer span.End()
+
 	var result Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2104,6 +2766,9 @@ func (g *GoCloak) GetClientRole(ctx context.Context, token, realm, idOfClient, r
 func (g *GoCloak) GetClients(ctx context.Context, token, realm string, params GetClientsParams) ([]*Client, error) {
 	const errMessage = "could not get clients"
 
+	ctx, span := g.startSpan(ctx, "GetClients")
+	defer span.End()
+
 	var result []*Client
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2126,6 +2791,9 @@ func (g *GoCloak) GetClients(ctx context.Context, token, realm string, params Ge
 func (g *GoCloak) GetClientManagementPermissions(ctx context.Context, token, realm string, idOfClient string) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not get management permissions"
 
+	ctx, span := g.startSpan(ctx, "GetClientManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2156,6 +2824,9 @@ func UserAttributeContains(attributes map[string][]string, attribute, value stri
 func (g *GoCloak) CreateRealmRole(ctx context.Context, token string, realm string, role Role) (string, error) {
 	const errMessage = "could not create realm role"
 
+	ctx, span := g.startSpan(ctx, "CreateRealmRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(role).
 		Post(g.getAdminRealmURL(realm, "roles"))
@@ -2171,6 +2842,9 @@ func (g *GoCloak) CreateRealmRole(ctx context.Context, token string, realm strin
 func (g *GoCloak) GetRealmRole(ctx context.Context, token, realm, roleName string) (*Role, error) {
 	const errMessage = "could not get realm role"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRole")
+	defer span.End()
+
 	var result Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -2188,6 +2862,9 @@ func (g *GoCloak) GetRealmRole(ctx context.Context, token, realm, roleName strin
 func (g *GoCloak) GetRealmRoleByID(ctx context.Context, token, realm, roleID string) (*Role, error) {
 	const errMessage = "could not get realm role"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRoleByID")
+	defer span.End()
+
 	var result Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2204,6 +2881,9 @@ func (g *GoCloak) GetRealmRoleByID(ctx context.Context, token, realm, roleID str
 func (g *GoCloak) GetRealmRoles(ctx context.Context, token, realm string, params GetRoleParams) ([]*Role, error) {
 	const errMessage = "could not get realm roles"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRoles")
+	defer span.End()
+
 	var result []*Role
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2226,6 +2906,9 @@ func (g *GoCloak) GetRealmRoles(ctx context.Context, token, realm string, params
 func (g *GoCloak) GetRealmRolesByUserID(ctx context.Context, token, realm, userID string) ([]*Role, error) {
 	const errMessage = "could not get realm roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2242,6 +2925,9 @@ func (g *GoCloak) GetRealmRolesByUserID(ctx context.Context, token, realm, userI
 func (g *GoCloak) GetRealmRolesByGroupID(ctx context.Context, token, realm, groupID string) ([]*Role, error) {
 	const errMessage = "could not get realm roles by group id"
 
+	ctx, span := g.startSpan(ctx, "GetRealmRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2258,6 +2944,9 @@ func (g *GoCloak) GetRealmRolesByGroupID(ctx context.Context, token, realm, grou
 func (g *GoCloak) UpdateRealmRole(ctx context.Context, token, realm, roleName string, role Role) error {
 	const errMessage = "could not update realm role"
 
+	ctx, span := g.startSpan(ctx, "UpdateRealmRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(role).
 		Put(g.getAdminRealmURL(realm, "roles", roleName))
@@ -2269,6 +2958,9 @@ func (g *GoCloak) UpdateRealmRole(ctx context.Context, token, realm, roleName st
 func (g *GoCloak) UpdateRealmRoleByID(ctx context.Context, token, realm, roleID string, role Role) error {
 	const errMessage = "could not update realm role"
 
+	ctx, span := g.startSpan(ctx, "UpdateRealmRoleByID")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(role).
 		Put(g.getAdminRealmURL(realm, "roles-by-id", roleID))
@@ -2280,6 +2972,9 @@ func (g *GoCloak) UpdateRealmRoleByID(ctx context.Context, token, realm, roleID
 func (g *GoCloak) DeleteRealmRole(ctx context.Context, token, realm, roleName string) error {
 	const errMessage = "could not delete realm role"
 
+	ctx, span := g.startSpan(ctx, "DeleteRealmRole")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "roles", roleName))
 
@@ -2290,6 +2985,9 @@ func (g *GoCloak) DeleteRealmRole(ctx context.Context, token, realm, roleName st
 func (g *GoCloak) AddRealmRoleToUser(ctx context.Context, token, realm, userID string, roles []Role) error {
 	const errMessage = "could not add realm role to user"
 
+	ctx, span := g.startSpan(ctx, "AddRealmRoleToUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "users", userID, "role-mappings", "realm"))
@@ -2301,6 +2999,9 @@ func (g *GoCloak) AddRealmRoleToUser(ctx context.Context, token, realm, userID s
 func (g *GoCloak) DeleteRealmRoleFromUser(ctx context.Context, token, realm, userID string, roles []Role) error {
 	const errMessage = "could not delete realm role from user"
 
+	ctx, span := g.startSpan(ctx, "DeleteRealmRoleFromUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "role-mappings", "realm"))
@@ -2312,6 +3013,9 @@ func (g *GoCloak) DeleteRealmRoleFromUser(ctx context.Context, token, realm, use
 func (g *GoCloak) AddRealmRoleToGroup(ctx context.Context, token, realm, groupID string, roles []Role) error {
 	const errMessage = "could not add realm role to group"
 
+	ctx, span := g.startSpan(ctx, "AddRealmRoleToGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "groups", groupID, "role-mappings", "realm"))
@@ -2323,6 +3027,9 @@ func (g *GoCloak) AddRealmRoleToGroup(ctx context.Context, token, realm, groupID
 func (g *GoCloak) DeleteRealmRoleFromGroup(ctx context.Context, token, realm, groupID string, roles []Role) error {
 	const errMessage = "could not delete realm role from group"
 
+	ctx, span := g.startSpan(ctx, "DeleteRealmRoleFromGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "groups", groupID, "role-mappings", "realm"))
@@ -2334,6 +3041,9 @@ func (g *GoCloak) DeleteRealmRoleFromGroup(ctx context.Context, token, realm, gr
 func (g *GoCloak) AddRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []Role) error {
 	const errMessage = "could not add realm role composite"
 
+	ctx, span := g.startSpan(ctx, "AddRealmRoleComposite")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "roles", roleName, "composites"))
@@ -2345,6 +3055,9 @@ func (g *GoCloak) AddRealmRoleComposite(ctx context.Context, token, realm, roleN
 func (g *GoCloak) DeleteRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []Role) error {
 	const errMessage = "could not delete realm role composite"
 
+	ctx, span := g.startSpan(ctx, "DeleteRealmRoleComposite")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "roles", roleName, "composites"))
@@ -2356,6 +3069,9 @@ func (g *GoCloak) DeleteRealmRoleComposite(ctx context.Context, token, realm, ro
 func (g *GoCloak) GetCompositeRealmRoles(ctx context.Context, token, realm, roleName string) ([]*Role, error) {
 	const errMessage = "could not get composite realm roles by role"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeRealmRoles")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2372,6 +3088,9 @@ func (g *GoCloak) GetCompositeRealmRoles(ctx context.Context, token, realm, role
 func (g *GoCloak) GetCompositeRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by role id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeRolesByRoleID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2388,6 +3107,9 @@ func (g *GoCloak) GetCompositeRolesByRoleID(ctx context.Context, token, realm, r
 func (g *GoCloak) GetCompositeRealmRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by role id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeRealmRolesByRoleID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2404,6 +3126,9 @@ func (g *GoCloak) GetCompositeRealmRolesByRoleID(ctx context.Context, token, rea
 func (g *GoCloak) GetCompositeRealmRolesByUserID(ctx context.Context, token, realm, userID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeRealmRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2420,6 +3145,9 @@ func (g *GoCloak) GetCompositeRealmRolesByUserID(ctx context.Context, token, rea
 func (g *GoCloak) GetCompositeRealmRolesByGroupID(ctx context.Context, token, realm, groupID string) ([]*Role, error) {
 	const errMessage = "could not get composite client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetCompositeRealmRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2436,6 +3164,9 @@ func (g *GoCloak) GetCompositeRealmRolesByGroupID(ctx context.Context, token, re
 func (g *GoCloak) GetAvailableRealmRolesByUserID(ctx context.Context, token, realm, userID string) ([]*Role, error) {
 	const errMessage = "could not get available client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetAvailableRealmRolesByUserID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2452,6 +3183,9 @@ func (g *GoCloak) GetAvailableRealmRolesByUserID(ctx context.Context, token, rea
 func (g *GoCloak) GetAvailableRealmRolesByGroupID(ctx context.Context, token, realm, groupID string) ([]*Role, error) {
 	const errMessage = "could not get available client roles by user id"
 
+	ctx, span := g.startSpan(ctx, "GetAvailableRealmRolesByGroupID")
+	defer span.End()
+
 	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2471,6 +3205,10 @@ func (g *GoCloak) GetAvailableRealmRolesByGroupID(ctx context.Context, token, re
 // GetRealm returns top-level representation of the realm
 func (g *GoCloak) GetRealm(ctx context.Context, token, realm string) (*RealmRepresentation, error) {
 	const errMessage = "could not get realm"
+
+	ctx, span := g.startSpan(ctx, "GetRealm")
+	defer span.End()
+
 	if realm == "" {
 		return nil, errors.New("realm is empty")
 	}
@@ -2490,6 +3228,9 @@ func (g *GoCloak) GetRealm(ctx context.Context, token, realm string) (*RealmRepr
 func (g *GoCloak) GetRealms(ctx context.Context, token string) ([]*RealmRepresentation, error) {
 	const errMessage = "could not get realms"
 
+	ctx, span := g.startSpan(ctx, "GetRealms")
+	defer span.End()
+
 	var result []*RealmRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2506,6 +3247,9 @@ func (g *GoCloak) GetRealms(ctx context.Context, token string) ([]*RealmRepresen
 func (g *GoCloak) CreateRealm(ctx context.Context, token string, realm RealmRepresentation) (string, error) {
 	const errMessage = "could not create realm"
 
+	ctx, span := g.startSpan(ctx, "CreateRealm")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(&realm).
 		Post(g.getAdminRealmURL(""))
@@ -2520,6 +3264,9 @@ func (g *GoCloak) CreateRealm(ctx context.Context, token string, realm RealmRepr
 func (g *GoCloak) UpdateRealm(ctx context.Context, token string, realm RealmRepresentation) error {
 	const errMessage = "could not update realm"
 
+	ctx, span := g.startSpan(ctx, "UpdateRealm")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(realm).
 		Put(g.getAdminRealmURL(PString(realm.Realm)))
@@ -2531,6 +3278,9 @@ func (g *GoCloak) UpdateRealm(ctx context.Context, token string, realm RealmRepr
 func (g *GoCloak) DeleteRealm(ctx context.Context, token, realm string) error {
 	const errMessage = "could not delete realm"
 
+	ctx, span := g.startSpan(ctx, "DeleteRealm")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm))
 
@@ -2541,6 +3291,9 @@ func (g *GoCloak) DeleteRealm(ctx context.Context, token, realm string) error {
 func (g *GoCloak) ClearRealmCache(ctx context.Context, token, realm string) error {
 	const errMessage = "could not clear realm cache"
 
+	ctx, span := g.startSpan(ctx, "ClearRealmCache")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Post(g.getAdminRealmURL(realm, "clear-realm-cache"))
 
@@ -2551,6 +3304,9 @@ func (g *GoCloak) ClearRealmCache(ctx context.Context, token, realm string) erro
 func (g *GoCloak) ClearUserCache(ctx context.Context, token, realm string) error {
 	const errMessage = "could not clear user cache"
 
+	ctx, span := g.startSpan(ctx, "ClearUserCache")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Post(g.getAdminRealmURL(realm, "clear-user-cache"))
 
@@ -2561,6 +3317,9 @@ func (g *GoCloak) ClearUserCache(ctx context.Context, token, realm string) error
 func (g *GoCloak) ClearKeysCache(ctx context.Context, token, realm string) error {
 	const errMessage = "could not clear keys cache"
 
+	ctx, span := g.startSpan(ctx, "ClearKeysCache")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Post(g.getAdminRealmURL(realm, "clear-keys-cache"))
 
@@ -2570,6 +3329,10 @@ func (g *GoCloak) ClearKeysCache(ctx context.Context, token, realm string) error
 // GetAuthenticationFlows get all authentication flows from a realm
 func (g *GoCloak) GetAuthenticationFlows(ctx context.Context, token, realm string) ([]*AuthenticationFlowRepresentation, error) {
 	const errMessage = "could not retrieve authentication flows"
+
+	ctx, span := g.startSpan(ctx, "GetAuthenticationFlows")
+	defer span.End()
+
 	var result []*AuthenticationFlowRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2584,6 +3347,10 @@ func (g *GoCloak) GetAuthenticationFlows(ctx context.Context, token, realm strin
 // GetAuthenticationFlow get an authentication flow with the given ID
 func (g *GoCloak) GetAuthenticationFlow(ctx context.Context, token, realm string, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
 	const errMessage = "could not retrieve authentication flows"
+
+	ctx, span := g.startSpan(ctx, "GetAuthenticationFlow")
+	defer span.End()
+
 	var result *AuthenticationFlowRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2598,6 +3365,10 @@ func (g *GoCloak) GetAuthenticationFlow(ctx context.Context, token, realm string
 // CreateAuthenticationFlow creates a new Authentication flow in a realm
 func (g *GoCloak) CreateAuthenticationFlow(ctx context.Context, token, realm string, flow AuthenticationFlowRepresentation) error {
 	const errMessage = "could not create authentication flows"
+
+	ctx, span := g.startSpan(ctx, "CreateAuthenticationFlow")
+	defer span.End()
+
 	var result []*AuthenticationFlowRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).SetBody(flow).
@@ -2609,6 +3380,10 @@ func (g *GoCloak) CreateAuthenticationFlow(ctx context.Context, token, realm str
 // UpdateAuthenticationFlow a given Authentication Flow
 func (g *GoCloak) UpdateAuthenticationFlow(ctx context.Context, token, realm string, flow AuthenticationFlowRepresentation, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
 	const errMessage = "could not create authentication flows"
+
+	ctx, span := g.startSpan(ctx, "UpdateAuthenticationFlow")
+	defer span.End()
+
 	var result *AuthenticationFlowRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).SetBody(flow).
@@ -2623,6 +3398,10 @@ func (g *GoCloak) UpdateAuthenticationFlow(ctx context.Context, token, realm str
 // DeleteAuthenticationFlow deletes a flow in a realm with the given ID
 func (g *GoCloak) DeleteAuthenticationFlow(ctx context.Context, token, realm, flowID string) error {
 	const errMessage = "could not delete authentication flows"
+
+	ctx, span := g.startSpan(ctx, "DeleteAuthenticationFlow")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "authentication", "flows", flowID))
 
@@ -2632,6 +3411,10 @@ func (g *GoCloak) DeleteAuthenticationFlow(ctx context.Context, token, realm, fl
 // GetAuthenticationExecutions retrieves all executions of a given flow
 func (g *GoCloak) GetAuthenticationExecutions(ctx context.Context, token, realm, flow string) ([]*ModifyAuthenticationExecutionRepresentation, error) {
 	const errMessage = "could not retrieve authentication flows"
+
+	ctx, span := g.startSpan(ctx, "GetAuthenticationExecutions")
+	defer span.End()
+
 	var result []*ModifyAuthenticationExecutionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -2646,6 +3429,10 @@ func (g *GoCloak) GetAuthenticationExecutions(ctx context.Context, token, realm,
 // CreateAuthenticationExecution creates a new execution for the given flow name in the given realm
 func (g *GoCloak) CreateAuthenticationExecution(ctx context.Context, token, realm, flow string, execution CreateAuthenticationExecutionRepresentation) error {
 	const errMessage = "could not create authentication execution"
+
+	ctx, span := g.startSpan(ctx, "CreateAuthenticationExecution")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).SetBody(execution).
 		Post(g.getAdminRealmURL(realm, "authentication", "flows", flow, "executions", "execution"))
 
@@ -2655,6 +3442,10 @@ func (g *GoCloak) CreateAuthenticationExecution(ctx context.Context, token, real
 // UpdateAuthenticationExecution updates an authentication execution for the given flow in the given realm
 func (g *GoCloak) UpdateAuthenticationExecution(ctx context.Context, token, realm, flow string, execution ModifyAuthenticationExecutionRepresentation) error {
 	const errMessage = "could not update authentication execution"
+
+	ctx, span := g.startSpan(ctx, "UpdateAuthenticationExecution")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).SetBody(execution).
 		Put(g.getAdminRealmURL(realm, "authentication", "flows", flow, "executions"))
 
@@ -2664,6 +3455,10 @@ func (g *GoCloak) UpdateAuthenticationExecution(ctx context.Context, token, real
 // DeleteAuthenticationExecution delete a single execution with the given ID
 func (g *GoCloak) DeleteAuthenticationExecution(ctx context.Context, token, realm, executionID string) error {
 	const errMessage = "could not delete authentication execution"
+
+	ctx, span := g.startSpan(ctx, "DeleteAuthenticationExecution")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "authentication", "executions", executionID))
 
@@ -2673,6 +3468,10 @@ func (g *GoCloak) DeleteAuthenticationExecution(ctx context.Context, token, real
 // CreateAuthenticationExecutionFlow creates a new execution for the given flow name in the given realm
 func (g *GoCloak) CreateAuthenticationExecutionFlow(ctx context.Context, token, realm, flow string, executionFlow CreateAuthenticationExecutionFlowRepresentation) error {
 	const errMessage = "could not create authentication execution flow"
+
+	ctx, span := g.startSpan(ctx, "CreateAuthenticationExecutionFlow")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).SetBody(executionFlow).
 		Post(g.getAdminRealmURL(realm, "authentication", "flows", flow, "executions", "flow"))
 
@@ -2689,6 +3488,9 @@ func (g *GoCloak) CreateAuthenticationExecutionFlow(ctx context.Context, token,
 func (g *GoCloak) CreateUser(ctx context.Context, token, realm string, user User) (string, error) {
 	const errMessage = "could not create user"
 
+	ctx, span := g.startSpan(ctx, "CreateUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(user).
 		Post(g.getAdminRealmURL(realm, "users"))
@@ -2704,6 +3506,9 @@ func (g *GoCloak) CreateUser(ctx context.Context, token, realm string, user User
 func (g *GoCloak) DeleteUser(ctx context.Context, token, realm, userID string) error {
 	const errMessage = "could not delete user"
 
+	ctx, span := g.startSpan(ctx, "DeleteUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "users", userID))
 
@@ -2714,6 +3519,9 @@ func (g *GoCloak) DeleteUser(ctx context.Context, token, realm, userID string) e
 func (g *GoCloak) GetUserByID(ctx context.Context, accessToken, realm, userID string) (*User, error) {
 	const errMessage = "could not get user by id"
 
+	ctx, span := g.startSpan(ctx, "GetUserByID")
+	defer span.End()
+
 	if userID == "" {
 		return nil, errors.Wrap(errors.New("userID shall not be empty"), errMessage)
 	}
@@ -2734,6 +3542,9 @@ func (g *GoCloak) GetUserByID(ctx context.Context, accessToken, realm, userID st
 func (g *GoCloak) GetUserCount(ctx context.Context, token string, realm string, params GetUsersParams) (int, error) {
 	const errMessage = "could not get user count"
 
+	ctx, span := g.startSpan(ctx, "GetUserCount")
+	defer span.End()
+
 	var result int
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2756,6 +3567,9 @@ func (g *GoCloak) GetUserCount(ctx context.Context, token string, realm string,
 func (g *GoCloak) GetUserGroups(ctx context.Context, token, realm, userID string, params GetGroupsParams) ([]*Group, error) {
 	const errMessage = "could not get user groups"
 
+	ctx, span := g.startSpan(ctx, "GetUserGroups")
+	defer span.End()
+
 	var result []*Group
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2778,6 +3592,9 @@ func (g *GoCloak) GetUserGroups(ctx context.Context, token, realm, userID string
 func (g *GoCloak) GetUsers(ctx context.Context, token, realm string, params GetUsersParams) ([]*User, error) {
 	const errMessage = "could not get users"
 
+	ctx, span := g.startSpan(ctx, "GetUsers")
+	defer span.End()
+
 	var result []*User
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2800,6 +3617,9 @@ func (g *GoCloak) GetUsers(ctx context.Context, token, realm string, params GetU
 func (g *GoCloak) GetUsersByRoleName(ctx context.Context, token, realm, roleName string, params GetUsersByRoleParams) ([]*User, error) {
 	const errMessage = "could not get users by role name"
 
+	ctx, span := g.startSpan(ctx, "GetUsersByRoleName")
+	defer span.End()
+
 	var result []*User
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2822,6 +3642,9 @@ func (g *GoCloak) GetUsersByRoleName(ctx context.Context, token, realm, roleName
 func (g *GoCloak) GetUsersByClientRoleName(ctx context.Context, token, realm, idOfClient, roleName string, params GetUsersByRoleParams) ([]*User, error) {
 	const errMessage = "could not get users by client role name"
 
+	ctx, span := g.startSpan(ctx, "GetUsersByClientRoleName")
+	defer span.End()
+
 	var result []*User
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -2844,6 +3667,9 @@ func (g *GoCloak) GetUsersByClientRoleName(ctx context.Context, token, realm, id
 func (g *GoCloak) SetPassword(ctx context.Context, token, userID, realm, password string, temporary bool) error {
 	const errMessage = "could not set password"
 
+	ctx, span := g.startSpan(ctx, "SetPassword")
+	defer span.End()
+
 	requestBody := SetPasswordRequest{Password: &password, Temporary: &temporary, Type: StringP("password")}
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(requestBody).
@@ -2856,6 +3682,9 @@ func (g *GoCloak) SetPassword(ctx context.Context, token, userID, realm, passwor
 func (g *GoCloak) UpdateUser(ctx context.Context, token, realm string, user User) error {
 	const errMessage = "could not update user"
 
+	ctx, span := g.startSpan(ctx, "UpdateUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(user).
 		Put(g.getAdminRealmURL(realm, "users", PString(user.ID)))
@@ -2867,6 +3696,9 @@ func (g *GoCloak) UpdateUser(ctx context.Context, token, realm string, user User
 func (g *GoCloak) AddUserToGroup(ctx context.Context, token, realm, userID, groupID string) error {
 	const errMessage = "could not add user to group"
 
+	ctx, span := g.startSpan(ctx, "AddUserToGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Put(g.getAdminRealmURL(realm, "users", userID, "groups", groupID))
 
@@ -2877,6 +3709,9 @@ func (g *GoCloak) AddUserToGroup(ctx context.Context, token, realm, userID, grou
 func (g *GoCloak) DeleteUserFromGroup(ctx context.Context, token, realm, userID, groupID string) error {
 	const errMessage = "could not delete user from group"
 
+	ctx, span := g.startSpan(ctx, "DeleteUserFromGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "groups", groupID))
 
@@ -2887,6 +3722,9 @@ func (g *GoCloak) DeleteUserFromGroup(ctx context.Context, token, realm, userID,
 func (g *GoCloak) GetUserSessions(ctx context.Context, token, realm, userID string) ([]*UserSessionRepresentation, error) {
 	const errMessage = "could not get user sessions"
 
+	ctx, span := g.startSpan(ctx, "GetUserSessions")
+	defer span.End()
+
 	var res []*UserSessionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&res).
@@ -2903,6 +3741,9 @@ func (g *GoCloak) GetUserSessions(ctx context.Context, token, realm, userID stri
 func (g *GoCloak) GetUserOfflineSessionsForClient(ctx context.Context, token, realm, userID, idOfClient string) ([]*UserSessionRepresentation, error) {
 	const errMessage = "could not get user offline sessions for client"
 
+	ctx, span := g.startSpan(ctx, "GetUserOfflineSessionsForClient")
+	defer span.End()
+
 	var res []*UserSessionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&res).
@@ -2919,6 +3760,9 @@ func (g *GoCloak) GetUserOfflineSessionsForClient(ctx context.Context, token, re
 func (g *GoCloak) AddClientRolesToUser(ctx context.Context, token, realm, idOfClient, userID string, roles []Role) error {
 	const errMessage = "could not add client role to user"
 
+	ctx, span := g.startSpan(ctx, "AddClientRolesToUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "users", userID, "role-mappings", "clients", idOfClient))
@@ -2930,6 +3774,9 @@ func (g *GoCloak) AddClientRolesToUser(ctx context.Context, token, realm, idOfCl
 //
 // Deprecated: replaced by AddClientRolesToUser
 func (g *GoCloak) AddClientRoleToUser(ctx context.Context, token, realm, idOfClient, userID string, roles []Role) error {
+	ctx, span := g.startSpan(ctx, "AddClientRoleToUser")
+	defer span.End()
+
 	return g.AddClientRolesToUser(ctx, token, realm, idOfClient, userID, roles)
 }
 
@@ -2937,6 +3784,9 @@ func (g *GoCloak) AddClientRoleToUser(ctx context.Context, token, realm, idOfCli
 func (g *GoCloak) AddClientRolesToGroup(ctx context.Context, token, realm, idOfClient, groupID string, roles []Role) error {
 	const errMessage = "could not add client role to group"
 
+	ctx, span := g.startSpan(ctx, "AddClientRolesToGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "groups", groupID, "role-mappings", "clients", idOfClient))
@@ -2948,6 +3798,9 @@ func (g *GoCloak) AddClientRolesToGroup(ctx context.Context, token, realm, idOfC
 //
 // Deprecated: replaced by AddClientRolesToGroup
 func (g *GoCloak) AddClientRoleToGroup(ctx context.Context, token, realm, idOfClient, groupID string, roles []Role) error {
+	ctx, span := g.startSpan(ctx, "AddClientRoleToGroup")
+	defer span.End()
+
 	return g.AddClientRolesToGroup(ctx, token, realm, idOfClient, groupID, roles)
 }
 
@@ -2955,6 +3808,9 @@ func (g *GoCloak) AddClientRoleToGroup(ctx context.Context, token, realm, idOfCl
 func (g *GoCloak) DeleteClientRolesFromUser(ctx context.Context, token, realm, idOfClient, userID string, roles []Role) error {
 	const errMessage = "could not delete client role from user"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientRolesFromUser")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "role-mappings", "clients", idOfClient))
@@ -2966,6 +3822,9 @@ func (g *GoCloak) DeleteClientRolesFromUser(ctx context.Context, token, realm, i
 //
 // Deprecated: replaced by DeleteClientRolesFrom
 func (g *GoCloak) DeleteClientRoleFromUser(ctx context.Context, token, realm, idOfClient, userID string, roles []Role) error {
+	ctx, span := g.startSpan(ctx, "DeleteClientRoleFromUser")
+	defer span.End()
+
 	return g.DeleteClientRolesFromUser(ctx, token, realm, idOfClient, userID, roles)
 }
 
@@ -2973,6 +3832,9 @@ func (g *GoCloak) DeleteClientRoleFromUser(ctx context.Context, token, realm, id
 func (g *GoCloak) DeleteClientRoleFromGroup(ctx context.Context, token, realm, idOfClient, groupID string, roles []Role) error {
 	const errMessage = "could not client role from group"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientRoleFromGroup")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "groups", groupID, "role-mappings", "clients", idOfClient))
@@ -2984,6 +3846,9 @@ func (g *GoCloak) DeleteClientRoleFromGroup(ctx context.Context, token, realm, i
 func (g *GoCloak) AddClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []Role) error {
 	const errMessage = "could not add client role composite"
 
+	ctx, span := g.startSpan(ctx, "AddClientRoleComposite")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "roles-by-id", roleID, "composites"))
@@ -2995,6 +3860,9 @@ func (g *GoCloak) AddClientRoleComposite(ctx context.Context, token, realm, role
 func (g *GoCloak) DeleteClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []Role) error {
 	const errMessage = "could not delete client role composite"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientRoleComposite")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "roles-by-id", roleID, "composites"))
@@ -3006,6 +3874,9 @@ func (g *GoCloak) DeleteClientRoleComposite(ctx context.Context, token, realm, r
 func (g *GoCloak) GetUserFederatedIdentities(ctx context.Context, token, realm, userID string) ([]*FederatedIdentityRepresentation, error) {
 	const errMessage = "could not get user federated identities"
 
+	ctx, span := g.startSpan(ctx, "GetUserFederatedIdentities")
+	defer span.End()
+
 	var res []*FederatedIdentityRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&res).
@@ -3022,6 +3893,9 @@ func (g *GoCloak) GetUserFederatedIdentities(ctx context.Context, token, realm,
 func (g *GoCloak) CreateUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string, federatedIdentityRep FederatedIdentityRepresentation) error {
 	const errMessage = "could not create user federeated identity"
 
+	ctx, span := g.startSpan(ctx, "CreateUserFederatedIdentity")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(federatedIdentityRep).
 		Post(g.getAdminRealmURL(realm, "users", userID, "federated-identity", providerID))
@@ -3033,6 +3907,9 @@ func (g *GoCloak) CreateUserFederatedIdentity(ctx context.Context, token, realm,
 func (g *GoCloak) DeleteUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string) error {
 	const errMessage = "could not delete user federeated identity"
 
+	ctx, span := g.startSpan(ctx, "DeleteUserFederatedIdentity")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "federated-identity", providerID))
 
@@ -3042,6 +3919,10 @@ func (g *GoCloak) DeleteUserFederatedIdentity(ctx context.Context, token, realm,
 // GetUserBruteForceDetectionStatus fetches a user status regarding brute force protection
 func (g *GoCloak) GetUserBruteForceDetectionStatus(ctx context.Context, accessToken, realm, userID string) (*BruteForceStatus, error) {
 	const errMessage = "could not brute force detection Status"
+
+	ctx, span := g.startSpan(ctx, "GetUserBruteForceDetectionStatus")
+	defer span.End()
+
 	var result BruteForceStatus
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -3063,6 +3944,9 @@ func (g *GoCloak) GetUserBruteForceDetectionStatus(ctx context.Context, accessTo
 func (g *GoCloak) CreateIdentityProvider(ctx context.Context, token string, realm string, providerRep IdentityProviderRepresentation) (string, error) {
 	const errMessage = "could not create identity provider"
 
+	ctx, span := g.startSpan(ctx, "CreateIdentityProvider")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(providerRep).
 		Post(g.getAdminRealmURL(realm, "identity-provider", "instances"))
@@ -3078,6 +3962,9 @@ func (g *GoCloak) CreateIdentityProvider(ctx context.Context, token string, real
 func (g *GoCloak) GetIdentityProviders(ctx context.Context, token, realm string) ([]*IdentityProviderRepresentation, error) {
 	const errMessage = "could not get identity providers"
 
+	ctx, span := g.startSpan(ctx, "GetIdentityProviders")
+	defer span.End()
+
 	var result []*IdentityProviderRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3094,6 +3981,9 @@ func (g *GoCloak) GetIdentityProviders(ctx context.Context, token, realm string)
 func (g *GoCloak) GetIdentityProvider(ctx context.Context, token, realm, alias string) (*IdentityProviderRepresentation, error) {
 	const errMessage = "could not get identity provider"
 
+	ctx, span := g.startSpan(ctx, "GetIdentityProvider")
+	defer span.End()
+
 	var result IdentityProviderRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3110,6 +4000,9 @@ func (g *GoCloak) GetIdentityProvider(ctx context.Context, token, realm, alias s
 func (g *GoCloak) UpdateIdentityProvider(ctx context.Context, token, realm, alias string, providerRep IdentityProviderRepresentation) error {
 	const errMessage = "could not update identity provider"
 
+	ctx, span := g.startSpan(ctx, "UpdateIdentityProvider")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(providerRep).
 		Put(g.getAdminRealmURL(realm, "identity-provider", "instances", alias))
@@ -3121,6 +4014,9 @@ func (g *GoCloak) UpdateIdentityProvider(ctx context.Context, token, realm, alia
 func (g *GoCloak) DeleteIdentityProvider(ctx context.Context, token, realm, alias string) error {
 	const errMessage = "could not delete identity provider"
 
+	ctx, span := g.startSpan(ctx, "DeleteIdentityProvider")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "identity-provider", "instances", alias))
 
@@ -3131,6 +4027,9 @@ func (g *GoCloak) DeleteIdentityProvider(ctx context.Context, token, realm, alia
 func (g *GoCloak) ExportIDPPublicBrokerConfig(ctx context.Context, token, realm, alias string) (*string, error) {
 	const errMessage = "could not get public identity provider configuration"
 
+	ctx, span := g.startSpan(ctx, "ExportIDPPublicBrokerConfig")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuthXMLHeader(ctx, token).
 		Get(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "export"))
 
@@ -3146,6 +4045,9 @@ func (g *GoCloak) ExportIDPPublicBrokerConfig(ctx context.Context, token, realm,
 func (g *GoCloak) ImportIdentityProviderConfig(ctx context.Context, token, realm, fromURL, providerID string) (map[string]string, error) {
 	const errMessage = "could not import config"
 
+	ctx, span := g.startSpan(ctx, "ImportIdentityProviderConfig")
+	defer span.End()
+
 	result := make(map[string]string)
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3166,6 +4068,9 @@ func (g *GoCloak) ImportIdentityProviderConfig(ctx context.Context, token, realm
 func (g *GoCloak) ImportIdentityProviderConfigFromFile(ctx context.Context, token, realm, providerID, fileName string, fileBody io.Reader) (map[string]string, error) {
 	const errMessage = "could not import config"
 
+	ctx, span := g.startSpan(ctx, "ImportIdentityProviderConfigFromFile")
+	defer span.End()
+
 	result := make(map[string]string)
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3186,6 +4091,9 @@ func (g *GoCloak) ImportIdentityProviderConfigFromFile(ctx context.Context, toke
 func (g *GoCloak) CreateIdentityProviderMapper(ctx context.Context, token, realm, alias string, mapper IdentityProviderMapper) (string, error) {
 	const errMessage = "could not create mapper for identity provider"
 
+	ctx, span := g.startSpan(ctx, "CreateIdentityProviderMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(mapper).
 		Post(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "mappers"))
@@ -3201,6 +4109,9 @@ func (g *GoCloak) CreateIdentityProviderMapper(ctx context.Context, token, realm
 func (g *GoCloak) GetIdentityProviderMapper(ctx context.Context, token string, realm string, alias string, mapperID string) (*IdentityProviderMapper, error) {
 	const errMessage = "could not get identity provider mapper"
 
+	ctx, span := g.startSpan(ctx, "GetIdentityProviderMapper")
+	defer span.End()
+
 	result := IdentityProviderMapper{}
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3217,6 +4128,9 @@ func (g *GoCloak) GetIdentityProviderMapper(ctx context.Context, token string, r
 func (g *GoCloak) DeleteIdentityProviderMapper(ctx context.Context, token, realm, alias, mapperID string) error {
 	const errMessage = "could not delete mapper for identity provider"
 
+	ctx, span := g.startSpan(ctx, "DeleteIdentityProviderMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "mappers", mapperID))
 
@@ -3227,6 +4141,9 @@ func (g *GoCloak) DeleteIdentityProviderMapper(ctx context.Context, token, realm
 func (g *GoCloak) GetIdentityProviderMappers(ctx context.Context, token, realm, alias string) ([]*IdentityProviderMapper, error) {
 	const errMessage = "could not get identity provider mappers"
 
+	ctx, span := g.startSpan(ctx, "GetIdentityProviderMappers")
+	defer span.End()
+
 	var result []*IdentityProviderMapper
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3243,6 +4160,9 @@ func (g *GoCloak) GetIdentityProviderMappers(ctx context.Context, token, realm,
 func (g *GoCloak) GetIdentityProviderMapperByID(ctx context.Context, token, realm, alias, mapperID string) (*IdentityProviderMapper, error) {
 	const errMessage = "could not get identity provider mappers"
 
+	ctx, span := g.startSpan(ctx, "GetIdentityProviderMapperByID")
+	defer span.End()
+
 	var result IdentityProviderMapper
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3259,6 +4179,9 @@ func (g *GoCloak) GetIdentityProviderMapperByID(ctx context.Context, token, real
 func (g *GoCloak) UpdateIdentityProviderMapper(ctx context.Context, token, realm, alias string, mapper IdentityProviderMapper) error {
 	const errMessage = "could not update identity provider mapper"
 
+	ctx, span := g.startSpan(ctx, "UpdateIdentityProviderMapper")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(mapper).
 		Put(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "mappers", PString(mapper.ID)))
@@ -3274,6 +4197,9 @@ func (g *GoCloak) UpdateIdentityProviderMapper(ctx context.Context, token, realm
 func (g *GoCloak) GetResource(ctx context.Context, token, realm, idOfClient, resourceID string) (*ResourceRepresentation, error) {
 	const errMessage = "could not get resource"
 
+	ctx, span := g.startSpan(ctx, "GetResource")
+	defer span.End()
+
 	var result ResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3290,6 +4216,9 @@ func (g *GoCloak) GetResource(ctx context.Context, token, realm, idOfClient, res
 func (g *GoCloak) GetResourceClient(ctx context.Context, token, realm, resourceID string) (*ResourceRepresentation, error) {
 	const errMessage = "could not get resource"
 
+	ctx, span := g.startSpan(ctx, "GetResourceClient")
+	defer span.End()
+
 	var result ResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3308,6 +4237,9 @@ func (g *GoCloak) GetResourceClient(ctx context.Context, token, realm, resourceI
 func (g *GoCloak) GetResources(ctx context.Context, token, realm, idOfClient string, params GetResourceParams) ([]*ResourceRepresentation, error) {
 	const errMessage = "could not get resources"
 
+	ctx, span := g.startSpan(ctx, "GetResources")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, err
@@ -3330,6 +4262,9 @@ func (g *GoCloak) GetResources(ctx context.Context, token, realm, idOfClient str
 func (g *GoCloak) GetResourcesClient(ctx context.Context, token, realm string, params GetResourceParams) ([]*ResourceRepresentation, error) {
 	const errMessage = "could not get resources"
 
+	ctx, span := g.startSpan(ctx, "GetResourcesClient")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, err
@@ -3362,6 +4297,9 @@ func (g *GoCloak) GetResourcesClient(ctx context.Context, token, realm string, p
 func (g *GoCloak) GetResourceServer(ctx context.Context, token, realm, idOfClient string) (*ResourceServerRepresentation, error) {
 	const errMessage = "could not get resource server settings"
 
+	ctx, span := g.startSpan(ctx, "GetResourceServer")
+	defer span.End()
+
 	var result *ResourceServerRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3378,6 +4316,9 @@ func (g *GoCloak) GetResourceServer(ctx context.Context, token, realm, idOfClien
 func (g *GoCloak) UpdateResource(ctx context.Context, token, realm, idOfClient string, resource ResourceRepresentation) error {
 	const errMessage = "could not update resource"
 
+	ctx, span := g.startSpan(ctx, "UpdateResource")
+	defer span.End()
+
 	if NilOrEmpty(resource.ID) {
 		return errors.New("ID of a resource required")
 	}
@@ -3393,6 +4334,9 @@ func (g *GoCloak) UpdateResource(ctx context.Context, token, realm, idOfClient s
 func (g *GoCloak) UpdateResourceClient(ctx context.Context, token, realm string, resource ResourceRepresentation) error {
 	const errMessage = "could not update resource"
 
+	ctx, span := g.startSpan(ctx, "UpdateResourceClient")
+	defer span.End()
+
 	if NilOrEmpty(resource.ID) {
 		return errors.New("ID of a resource required")
 	}
@@ -3408,6 +4352,9 @@ func (g *GoCloak) UpdateResourceClient(ctx context.Context, token, realm string,
 func (g *GoCloak) CreateResource(ctx context.Context, token, realm string, idOfClient string, resource ResourceRepresentation) (*ResourceRepresentation, error) {
 	const errMessage = "could not create resource"
 
+	ctx, span := g.startSpan(ctx, "CreateResource")
+	defer span.End()
+
 	var result ResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3425,6 +4372,9 @@ func (g *GoCloak) CreateResource(ctx context.Context, token, realm string, idOfC
 func (g *GoCloak) CreateResourceClient(ctx context.Context, token, realm string, resource ResourceRepresentation) (*ResourceRepresentation, error) {
 	const errMessage = "could not create resource"
 
+	ctx, span := g.startSpan(ctx, "CreateResourceClient")
+	defer span.End()
+
 	var result ResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3442,6 +4392,9 @@ func (g *GoCloak) CreateResourceClient(ctx context.Context, token, realm string,
 func (g *GoCloak) DeleteResource(ctx context.Context, token, realm, idOfClient, resourceID string) error {
 	const errMessage = "could not delete resource"
 
+	ctx, span := g.startSpan(ctx, "DeleteResource")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "resource", resourceID))
 
@@ -3452,6 +4405,9 @@ func (g *GoCloak) DeleteResource(ctx context.Context, token, realm, idOfClient,
 func (g *GoCloak) DeleteResourceClient(ctx context.Context, token, realm, resourceID string) error {
 	const errMessage = "could not delete resource"
 
+	ctx, span := g.startSpan(ctx, "DeleteResourceClient")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getRealmURL(realm, "authz", "protection", "resource_set", resourceID))
 
@@ -3462,6 +4418,9 @@ func (g *GoCloak) DeleteResourceClient(ctx context.Context, token, realm, resour
 func (g *GoCloak) GetScope(ctx context.Context, token, realm, idOfClient, scopeID string) (*ScopeRepresentation, error) {
 	const errMessage = "could not get scope"
 
+	ctx, span := g.startSpan(ctx, "GetScope")
+	defer span.End()
+
 	var result ScopeRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3478,6 +4437,9 @@ func (g *GoCloak) GetScope(ctx context.Context, token, realm, idOfClient, scopeI
 func (g *GoCloak) GetScopes(ctx context.Context, token, realm, idOfClient string, params GetScopeParams) ([]*ScopeRepresentation, error) {
 	const errMessage = "could not get scopes"
 
+	ctx, span := g.startSpan(ctx, "GetScopes")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, err
@@ -3499,6 +4461,9 @@ func (g *GoCloak) GetScopes(ctx context.Context, token, realm, idOfClient string
 func (g *GoCloak) CreateScope(ctx context.Context, token, realm, idOfClient string, scope ScopeRepresentation) (*ScopeRepresentation, error) {
 	const errMessage = "could not create scope"
 
+	ctx, span := g.startSpan(ctx, "CreateScope")
+	defer span.End()
+
 	var result ScopeRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3516,6 +4481,9 @@ func (g *GoCloak) CreateScope(ctx context.Context, token, realm, idOfClient stri
 func (g *GoCloak) GetPermissionScope(ctx context.Context, token, realm, idOfClient string, idOfScope string) (*PolicyRepresentation, error) {
 	const errMessage = "could not get permission scope"
 
+	ctx, span := g.startSpan(ctx, "GetPermissionScope")
+	defer span.End()
+
 	var result PolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3533,6 +4501,9 @@ func (g *GoCloak) GetPermissionScope(ctx context.Context, token, realm, idOfClie
 func (g *GoCloak) UpdatePermissionScope(ctx context.Context, token, realm, idOfClient string, idOfScope string, policy PolicyRepresentation) error {
 	const errMessage = "could not create permission scope"
 
+	ctx, span := g.startSpan(ctx, "UpdatePermissionScope")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(policy).
 		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "permission", "scope", idOfScope))
@@ -3544,6 +4515,9 @@ func (g *GoCloak) UpdatePermissionScope(ctx context.Context, token, realm, idOfC
 func (g *GoCloak) UpdateScope(ctx context.Context, token, realm, idOfClient string, scope ScopeRepresentation) error {
 	const errMessage = "could not update scope"
 
+	ctx, span := g.startSpan(ctx, "UpdateScope")
+	defer span.End()
+
 	if NilOrEmpty(scope.ID) {
 		return errors.New("ID of a scope required")
 	}
@@ -3559,6 +4533,9 @@ func (g *GoCloak) UpdateScope(ctx context.Context, token, realm, idOfClient stri
 func (g *GoCloak) DeleteScope(ctx context.Context, token, realm, idOfClient, scopeID string) error {
 	const errMessage = "could not delete scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteScope")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "scope", scopeID))
 
@@ -3569,6 +4546,9 @@ func (g *GoCloak) DeleteScope(ctx context.Context, token, realm, idOfClient, sco
 func (g *GoCloak) GetPolicy(ctx context.Context, token, realm, idOfClient, policyID string) (*PolicyRepresentation, error) {
 	const errMessage = "could not get policy"
 
+	ctx, span := g.startSpan(ctx, "GetPolicy")
+	defer span.End()
+
 	var result PolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3585,6 +4565,9 @@ func (g *GoCloak) GetPolicy(ctx context.Context, token, realm, idOfClient, polic
 func (g *GoCloak) GetPolicies(ctx context.Context, token, realm, idOfClient string, params GetPolicyParams) ([]*PolicyRepresentation, error) {
 	const errMessage = "could not get policies"
 
+	ctx, span := g.startSpan(ctx, "GetPolicies")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
@@ -3618,6 +4601,9 @@ func (g *GoCloak) GetPolicies(ctx context.Context, token, realm, idOfClient stri
 func (g *GoCloak) CreatePolicy(ctx context.Context, token, realm, idOfClient string, policy PolicyRepresentation) (*PolicyRepresentation, error) {
 	const errMessage = "could not create policy"
 
+	ctx, span := g.startSpan(ctx, "CreatePolicy")
+	defer span.End()
+
 	if NilOrEmpty(policy.Type) {
 		return nil, errors.New("type of a policy required")
 	}
@@ -3654,6 +4640,9 @@ func (g *GoCloak) CreatePolicy(ctx context.Context, token, realm, idOfClient str
 func (g *GoCloak) UpdatePolicy(ctx context.Context, token, realm, idOfClient string, policy PolicyRepresentation) error {
 	const errMessage = "could not update policy"
 
+	ctx, span := g.startSpan(ctx, "UpdatePolicy")
+	defer span.End()
+
 	if NilOrEmpty(policy.ID) {
 		return errors.New("ID of a policy required")
 	}
@@ -3686,6 +4675,9 @@ func (g *GoCloak) UpdatePolicy(ctx context.Context, token, realm, idOfClient str
 func (g *GoCloak) DeletePolicy(ctx context.Context, token, realm, idOfClient, policyID string) error {
 	const errMessage = "could not delete policy"
 
+	ctx, span := g.startSpan(ctx, "DeletePolicy")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyID))
 
@@ -3696,6 +4688,9 @@ func (g *GoCloak) DeletePolicy(ctx context.Context, token, realm, idOfClient, po
 func (g *GoCloak) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyRepresentation, error) {
 	const errMessage = "could not get policy associated policies"
 
+	ctx, span := g.startSpan(ctx, "GetAuthorizationPolicyAssociatedPolicies")
+	defer span.End()
+
 	var result []*PolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3712,6 +4707,9 @@ func (g *GoCloak) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context,
 func (g *GoCloak) GetAuthorizationPolicyResources(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyResourceRepresentation, error) {
 	const errMessage = "could not get policy resources"
 
+	ctx, span := g.startSpan(ctx, "GetAuthorizationPolicyResources")
+	defer span.End()
+
 	var result []*PolicyResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3728,6 +4726,9 @@ func (g *GoCloak) GetAuthorizationPolicyResources(ctx context.Context, token, re
 func (g *GoCloak) GetAuthorizationPolicyScopes(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyScopeRepresentation, error) {
 	const errMessage = "could not get policy scopes"
 
+	ctx, span := g.startSpan(ctx, "GetAuthorizationPolicyScopes")
+	defer span.End()
+
 	var result []*PolicyScopeRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3744,6 +4745,9 @@ func (g *GoCloak) GetAuthorizationPolicyScopes(ctx context.Context, token, realm
 func (g *GoCloak) GetResourcePolicy(ctx context.Context, token, realm, permissionID string) (*ResourcePolicyRepresentation, error) {
 	const errMessage = "could not get resource policy"
 
+	ctx, span := g.startSpan(ctx, "GetResourcePolicy")
+	defer span.End()
+
 	var result ResourcePolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuthNoCache(ctx, token).
 		SetResult(&result).
@@ -3760,6 +4764,9 @@ func (g *GoCloak) GetResourcePolicy(ctx context.Context, token, realm, permissio
 func (g *GoCloak) GetResourcePolicies(ctx context.Context, token, realm string, params GetResourcePoliciesParams) ([]*ResourcePolicyRepresentation, error) {
 	const errMessage = "could not get resource policies"
 
+	ctx, span := g.startSpan(ctx, "GetResourcePolicies")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, err
@@ -3782,6 +4789,9 @@ func (g *GoCloak) GetResourcePolicies(ctx context.Context, token, realm string,
 func (g *GoCloak) CreateResourcePolicy(ctx context.Context, token, realm, resourceID string, policy ResourcePolicyRepresentation) (*ResourcePolicyRepresentation, error) {
 	const errMessage = "could not create resource policy"
 
+	ctx, span := g.startSpan(ctx, "CreateResourcePolicy")
+	defer span.End()
+
 	var result ResourcePolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuthNoCache(ctx, token).
 		SetResult(&result).
@@ -3799,6 +4809,9 @@ func (g *GoCloak) CreateResourcePolicy(ctx context.Context, token, realm, resour
 func (g *GoCloak) UpdateResourcePolicy(ctx context.Context, token, realm, permissionID string, policy ResourcePolicyRepresentation) error {
 	const errMessage = "could not update resource policy"
 
+	ctx, span := g.startSpan(ctx, "UpdateResourcePolicy")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuthNoCache(ctx, token).
 		SetBody(policy).
 		Put(g.getRealmURL(realm, "authz", "protection", "uma-policy", permissionID))
@@ -3810,6 +4823,9 @@ func (g *GoCloak) UpdateResourcePolicy(ctx context.Context, token, realm, permis
 func (g *GoCloak) DeleteResourcePolicy(ctx context.Context, token, realm, permissionID string) error {
 	const errMessage = "could not  delete resource policy"
 
+	ctx, span := g.startSpan(ctx, "DeleteResourcePolicy")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getRealmURL(realm, "authz", "protection", "uma-policy", permissionID))
 
@@ -3820,6 +4836,9 @@ func (g *GoCloak) DeleteResourcePolicy(ctx context.Context, token, realm, permis
 func (g *GoCloak) GetPermission(ctx context.Context, token, realm, idOfClient, permissionID string) (*PermissionRepresentation, error) {
 	const errMessage = "could not get permission"
 
+	ctx, span := g.startSpan(ctx, "GetPermission")
+	defer span.End()
+
 	var result PermissionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3836,6 +4855,9 @@ func (g *GoCloak) GetPermission(ctx context.Context, token, realm, idOfClient, p
 func (g *GoCloak) GetDependentPermissions(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PermissionRepresentation, error) {
 	const errMessage = "could not get permission"
 
+	ctx, span := g.startSpan(ctx, "GetDependentPermissions")
+	defer span.End()
+
 	var result []*PermissionRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3852,6 +4874,9 @@ func (g *GoCloak) GetDependentPermissions(ctx context.Context, token, realm, idO
 func (g *GoCloak) GetPermissionResources(ctx context.Context, token, realm, idOfClient, permissionID string) ([]*PermissionResource, error) {
 	const errMessage = "could not get permission resource"
 
+	ctx, span := g.startSpan(ctx, "GetPermissionResources")
+	defer span.End()
+
 	var result []*PermissionResource
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3868,6 +4893,9 @@ func (g *GoCloak) GetPermissionResources(ctx context.Context, token, realm, idOf
 func (g *GoCloak) GetScopePermissions(ctx context.Context, token, realm, idOfClient, idOfScope string) ([]*PolicyRepresentation, error) {
 	const errMessage = "could not get scope permissions"
 
+	ctx, span := g.startSpan(ctx, "GetScopePermissions")
+	defer span.End()
+
 	var result []*PolicyRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3883,6 +4911,9 @@ func (g *GoCloak) GetScopePermissions(ctx context.Context, token, realm, idOfCli
 func (g *GoCloak) GetPermissionScopes(ctx context.Context, token, realm, idOfClient, permissionID string) ([]*PermissionScope, error) {
 	const errMessage = "could not get permission scopes"
 
+	ctx, span := g.startSpan(ctx, "GetPermissionScopes")
+	defer span.End()
+
 	var result []*PermissionScope
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -3899,6 +4930,9 @@ func (g *GoCloak) GetPermissionScopes(ctx context.Context, token, realm, idOfCli
 func (g *GoCloak) GetPermissions(ctx context.Context, token, realm, idOfClient string, params GetPermissionParams) ([]*PermissionRepresentation, error) {
 	const errMessage = "could not get permissions"
 
+	ctx, span := g.startSpan(ctx, "GetPermissions")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
@@ -3945,6 +4979,9 @@ func checkPermissionTicketParams(permissions []CreatePermissionTicketParams) err
 func (g *GoCloak) CreatePermissionTicket(ctx context.Context, token, realm string, permissions []CreatePermissionTicketParams) (*PermissionTicketResponseRepresentation, error) {
 	const errMessage = "could not create permission ticket"
 
+	ctx, span := g.startSpan(ctx, "CreatePermissionTicket")
+	defer span.End()
+
 	err := checkPermissionTicketParams(permissions)
 	if err != nil {
 		return nil, err
@@ -3982,6 +5019,9 @@ func checkPermissionGrantParams(permission PermissionGrantParams) error {
 func (g *GoCloak) GrantUserPermission(ctx context.Context, token, realm string, permission PermissionGrantParams) (*PermissionGrantResponseRepresentation, error) {
 	const errMessage = "could not grant user permission"
 
+	ctx, span := g.startSpan(ctx, "GrantUserPermission")
+	defer span.End()
+
 	err := checkPermissionGrantParams(permission)
 	if err != nil {
 		return nil, err
@@ -4020,6 +5060,9 @@ func checkPermissionUpdateParams(permission PermissionGrantParams) error {
 func (g *GoCloak) UpdateUserPermission(ctx context.Context, token, realm string, permission PermissionGrantParams) (*PermissionGrantResponseRepresentation, error) {
 	const errMessage = "could not update user permission"
 
+	ctx, span := g.startSpan(ctx, "UpdateUserPermission")
+	defer span.End()
+
 	err := checkPermissionUpdateParams(permission)
 	if err != nil {
 		return nil, err
@@ -4047,6 +5090,9 @@ func (g *GoCloak) UpdateUserPermission(ctx context.Context, token, realm string,
 func (g *GoCloak) GetUserPermissions(ctx context.Context, token, realm string, params GetUserPermissionParams) ([]*PermissionGrantResponseRepresentation, error) {
 	const errMessage = "could not get user permissions"
 
+	ctx, span := g.startSpan(ctx, "GetUserPermissions")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, err
@@ -4069,6 +5115,9 @@ func (g *GoCloak) GetUserPermissions(ctx context.Context, token, realm string, p
 func (g *GoCloak) DeleteUserPermission(ctx context.Context, token, realm, ticketID string) error {
 	const errMessage = "could not delete user permission"
 
+	ctx, span := g.startSpan(ctx, "DeleteUserPermission")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getRealmURL(realm, "authz", "protection", "permission", "ticket", ticketID))
 
@@ -4079,6 +5128,9 @@ func (g *GoCloak) DeleteUserPermission(ctx context.Context, token, realm, ticket
 func (g *GoCloak) CreatePermission(ctx context.Context, token, realm, idOfClient string, permission PermissionRepresentation) (*PermissionRepresentation, error) {
 	const errMessage = "could not create permission"
 
+	ctx, span := g.startSpan(ctx, "CreatePermission")
+	defer span.End()
+
 	if NilOrEmpty(permission.Type) {
 		return nil, errors.New("type of a permission required")
 	}
@@ -4100,6 +5152,9 @@ func (g *GoCloak) CreatePermission(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) UpdatePermission(ctx context.Context, token, realm, idOfClient string, permission PermissionRepresentation) error {
 	const errMessage = "could not update permission"
 
+	ctx, span := g.startSpan(ctx, "UpdatePermission")
+	defer span.End()
+
 	if NilOrEmpty(permission.ID) {
 		return errors.New("ID of a permission required")
 	}
@@ -4114,6 +5169,9 @@ func (g *GoCloak) UpdatePermission(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) DeletePermission(ctx context.Context, token, realm, idOfClient, permissionID string) error {
 	const errMessage = "could not delete permission"
 
+	ctx, span := g.startSpan(ctx, "DeletePermission")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "permission", permissionID))
 
@@ -4128,6 +5186,9 @@ func (g *GoCloak) DeletePermission(ctx context.Context, token, realm, idOfClient
 func (g *GoCloak) GetCredentialRegistrators(ctx context.Context, token, realm string) ([]string, error) {
 	const errMessage = "could not get user credential registrators"
 
+	ctx, span := g.startSpan(ctx, "GetCredentialRegistrators")
+	defer span.End()
+
 	var result []string
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -4144,6 +5205,9 @@ func (g *GoCloak) GetCredentialRegistrators(ctx context.Context, token, realm st
 func (g *GoCloak) GetConfiguredUserStorageCredentialTypes(ctx context.Context, token, realm, userID string) ([]string, error) {
 	const errMessage = "could not get user credential registrators"
 
+	ctx, span := g.startSpan(ctx, "GetConfiguredUserStorageCredentialTypes")
+	defer span.End()
+
 	var result []string
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -4160,6 +5224,9 @@ func (g *GoCloak) GetConfiguredUserStorageCredentialTypes(ctx context.Context, t
 func (g *GoCloak) GetCredentials(ctx context.Context, token, realm, userID string) ([]*CredentialRepresentation, error) {
 	const errMessage = "could not get user credentials"
 
+	ctx, span := g.startSpan(ctx, "GetCredentials")
+	defer span.End()
+
 	var result []*CredentialRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
@@ -4176,6 +5243,9 @@ func (g *GoCloak) GetCredentials(ctx context.Context, token, realm, userID strin
 func (g *GoCloak) DeleteCredentials(ctx context.Context, token, realm, userID, credentialID string) error {
 	const errMessage = "could not delete user credentials"
 
+	ctx, span := g.startSpan(ctx, "DeleteCredentials")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID))
 
@@ -4186,6 +5256,9 @@ func (g *GoCloak) DeleteCredentials(ctx context.Context, token, realm, userID, c
 func (g *GoCloak) UpdateCredentialUserLabel(ctx context.Context, token, realm, userID, credentialID, userLabel string) error {
 	const errMessage = "could not update credential label for a user"
 
+	ctx, span := g.startSpan(ctx, "UpdateCredentialUserLabel")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetHeader("Content-Type", "text/plain").
 		SetBody(userLabel).
@@ -4198,6 +5271,9 @@ func (g *GoCloak) UpdateCredentialUserLabel(ctx context.Context, token, realm, u
 func (g *GoCloak) DisableAllCredentialsByType(ctx context.Context, token, realm, userID string, types []string) error {
 	const errMessage = "could not update disable credentials"
 
+	ctx, span := g.startSpan(ctx, "DisableAllCredentialsByType")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(types).
 		Put(g.getAdminRealmURL(realm, "users", userID, "disable-credential-types"))
@@ -4209,6 +5285,9 @@ func (g *GoCloak) DisableAllCredentialsByType(ctx context.Context, token, realm,
 func (g *GoCloak) MoveCredentialBehind(ctx context.Context, token, realm, userID, credentialID, newPreviousCredentialID string) error {
 	const errMessage = "could not move credential"
 
+	ctx, span := g.startSpan(ctx, "MoveCredentialBehind")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveAfter", newPreviousCredentialID))
 
@@ -4219,6 +5298,9 @@ func (g *GoCloak) MoveCredentialBehind(ctx context.Context, token, realm, userID
 func (g *GoCloak) MoveCredentialToFirst(ctx context.Context, token, realm, userID, credentialID string) error {
 	const errMessage = "could not move credential"
 
+	ctx, span := g.startSpan(ctx, "MoveCredentialToFirst")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveToFirst"))
 
@@ -4229,6 +5311,9 @@ func (g *GoCloak) MoveCredentialToFirst(ctx context.Context, token, realm, userI
 func (g *GoCloak) GetEvents(ctx context.Context, token string, realm string, params GetEventsParams) ([]*EventRepresentation, error) {
 	const errMessage = "could not get events"
 
+	ctx, span := g.startSpan(ctx, "GetEvents")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
@@ -4251,6 +5336,9 @@ func (g *GoCloak) GetEvents(ctx context.Context, token string, realm string, par
 func (g *GoCloak) GetClientScopesScopeMappingsRealmRolesAvailable(ctx context.Context, token, realm, clientScopeID string) ([]*Role, error) {
 	const errMessage = "could not get available realm-level roles with the client-scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopesScopeMappingsRealmRolesAvailable")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4268,6 +5356,9 @@ func (g *GoCloak) GetClientScopesScopeMappingsRealmRolesAvailable(ctx context.Co
 func (g *GoCloak) GetClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm, clientScopeID string) ([]*Role, error) {
 	const errMessage = "could not get realm-level roles with the client-scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopesScopeMappingsRealmRoles")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4285,6 +5376,9 @@ func (g *GoCloak) GetClientScopesScopeMappingsRealmRoles(ctx context.Context, to
 func (g *GoCloak) DeleteClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm, clientScopeID string, roles []Role) error {
 	const errMessage = "could not delete realm-level roles from the client-scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScopesScopeMappingsRealmRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "client-scopes", clientScopeID, "scope-mappings", "realm"))
@@ -4296,6 +5390,9 @@ func (g *GoCloak) DeleteClientScopesScopeMappingsRealmRoles(ctx context.Context,
 func (g *GoCloak) CreateClientScopesScopeMappingsRealmRoles(ctx context.Context, token, realm, clientScopeID string, roles []Role) error {
 	const errMessage = "could not create realm-level roles to the client-scope"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScopesScopeMappingsRealmRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "client-scopes", clientScopeID, "scope-mappings", "realm"))
@@ -4307,6 +5404,9 @@ func (g *GoCloak) CreateClientScopesScopeMappingsRealmRoles(ctx context.Context,
 func (g *GoCloak) RegisterRequiredAction(ctx context.Context, token string, realm string, requiredAction RequiredActionProviderRepresentation) error {
 	const errMessage = "could not create required action"
 
+	ctx, span := g.startSpan(ctx, "RegisterRequiredAction")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(requiredAction).
 		Post(g.getAdminRealmURL(realm, "authentication", "register-required-action"))
@@ -4322,6 +5422,9 @@ func (g *GoCloak) RegisterRequiredAction(ctx context.Context, token string, real
 func (g *GoCloak) GetUnregisteredRequiredActions(ctx context.Context, token string, realm string) ([]*UnregisteredRequiredActionProviderRepresentation, error) {
 	const errMessage = "could not get unregistered required actions"
 
+	ctx, span := g.startSpan(ctx, "GetUnregisteredRequiredActions")
+	defer span.End()
+
 	var result []*UnregisteredRequiredActionProviderRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4338,6 +5441,10 @@ func (g *GoCloak) GetUnregisteredRequiredActions(ctx context.Context, token stri
 // GetRequiredActions gets a list of required actions for a given realm
 func (g *GoCloak) GetRequiredActions(ctx context.Context, token string, realm string) ([]*RequiredActionProviderRepresentation, error) {
 	const errMessage = "could not get required actions"
+
+	ctx, span := g.startSpan(ctx, "GetRequiredActions")
+	defer span.End()
+
 	var result []*RequiredActionProviderRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4354,6 +5461,10 @@ func (g *GoCloak) GetRequiredActions(ctx context.Context, token string, realm st
 // GetRequiredAction gets a required action for a given realm
 func (g *GoCloak) GetRequiredAction(ctx context.Context, token string, realm string, alias string) (*RequiredActionProviderRepresentation, error) {
 	const errMessage = "could not get required action"
+
+	ctx, span := g.startSpan(ctx, "GetRequiredAction")
+	defer span.End()
+
 	var result RequiredActionProviderRepresentation
 
 	if alias == "" {
@@ -4375,6 +5486,9 @@ func (g *GoCloak) GetRequiredAction(ctx context.Context, token string, realm str
 func (g *GoCloak) UpdateRequiredAction(ctx context.Context, token string, realm string, requiredAction RequiredActionProviderRepresentation) error {
 	const errMessage = "could not update required action"
 
+	ctx, span := g.startSpan(ctx, "UpdateRequiredAction")
+	defer span.End()
+
 	if NilOrEmpty(requiredAction.ProviderID) {
 		return errors.New("providerId is required for updating a required action")
 	}
@@ -4389,6 +5503,9 @@ func (g *GoCloak) UpdateRequiredAction(ctx context.Context, token string, realm
 func (g *GoCloak) DeleteRequiredAction(ctx context.Context, token string, realm string, alias string) error {
 	const errMessage = "could not delete required action"
 
+	ctx, span := g.startSpan(ctx, "DeleteRequiredAction")
+	defer span.End()
+
 	if alias == "" {
 		return errors.New("alias is required for deleting a required action")
 	}
@@ -4408,6 +5525,9 @@ func (g *GoCloak) CreateClientScopesScopeMappingsClientRoles(
 ) error {
 	const errMessage = "could not create client-level roles to the client-scope"
 
+	ctx, span := g.startSpan(ctx, "CreateClientScopesScopeMappingsClientRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Post(g.getAdminRealmURL(realm, "client-scopes", idOfClientScope, "scope-mappings", "clients", idOfClient))
@@ -4421,6 +5541,9 @@ func (g *GoCloak) CreateClientScopesScopeMappingsClientRoles(
 func (g *GoCloak) GetClientScopesScopeMappingsClientRolesAvailable(ctx context.Context, token, realm, idOfClientScope, idOfClient string) ([]*Role, error) {
 	const errMessage = "could not get available client-level roles with the client-scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopesScopeMappingsClientRolesAvailable")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4439,6 +5562,9 @@ func (g *GoCloak) GetClientScopesScopeMappingsClientRolesAvailable(ctx context.C
 func (g *GoCloak) GetClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClientScope, idOfClient string) ([]*Role, error) {
 	const errMessage = "could not get client-level roles with the client-scope"
 
+	ctx, span := g.startSpan(ctx, "GetClientScopesScopeMappingsClientRoles")
+	defer span.End()
+
 	var result []*Role
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4457,6 +5583,9 @@ func (g *GoCloak) GetClientScopesScopeMappingsClientRoles(ctx context.Context, t
 func (g *GoCloak) DeleteClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClientScope, idOfClient string, roles []Role) error {
 	const errMessage = "could not delete client-level roles from the client-scope"
 
+	ctx, span := g.startSpan(ctx, "DeleteClientScopesScopeMappingsClientRoles")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(roles).
 		Delete(g.getAdminRealmURL(realm, "client-scopes", idOfClientScope, "scope-mappings", "clients", idOfClient))
@@ -4468,13 +5597,30 @@ func (g *GoCloak) DeleteClientScopesScopeMappingsClientRoles(ctx context.Context
 func (g *GoCloak) RevokeToken(ctx context.Context, realm, clientID, clientSecret, refreshToken string) error {
 	const errMessage = "could not revoke token"
 
-	resp, err := g.GetRequestWithBasicAuth(ctx, clientID, clientSecret).
-		SetFormData(map[string]string{
-			"client_id":     clientID,
-			"client_secret": clientSecret,
-			"token":         refreshToken,
-		}).
-		Post(g.getRealmURL(realm, g.Config.revokeEndpoint))
+	ctx, span := g.startSpan(ctx, "RevokeToken")
+	defer span.End()
+
+	revocationURL, err := g.getRevocationURL(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, clientID, clientSecret, nil)
+	if err != nil {
+		return errors.Wrap(err, errMessage)
+	}
+
+	formData := map[string]string{
+		"client_id": clientID,
+		"token":     refreshToken,
+	}
+	// an authenticator replaces the secret, sending both would be two authentication methods
+	if clientSecret != "" && g.getClientAuthenticator(clientID, nil) == nil {
+		formData["client_secret"] = clientSecret
+	}
+
+	resp, err := req.SetFormData(formData).
+		Post(revocationURL)
 
 	return checkForError(resp, err, errMessage)
 }
@@ -4483,6 +5629,9 @@ func (g *GoCloak) RevokeToken(ctx context.Context, realm, clientID, clientSecret
 func (g *GoCloak) UpdateUsersManagementPermissions(ctx context.Context, accessToken, realm string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not update users management permissions"
 
+	ctx, span := g.startSpan(ctx, "UpdateUsersManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -4501,6 +5650,9 @@ func (g *GoCloak) UpdateUsersManagementPermissions(ctx context.Context, accessTo
 func (g *GoCloak) GetUsersManagementPermissions(ctx context.Context, accessToken, realm string) (*ManagementPermissionRepresentation, error) {
 	const errMessage = "could not get users management permissions"
 
+	ctx, span := g.startSpan(ctx, "GetUsersManagementPermissions")
+	defer span.End()
+
 	var result ManagementPermissionRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
@@ -4518,6 +5670,9 @@ func (g *GoCloak) GetUsersManagementPermissions(ctx context.Context, accessToken
 func (g *GoCloak) CreateOrganization(ctx context.Context, token, realm string, organization OrganizationRepresentation) (string, error) {
 	const errMessage = "could not create organization"
 
+	ctx, span := g.startSpan(ctx, "CreateOrganization")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(organization).
 		Post(g.getAdminRealmURL(realm, "organizations"))
@@ -4533,6 +5688,9 @@ func (g *GoCloak) CreateOrganization(ctx context.Context, token, realm string, o
 func (g *GoCloak) GetOrganizations(ctx context.Context, token, realm string, params GetOrganizationsParams) ([]*OrganizationRepresentation, error) {
 	const errMessage = "could not get organizations"
 
+	ctx, span := g.startSpan(ctx, "GetOrganizations")
+	defer span.End()
+
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
@@ -4554,6 +5712,10 @@ func (g *GoCloak) GetOrganizations(ctx context.Context, token, realm string, par
 // GetOrganizationByID returns the organization representation of the organization with provided ID
 func (g *GoCloak) GetOrganizationByID(ctx context.Context, token, realm, idOfOrganization string) (*OrganizationRepresentation, error) {
 	const errMessage = "could not find organization"
+
+	ctx, span := g.startSpan(ctx, "GetOrganizationByID")
+	defer span.End()
+
 	var result *OrganizationRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4571,6 +5733,9 @@ func (g *GoCloak) GetOrganizationByID(ctx context.Context, token, realm, idOfOrg
 func (g *GoCloak) UpdateOrganization(ctx context.Context, token, realm string, organization OrganizationRepresentation) error {
 	const errMessage = "could not update organization"
 
+	ctx, span := g.startSpan(ctx, "UpdateOrganization")
+	defer span.End()
+
 	if NilOrEmpty(organization.ID) {
 		return errors.Wrap(errors.New("ID of an organization required"), errMessage)
 	}
@@ -4586,6 +5751,9 @@ func (g *GoCloak) UpdateOrganization(ctx context.Context, token, realm string, o
 func (g *GoCloak) DeleteOrganization(ctx context.Context, token, realm, idOfOrganization string) error {
 	const errMessage = "could not delete organization"
 
+	ctx, span := g.startSpan(ctx, "DeleteOrganization")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "organizations", idOfOrganization))
 
@@ -4598,6 +5766,9 @@ func (g *GoCloak) DeleteOrganization(ctx context.Context, token, realm, idOfOrga
 func (g *GoCloak) InviteUserToOrganization(ctx context.Context, token, realm string, idOfOrganization string, user OrganizationInviteUserParams) error {
 	const errMessage = "could not invite organization user"
 
+	ctx, span := g.startSpan(ctx, "InviteUserToOrganization")
+	defer span.End()
+
 	err := checkOrganizationInviteUserParams(user)
 	if err != nil {
 		return err
@@ -4627,6 +5798,9 @@ func checkOrganizationInviteUserParams(invitation OrganizationInviteUserParams)
 func (g *GoCloak) InviteUserToOrganizationByID(ctx context.Context, token, realm, idOfOrganization, idOfUser string) error {
 	const errMessage = "could not invite user to organization by id"
 
+	ctx, span := g.startSpan(ctx, "InviteUserToOrganizationByID")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetFormData(map[string]string{
 			"id": idOfUser,
@@ -4642,6 +5816,9 @@ func (g *GoCloak) InviteUserToOrganizationByID(ctx context.Context, token, realm
 func (g *GoCloak) AddUserToOrganization(ctx context.Context, token, realm, idOfOrganization, idOfUser string) error {
 	const errMessage = "could not add user to organization"
 
+	ctx, span := g.startSpan(ctx, "AddUserToOrganization")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(idOfUser).
 		Post(g.getAdminRealmURL(realm, "organizations", idOfOrganization, "members"))
@@ -4652,6 +5829,10 @@ func (g *GoCloak) AddUserToOrganization(ctx context.Context, token, realm, idOfO
 // GetOrganizationMemberCount returns number of members in the organization.
 func (g *GoCloak) GetOrganizationMemberCount(ctx context.Context, token, realm, idOfOrganization string) (int, error) {
 	const errMessage = "could not get organization members count"
+
+	ctx, span := g.startSpan(ctx, "GetOrganizationMemberCount")
+	defer span.End()
+
 	var result int
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4669,6 +5850,9 @@ func (g *GoCloak) GetOrganizationMemberCount(ctx context.Context, token, realm,
 func (g *GoCloak) GetOrganizationMembers(ctx context.Context, token, realm, idOfOrganization string, params GetMembersParams) ([]*MemberRepresentation, error) {
 	const errMessage = "could not get organization members"
 
+	ctx, span := g.startSpan(ctx, "GetOrganizationMembers")
+	defer span.End()
+
 	var result []*MemberRepresentation
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
@@ -4692,6 +5876,10 @@ func (g *GoCloak) GetOrganizationMembers(ctx context.Context, token, realm, idOf
 // Otherwise,an error response with status NOT_FOUND is returned
 func (g *GoCloak) GetOrganizationMemberByID(ctx context.Context, token, realm, idOfOrganization, idOfUser string) (*MemberRepresentation, error) {
 	const errMessage = "could not get organization member by ID"
+
+	ctx, span := g.startSpan(ctx, "GetOrganizationMemberByID")
+	defer span.End()
+
 	var result *MemberRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4708,6 +5896,10 @@ func (g *GoCloak) GetOrganizationMemberByID(ctx context.Context, token, realm, i
 // GetMemberAssociatedOrganizations returns the organizations associated with the user that has the specified id
 func (g *GoCloak) GetMemberAssociatedOrganizations(ctx context.Context, token, realm, idOfUser string) ([]*OrganizationRepresentation, error) {
 	const errMessage = "could not get member's associated organizations"
+
+	ctx, span := g.startSpan(ctx, "GetMemberAssociatedOrganizations")
+	defer span.End()
+
 	var result []*OrganizationRepresentation
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
@@ -4724,6 +5916,9 @@ func (g *GoCloak) GetMemberAssociatedOrganizations(ctx context.Context, token, r
 func (g *GoCloak) GetOrganizationMemberOrganizations(ctx context.Context, accessToken, realm, idOfOrganization, idOfUser string) ([]*OrganizationRepresentation, error) {
 	const errMessage = "could not get organization member organizations"
 
+	ctx, span := g.startSpan(ctx, "GetOrganizationMemberOrganizations")
+	defer span.End()
+
 	if idOfOrganization == "" {
 		return nil, errors.Wrap(errors.New("organizationID shall not be empty"), errMessage)
 	}
@@ -4748,6 +5943,9 @@ func (g *GoCloak) GetOrganizationMemberOrganizations(ctx context.Context, access
 func (g *GoCloak) RemoveUserFromOrganization(ctx context.Context, token, realm, idOfOrganization, idOfUser string) error {
 	const errMessage = "could not remove user from organization"
 
+	ctx, span := g.startSpan(ctx, "RemoveUserFromOrganization")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		Delete(g.getAdminRealmURL(realm, "organizations", idOfOrganization, "members", idOfUser))
 
@@ -4759,6 +5957,9 @@ func (g *GoCloak) RemoveUserFromOrganization(ctx context.Context, token, realm,
 func (g *GoCloak) AddIdentityProviderToOrganization(ctx context.Context, token, realm string, idOfOrganization, identityProviderAlias string) error {
 	const errMessage = "could not add identity provider to organization"
 
+	ctx, span := g.startSpan(ctx, "AddIdentityProviderToOrganization")
+	defer span.End()
+
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetBody(identityProviderAlias).
 		Post(g.getAdminRealmURL(realm, "organizations", idOfOrganization, "identity-providers"))
@@ -0,0 +1,169 @@
+package gocloak
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // x5t is defined as SHA-1 thumbprint
+	"crypto/x509"
+	"encoding/base64"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// clientAssertionType is the client_assertion_type of client_secret_jwt and private_key_jwt
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	// defaultClientAssertionLifetime is used if no lifetime is set for a client assertion
+	defaultClientAssertionLifetime = time.Minute
+)
+
+// ClientAuthenticator authenticates a confidential client in requests to the token, introspection,
+// revocation, logout, device authorization, pushed authorization and backchannel authentication endpoints.
+// audience is the issuer URL of the realm.
+type ClientAuthenticator interface {
+	Authenticate(req *resty.Request, clientID, audience string) error
+}
+
+// ClientSecretBasic authenticates the client with its secret in the Authorization header (client_secret_basic)
+type ClientSecretBasic struct {
+	Secret string
+}
+
+// Authenticate implements ClientAuthenticator
+func (a ClientSecretBasic) Authenticate(req *resty.Request, clientID, _ string) error {
+	req.SetHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(clientID+":"+a.Secret)))
+	return nil
+}
+
+// ClientSecretPost authenticates the client with its secret in the form body (client_secret_post)
+type ClientSecretPost struct {
+	Secret string
+}
+
+// Authenticate implements ClientAuthenticator
+func (a ClientSecretPost) Authenticate(req *resty.Request, clientID, _ string) error {
+	req.SetFormData(map[string]string{
+		"client_id":     clientID,
+		"client_secret": a.Secret,
+	})
+	return nil
+}
+
+// ClientSecretJWT authenticates the client with a JWT signed with its secret (client_secret_jwt).
+// SigningMethod defaults to HS256 and Lifetime to one minute.
+type ClientSecretJWT struct {
+	Secret        string
+	SigningMethod *jwt.SigningMethodHMAC
+	Lifetime      time.Duration
+}
+
+// Authenticate implements ClientAuthenticator
+func (a ClientSecretJWT) Authenticate(req *resty.Request, clientID, audience string) error {
+	signingMethod := a.SigningMethod
+	if signingMethod == nil {
+		signingMethod = jwt.SigningMethodHS256
+	}
+
+	token := jwt.NewWithClaims(signingMethod, clientAssertionClaims(clientID, audience, a.Lifetime))
+	assertion, err := token.SignedString([]byte(a.Secret))
+	if err != nil {
+		return errors.Wrap(err, "could not sign client assertion")
+	}
+
+	setClientAssertion(req, clientID, assertion)
+	return nil
+}
+
+// PrivateKeyJWT authenticates the client with a JWT signed with its private key (private_key_jwt).
+// If set, KeyID is sent as kid header and the thumbprints of Certificate as x5t and x5t#S256 headers.
+// Audience defaults to the issuer URL of the realm and Lifetime to one minute.
+type PrivateKeyJWT struct {
+	Key           interface{}
+	SigningMethod jwt.SigningMethod
+	KeyID         string
+	Certificate   *x509.Certificate
+	Audience      string
+	Lifetime      time.Duration
+}
+
+// Authenticate implements ClientAuthenticator
+func (a PrivateKeyJWT) Authenticate(req *resty.Request, clientID, audience string) error {
+	if a.SigningMethod == nil {
+		return errors.New("could not sign client assertion: signing method is required")
+	}
+	if a.Audience != "" {
+		audience = a.Audience
+	}
+
+	token := jwt.NewWithClaims(a.SigningMethod, clientAssertionClaims(clientID, audience, a.Lifetime))
+	if a.KeyID != "" {
+		token.Header["kid"] = a.KeyID
+	}
+	if a.Certificate != nil {
+		sha1Hash := sha1.Sum(a.Certificate.Raw) //nolint:gosec // x5t is defined as SHA-1 thumbprint
+		token.Header["x5t"] = base64.RawURLEncoding.EncodeToString(sha1Hash[:])
+		token.Header["x5t#S256"] = CertificateThumbprint(a.Certificate)
+	}
+
+	assertion, err := token.SignedString(a.Key)
+	if err != nil {
+		return errors.Wrap(err, "could not sign client assertion")
+	}
+
+	setClientAssertion(req, clientID, assertion)
+	return nil
+}
+
+func clientAssertionClaims(clientID, audience string, lifetime time.Duration) jwt.RegisteredClaims {
+	if lifetime <= 0 {
+		lifetime = defaultClientAssertionLifetime
+	}
+
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{audience},
+		ID:        ksuid.New().String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+	}
+}
+
+func setClientAssertion(req *resty.Request, clientID, assertion string) {
+	req.SetFormData(map[string]string{
+		"client_id":             clientID,
+		"client_assertion_type": clientAssertionType,
+		"client_assertion":      assertion,
+	})
+}
+
+// getClientAuthenticator returns the authenticator of the call, or the one set for the client with SetClientAuthenticator
+func (g *GoCloak) getClientAuthenticator(clientID string, authenticator ClientAuthenticator) ClientAuthenticator {
+	if authenticator != nil || clientID == "" {
+		return authenticator
+	}
+
+	return g.Config.clientAuthenticators[clientID]
+}
+
+// getClientAuthRequest returns a form request authenticating the client.
+// The given authenticator takes precedence over the one set for the client with SetClientAuthenticator,
+// which takes precedence over the client secret.
+func (g *GoCloak) getClientAuthRequest(ctx context.Context, realm, clientID, clientSecret string, authenticator ClientAuthenticator) (*resty.Request, error) {
+	authenticator = g.getClientAuthenticator(clientID, authenticator)
+	if authenticator == nil || clientID == "" {
+		return g.GetRequestWithBasicAuth(ctx, clientID, clientSecret), nil
+	}
+
+	req := g.GetRequest(ctx).
+		SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	if err := authenticator.Authenticate(req, clientID, g.getRealmURL(realm)); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
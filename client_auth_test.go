@@ -0,0 +1,174 @@
+package gocloak_test
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func newClientAuthServer(t *testing.T) *fakeServer {
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "active": true})
+	})
+
+	return server
+}
+
+func parseClientAssertion(t *testing.T, form url.Values, key interface{}) (*jwt.Token, jwt.MapClaims) {
+	require.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", form.Get("client_assertion_type"))
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(form.Get("client_assertion"), claims, func(*jwt.Token) (interface{}, error) {
+		return key, nil
+	})
+	require.NoError(t, err)
+	return token, claims
+}
+
+func TestClientAuthenticator_TokenOptions(t *testing.T) {
+	t.Parallel()
+	server := newClientAuthServer(t)
+	client := gocloak.NewClient(server.URL)
+	ctx := context.Background()
+	_, certificate := loadTestKeystore(t)
+	audience := server.URL + "/realms/test"
+
+	login := func(authenticator gocloak.ClientAuthenticator) recordedRequest {
+		_, err := client.GetToken(ctx, "test", gocloak.TokenOptions{
+			ClientID:            gocloak.StringP("backend"),
+			GrantType:           gocloak.StringP("password"),
+			Username:            gocloak.StringP("user"),
+			Password:            gocloak.StringP("password"),
+			ClientAuthenticator: authenticator,
+		})
+		require.NoError(t, err)
+		return server.last(t, "")
+	}
+
+	request := login(gocloak.ClientSecretBasic{Secret: "secret"})
+	require.Equal(t, "Basic YmFja2VuZDpzZWNyZXQ=", request.Header.Get("Authorization"))
+
+	request = login(gocloak.ClientSecretPost{Secret: "secret"})
+	require.Empty(t, request.Header.Get("Authorization"))
+	require.Equal(t, "secret", request.Form.Get("client_secret"))
+
+	request = login(gocloak.ClientSecretJWT{Secret: "a-very-secret-key-of-sufficient-length", SigningMethod: jwt.SigningMethodHS384})
+	token, claims := parseClientAssertion(t, request.Form, []byte("a-very-secret-key-of-sufficient-length"))
+	require.Equal(t, "HS384", token.Method.Alg())
+	require.Equal(t, "backend", claims["iss"])
+	require.Equal(t, "backend", claims["sub"])
+	require.Equal(t, []interface{}{audience}, claims["aud"])
+	require.NotEmpty(t, claims["jti"])
+	require.Equal(t, "password", request.Form.Get("grant_type"))
+
+	request = login(gocloak.PrivateKeyJWT{
+		Key:           certificate.PrivateKey,
+		SigningMethod: jwt.SigningMethodRS256,
+		KeyID:         "key-id",
+		Certificate:   certificate.Leaf,
+	})
+	token, _ = parseClientAssertion(t, request.Form, &certificate.PrivateKey.(*rsa.PrivateKey).PublicKey)
+	require.Equal(t, "key-id", token.Header["kid"])
+	require.Equal(t, gocloak.CertificateThumbprint(certificate.Leaf), token.Header["x5t#S256"])
+	require.NotEmpty(t, token.Header["x5t"])
+}
+
+func TestClientAuthenticator_Client(t *testing.T) {
+	t.Parallel()
+	server := newClientAuthServer(t)
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetClientAuthenticator("backend", gocloak.ClientSecretJWT{Secret: "a-very-secret-key-of-sufficient-length"}))
+	ctx := context.Background()
+
+	_, err := client.RefreshToken(ctx, "refresh-token", "backend", "", "test")
+	require.NoError(t, err)
+	_, err = client.RetrospectToken(ctx, "access-token", "backend", "", "test")
+	require.NoError(t, err)
+	require.NoError(t, client.RevokeToken(ctx, "test", "backend", "secret", "refresh-token"))
+
+	requests := server.recorded("")
+	require.Len(t, requests, 3)
+	for _, request := range requests {
+		require.Empty(t, request.Header.Get("Authorization"), request.Path)
+		require.Empty(t, request.Form.Get("client_secret"), request.Path)
+		_, claims := parseClientAssertion(t, request.Form, []byte("a-very-secret-key-of-sufficient-length"))
+		require.Equal(t, "backend", claims["sub"], request.Path)
+	}
+}
+
+func TestClientAuthenticator_OtherClients(t *testing.T) {
+	t.Parallel()
+	server := newClientAuthServer(t)
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetClientAuthenticator("backend", gocloak.ClientSecretJWT{Secret: "a-very-secret-key-of-sufficient-length"}))
+	ctx := context.Background()
+
+	_, err := client.LoginAdmin(ctx, "admin", "password", "master")
+	require.NoError(t, err)
+	_, err = client.RetrospectToken(ctx, "access-token", "frontend", "frontend-secret", "test")
+	require.NoError(t, err)
+	require.NoError(t, client.Logout(ctx, "frontend", "frontend-secret", "test", "refresh-token"))
+
+	// only the client the authenticator was set for signs assertions
+	requests := server.recorded("")
+	require.Len(t, requests, 3)
+	for _, request := range requests {
+		require.Empty(t, request.Form.Get("client_assertion"), request.Path)
+	}
+	require.Empty(t, requests[0].Header.Get("Authorization"), "admin-cli is a public client")
+	require.Equal(t, "admin-cli", requests[0].Form.Get("client_id"))
+	require.Equal(t, basicAuth("frontend", "frontend-secret"), requests[1].Header.Get("Authorization"))
+	require.Equal(t, basicAuth("frontend", "frontend-secret"), requests[2].Header.Get("Authorization"))
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestClientAuthenticator_AuthorizationRequests(t *testing.T) {
+	t.Parallel()
+	server := newClientAuthServer(t)
+	client := gocloak.NewClient(server.URL)
+	ctx := context.Background()
+	authenticator := gocloak.ClientSecretJWT{Secret: "a-very-secret-key-of-sufficient-length"}
+
+	_, err := client.PushAuthorizationRequest(ctx, "test", gocloak.PushedAuthorizationRequestOptions{
+		AuthCodeURLOptions: gocloak.AuthCodeURLOptions{
+			ClientID:    gocloak.StringP("backend"),
+			RedirectURI: gocloak.StringP("https://app.example.com/callback"),
+		},
+		ClientSecret:        gocloak.StringP("secret"),
+		ClientAuthenticator: authenticator,
+	})
+	require.NoError(t, err)
+	_, err = client.GetDeviceAuthorization(ctx, "test", gocloak.DeviceAuthorizationOptions{
+		ClientID:            gocloak.StringP("backend"),
+		ClientAuthenticator: authenticator,
+	})
+	require.NoError(t, err)
+	_, err = client.GetBackchannelAuthentication(ctx, "test", gocloak.BackchannelAuthenticationOptions{
+		ClientID:            gocloak.StringP("backend"),
+		ClientAuthenticator: authenticator,
+		LoginHint:           gocloak.StringP("user"),
+	})
+	require.NoError(t, err)
+
+	requests := server.recorded("")
+	require.Len(t, requests, 3)
+	for _, request := range requests {
+		require.Empty(t, request.Header.Get("Authorization"), request.Path)
+		require.Empty(t, request.Form.Get("client_secret"), request.Path)
+		_, claims := parseClientAssertion(t, request.Form, []byte("a-very-secret-key-of-sufficient-length"))
+		require.Equal(t, "backend", claims["sub"], request.Path)
+	}
+}
@@ -903,6 +903,104 @@ func Test_RetrospectToken(t *testing.T) {
 	require.True(t, gocloak.PBool(rptResult.Active), "Inactive Token oO")
 }
 
+func Test_IntrospectToken(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetUserToken(t, client)
+
+	result, err := client.IntrospectToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.IntrospectTokenOptions{
+			ClientID:      &cfg.GoCloak.ClientID,
+			ClientSecret:  &cfg.GoCloak.ClientSecret,
+			Token:         &token.AccessToken,
+			TokenTypeHint: gocloak.StringP(gocloak.TokenTypeHintAccessToken),
+		})
+	require.NoError(t, err, "IntrospectToken failed")
+	require.True(t, gocloak.PBool(result.Active), "Inactive Token oO")
+	require.Equal(t, cfg.GoCloak.ClientID, gocloak.PString(result.ClientID))
+	require.Equal(t, cfg.GoCloak.UserName, gocloak.PString(result.Username))
+
+	result, err = client.IntrospectToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.IntrospectTokenOptions{
+			ClientID:      &cfg.GoCloak.ClientID,
+			ClientSecret:  &cfg.GoCloak.ClientSecret,
+			Token:         &token.RefreshToken,
+			TokenTypeHint: gocloak.StringP(gocloak.TokenTypeHintRefreshToken),
+		})
+	require.NoError(t, err, "IntrospectToken failed")
+	require.True(t, gocloak.PBool(result.Active), "Inactive Token oO")
+}
+
+func Test_GetDeviceAuthorization(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+
+	deviceAuthorization, err := client.GetDeviceAuthorization(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.DeviceAuthorizationOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+			Scopes:       &[]string{"openid"},
+		})
+	require.NoError(t, err, "GetDeviceAuthorization failed")
+	require.NotEmpty(t, deviceAuthorization.DeviceCode)
+	require.NotEmpty(t, deviceAuthorization.UserCode)
+	require.Contains(t, deviceAuthorization.VerificationURI, "/realms/"+cfg.GoCloak.Realm+"/device")
+	require.Positive(t, deviceAuthorization.ExpiresIn)
+
+	// nobody approves the authorization, so polling ends with the context
+	deviceAuthorization.Interval = 1
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	_, err = client.PollDeviceToken(
+		ctx,
+		cfg.GoCloak.Realm,
+		gocloak.TokenOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+		},
+		deviceAuthorization)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_ExchangeToken(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetUserToken(t, client)
+
+	exchanged, err := client.ExchangeToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.TokenExchangeOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+			SubjectToken: &token.AccessToken,
+		})
+	require.NoError(t, err, "ExchangeToken failed")
+	require.NotEmpty(t, exchanged.AccessToken)
+	require.Equal(t, gocloak.TokenTypeAccessToken, exchanged.IssuedTokenType)
+
+	result, err := client.IntrospectToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.IntrospectTokenOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+			Token:        &exchanged.AccessToken,
+		})
+	require.NoError(t, err, "IntrospectToken failed")
+	require.True(t, gocloak.PBool(result.Active), "Inactive Token oO")
+	require.Equal(t, cfg.GoCloak.UserName, gocloak.PString(result.Username))
+}
+
 func Test_DecodeAccessToken(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -6515,14 +6613,12 @@ func Test_CheckError(t *testing.T) {
 
 	t.Log(err)
 
-	expectedError := &gocloak.APIError{
-		Code:    http.StatusNotFound,
-		Message: "404 Not Found: Could not find client",
-		Type:    gocloak.APIErrTypeUnknown,
-	}
-
 	apiError := err.(*gocloak.APIError)
-	require.Equal(t, expectedError, apiError)
+	require.Equal(t, http.StatusNotFound, apiError.Code)
+	require.Equal(t, "404 Not Found: Could not find client", apiError.Message)
+	require.Equal(t, gocloak.APIErrTypeNotFound, apiError.Type)
+	require.Equal(t, http.MethodGet, apiError.Method)
+	require.ErrorIs(t, err, gocloak.ErrNotFound)
 }
 
 // ---------------
@@ -0,0 +1,62 @@
+package gocloak
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// GetDeviceAuthorization starts the Device Authorization Grant and returns the codes to present to the user
+func (g *GoCloak) GetDeviceAuthorization(ctx context.Context, realm string, options DeviceAuthorizationOptions) (*DeviceAuthorizationResponse, error) {
+	const errMessage = "could not get device authorization"
+
+	ctx, span := g.startSpan(ctx, "GetDeviceAuthorization")
+	defer span.End()
+
+	if NilOrEmpty(options.ClientID) {
+		return nil, errors.New(errMessage + ": client id is required")
+	}
+
+	deviceURL, err := g.getDeviceAuthorizationURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, *options.ClientID, PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	var result DeviceAuthorizationResponse
+	resp, err := req.SetFormData(options.FormData()).
+		SetResult(&result).
+		Post(deviceURL)
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user completed the device authorization.
+// The interval of the device authorization is respected and increased on slow_down responses.
+// ErrAuthorizationExpired or ErrAccessDenied is returned if the authorization can't complete anymore.
+func (g *GoCloak) PollDeviceToken(ctx context.Context, realm string, options TokenOptions, deviceAuthorization *DeviceAuthorizationResponse) (*JWT, error) {
+	const errMessage = "could not get device token"
+
+	ctx, span := g.startSpan(ctx, "PollDeviceToken")
+	defer span.End()
+
+	if deviceAuthorization == nil || deviceAuthorization.DeviceCode == "" {
+		return nil, errors.New(errMessage + ": device code is required")
+	}
+	if NilOrEmpty(options.ClientID) {
+		return nil, errors.New(errMessage + ": client id is required")
+	}
+
+	options.GrantType = StringP("urn:ietf:params:oauth:grant-type:device_code")
+	options.DeviceCode = StringP(deviceAuthorization.DeviceCode)
+
+	return g.pollToken(ctx, realm, options, deviceAuthorization.Interval, deviceAuthorization.ExpiresIn)
+}
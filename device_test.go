@@ -0,0 +1,93 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const (
+	deviceAuthorizationPath = "/realms/test/protocol/openid-connect/auth/device"
+	tokenPath               = "/realms/test/protocol/openid-connect/token"
+)
+
+func newFakeDeviceServer(t *testing.T, tokenErrors ...string) *fakeServer {
+	server := newFakeServer(t)
+	server.handle(deviceAuthorizationPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.DeviceAuthorizationResponse{
+			DeviceCode:              "device-code",
+			UserCode:                "ABCD-EFGH",
+			VerificationURI:         "https://keycloak/realms/test/device",
+			VerificationURIComplete: "https://keycloak/realms/test/device?user_code=ABCD-EFGH",
+			ExpiresIn:               600,
+			Interval:                1,
+		})
+	})
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if poll := len(server.recorded(tokenPath)); poll <= len(tokenErrors) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: tokenErrors[poll-1]})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "access", ExpiresIn: 60})
+	})
+
+	return server
+}
+
+func TestDeviceAuthorization(t *testing.T) {
+	t.Parallel()
+	server := newFakeDeviceServer(t, "authorization_pending")
+	client := gocloak.NewClient(server.URL)
+	ctx := context.Background()
+
+	deviceAuthorization, err := client.GetDeviceAuthorization(ctx, "test", gocloak.DeviceAuthorizationOptions{
+		ClientID: gocloak.StringP("cli"),
+		Scopes:   &[]string{"openid", "offline_access"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ABCD-EFGH", deviceAuthorization.UserCode)
+	request := server.last(t, deviceAuthorizationPath)
+	require.Equal(t, "cli", request.Form.Get("client_id"))
+	require.Equal(t, "openid offline_access", request.Form.Get("scope"))
+
+	start := time.Now()
+	token, err := client.PollDeviceToken(ctx, "test", gocloak.TokenOptions{ClientID: gocloak.StringP("cli")}, deviceAuthorization)
+	require.NoError(t, err)
+	require.Equal(t, "access", token.AccessToken)
+	polls := server.recorded(tokenPath)
+	require.Len(t, polls, 2)
+	for _, poll := range polls {
+		require.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", poll.Form.Get("grant_type"))
+		require.Equal(t, "device-code", poll.Form.Get("device_code"))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+}
+
+func TestPollDeviceToken_Errors(t *testing.T) {
+	t.Parallel()
+	deviceAuthorization := &gocloak.DeviceAuthorizationResponse{DeviceCode: "device-code", Interval: 1}
+	options := gocloak.TokenOptions{ClientID: gocloak.StringP("cli")}
+
+	for _, expected := range []error{gocloak.ErrAccessDenied, gocloak.ErrAuthorizationExpired} {
+		server := newFakeDeviceServer(t, expected.(*gocloak.TokenPollError).Code)
+		client := gocloak.NewClient(server.URL)
+
+		_, err := client.PollDeviceToken(context.Background(), "test", options, deviceAuthorization)
+		require.ErrorIs(t, err, expected)
+	}
+
+	server := newFakeDeviceServer(t, "authorization_pending", "authorization_pending")
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	_, err := gocloak.NewClient(server.URL).PollDeviceToken(ctx, "test", options, deviceAuthorization)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
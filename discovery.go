@@ -0,0 +1,147 @@
+package gocloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// discoveryCache caches the discovery documents of every realm
+type discoveryCache struct {
+	lock    sync.Mutex
+	entries map[string]discoveryEntry
+}
+
+type discoveryEntry struct {
+	document  interface{}
+	fetchedAt time.Time
+}
+
+func (c *discoveryCache) load(key string, invalidateTime time.Duration) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.fetchedAt) >= invalidateTime {
+		return nil, false
+	}
+
+	return entry.document, true
+}
+
+func (c *discoveryCache) store(key string, document interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]discoveryEntry)
+	}
+	c.entries[key] = discoveryEntry{
+		document:  document,
+		fetchedAt: time.Now(),
+	}
+}
+
+// getOpenIDEndpointURL returns the URL of an OpenID Connect endpoint of the realm.
+// If discovery is enabled, the URL is taken from the OpenID configuration of the realm
+// and the given path is only used if the endpoint is not advertised.
+func (g *GoCloak) getOpenIDEndpointURL(ctx context.Context, realm string, discovered func(*OpenIDConfiguration) *string, path ...string) (string, error) {
+	if g.Config.openIDDiscovery {
+		configuration, err := g.GetOpenIDConfiguration(ctx, realm)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not discover endpoints of realm %s", realm)
+		}
+		if endpoint := discovered(configuration); !NilOrEmpty(endpoint) {
+			return *endpoint, nil
+		}
+	}
+
+	return g.getRealmURL(realm, path...), nil
+}
+
+// preferMTLSAlias returns the mTLS alias of an endpoint if the client authenticates with a certificate
+func (g *GoCloak) preferMTLSAlias(c *OpenIDConfiguration, endpoint *string, alias func(*MTLSEndpointAliases) *string) *string {
+	if g.Config.mtls && c.MTLSEndpointAliases != nil {
+		if aliasEndpoint := alias(c.MTLSEndpointAliases); !NilOrEmpty(aliasEndpoint) {
+			return aliasEndpoint
+		}
+	}
+
+	return endpoint
+}
+
+func (g *GoCloak) getTokenURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.TokenEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.TokenEndpoint
+		})
+	}, g.Config.tokenEndpoint)
+}
+
+func (g *GoCloak) getIntrospectionURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.IntrospectionEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.IntrospectionEndpoint
+		})
+	}, g.Config.tokenEndpoint, "introspect")
+}
+
+func (g *GoCloak) getUserInfoURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.UserinfoEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.UserinfoEndpoint
+		})
+	}, g.Config.openIDConnect, "userinfo")
+}
+
+func (g *GoCloak) getJWKSURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return c.JWKSURI
+	}, g.Config.openIDConnect, "certs")
+}
+
+func (g *GoCloak) getEndSessionURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return c.EndSessionEndpoint
+	}, g.Config.logoutEndpoint)
+}
+
+func (g *GoCloak) getRevocationURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.RevocationEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.RevocationEndpoint
+		})
+	}, g.Config.revokeEndpoint)
+}
+
+func (g *GoCloak) getAuthorizationURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return c.AuthorizationEndpoint
+	}, g.Config.openIDConnect, "auth")
+}
+
+func (g *GoCloak) getDeviceAuthorizationURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.DeviceAuthorizationEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.DeviceAuthorizationEndpoint
+		})
+	}, g.Config.openIDConnect, "auth", "device")
+}
+
+func (g *GoCloak) getPushedAuthorizationRequestURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.PushedAuthorizationRequestEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.PushedAuthorizationRequestEndpoint
+		})
+	}, g.Config.openIDConnect, "ext", "par", "request")
+}
+
+func (g *GoCloak) getBackchannelAuthenticationURL(ctx context.Context, realm string) (string, error) {
+	return g.getOpenIDEndpointURL(ctx, realm, func(c *OpenIDConfiguration) *string {
+		return g.preferMTLSAlias(c, c.BackchannelAuthenticationEndpoint, func(a *MTLSEndpointAliases) *string {
+			return a.BackchannelAuthenticationEndpoint
+		})
+	}, g.Config.openIDConnect, "ext", "ciba", "auth")
+}
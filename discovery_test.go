@@ -0,0 +1,99 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func TestGetOpenIDConfiguration_Cached(t *testing.T) {
+	t.Parallel()
+	var fetches int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/test/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.OpenIDConfiguration{
+			Issuer:        gocloak.StringP("https://keycloak/realms/test"),
+			TokenEndpoint: gocloak.StringP("https://keycloak/realms/test/protocol/openid-connect/token"),
+		})
+	})
+	mux.HandleFunc("/realms/test/.well-known/uma2-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.UMA2Configuration{
+			ResourceRegistrationEndpoint: gocloak.StringP("https://keycloak/realms/test/authz/protection/resource_set"),
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := gocloak.NewClient(server.URL)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		configuration, err := client.GetOpenIDConfiguration(ctx, "test")
+		require.NoError(t, err)
+		require.Equal(t, "https://keycloak/realms/test", gocloak.PString(configuration.Issuer))
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+
+	uma, err := client.GetUMA2Configuration(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "https://keycloak/realms/test/authz/protection/resource_set", gocloak.PString(uma.ResourceRegistrationEndpoint))
+
+	_, err = client.GetOpenIDConfiguration(ctx, "unknown")
+	require.Error(t, err)
+}
+
+func TestGetToken_DiscoveredEndpoint(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// the proxy exposes the token endpoint under a rewritten path
+	mux.HandleFunc("/realms/test/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.OpenIDConfiguration{
+			TokenEndpoint: gocloak.StringP(server.URL + "/auth/test/token"),
+		})
+	})
+	mux.HandleFunc("/auth/test/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "discovered", ExpiresIn: 60})
+	})
+
+	client := gocloak.NewClient(server.URL, gocloak.SetOpenIDDiscovery())
+	token, err := client.LoginClient(context.Background(), "client", "secret", "test")
+	require.NoError(t, err)
+	require.Equal(t, "discovered", token.AccessToken)
+
+	// without discovery the default path is used
+	_, err = gocloak.NewClient(server.URL).LoginClient(context.Background(), "client", "secret", "test")
+	require.Error(t, err)
+}
+
+func TestDiscoveryErrors_AreWrapped(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	client := gocloak.NewClient(server.URL, gocloak.SetOpenIDDiscovery())
+	ctx := context.Background()
+
+	_, err := client.GetUserInfo(ctx, "token", "test")
+	require.ErrorContains(t, err, "could not get user info")
+	require.ErrorContains(t, err, "could not discover endpoints of realm test")
+
+	err = client.RevokeToken(ctx, "test", "client", "secret", "token")
+	require.ErrorContains(t, err, "could not revoke token")
+
+	_, err = client.GetToken(ctx, "test", gocloak.TokenOptions{ClientID: gocloak.StringP("client")})
+	require.ErrorContains(t, err, "could not get token endpoint")
+}
@@ -0,0 +1,338 @@
+package gocloak
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+const (
+	// dpopProofType is the typ header of DPoP proofs
+	dpopProofType = "dpop+jwt"
+	// dpopAuthScheme is the authorization scheme of DPoP-bound access tokens
+	dpopAuthScheme = "DPoP"
+	// dpopNonceError is returned by servers which require a nonce in the DPoP proof
+	dpopNonceError = "use_dpop_nonce"
+)
+
+// dpopSigningMethods are the asymmetric algorithms accepted for DPoP proofs
+var dpopSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// DPoPSigner creates DPoP proofs (RFC 9449) with its key pair.
+// Nonces issued by servers are remembered per origin and added to later proofs.
+type DPoPSigner struct {
+	key        crypto.Signer
+	method     jwt.SigningMethod
+	jwk        *jwx.JWK
+	thumbprint string
+	now        func() time.Time
+
+	lock   sync.Mutex
+	nonces map[string]string
+}
+
+// NewDPoPSigner creates a new DPoPSigner with the given private key, e.g. an *ecdsa.PrivateKey with jwt.SigningMethodES256
+func NewDPoPSigner(key crypto.Signer, signedMethod jwt.SigningMethod) (*DPoPSigner, error) {
+	const errMessage = "could not create dpop signer"
+
+	if _, ok := signedMethod.(*jwt.SigningMethodHMAC); ok {
+		return nil, errors.New(errMessage + ": dpop proofs require an asymmetric algorithm")
+	}
+
+	jwk, err := jwx.PublicJWK(key.Public())
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	thumbprint, err := jwk.Thumbprint()
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	return &DPoPSigner{
+		key:        key,
+		method:     signedMethod,
+		jwk:        jwk,
+		thumbprint: thumbprint,
+		now:        time.Now,
+		nonces:     make(map[string]string),
+	}, nil
+}
+
+// Thumbprint returns the JWK thumbprint of the public key, which Keycloak binds tokens to as cnf.jkt claim
+func (s *DPoPSigner) Thumbprint() string {
+	return s.thumbprint
+}
+
+// Proof creates a DPoP proof for a request with the given method and URL.
+// If accessToken is not empty, the proof is bound to it via the ath claim.
+func (s *DPoPSigner) Proof(httpMethod, httpURL, accessToken string) (string, error) {
+	const errMessage = "could not create dpop proof"
+
+	htu, origin, err := dpopTarget(httpURL)
+	if err != nil {
+		return "", errors.Wrap(err, errMessage)
+	}
+
+	claims := jwt.MapClaims{
+		"htm": strings.ToUpper(httpMethod),
+		"htu": htu,
+		"iat": s.now().Unix(),
+		"jti": ksuid.New().String(),
+	}
+	if accessToken != "" {
+		claims["ath"] = dpopAccessTokenHash(accessToken)
+	}
+	if nonce := s.nonce(origin); nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["typ"] = dpopProofType
+	token.Header["jwk"] = s.jwk
+
+	proof, err := token.SignedString(s.key)
+	if err != nil {
+		return "", errors.Wrap(err, errMessage)
+	}
+
+	return proof, nil
+}
+
+// SetNonce remembers the nonce issued by the server of the given URL for later proofs
+func (s *DPoPSigner) SetNonce(httpURL, nonce string) {
+	_, origin, err := dpopTarget(httpURL)
+	if err != nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nonces[origin] = nonce
+}
+
+func (s *DPoPSigner) nonce(origin string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.nonces[origin]
+}
+
+// beforeRequest sends access tokens with the DPoP scheme
+func (s *DPoPSigner) beforeRequest(_ *resty.Client, req *resty.Request) error {
+	if req.Token != "" {
+		req.AuthScheme = dpopAuthScheme
+	}
+
+	return nil
+}
+
+// preRequest adds a DPoP proof to every request right before it is sent,
+// so the proof is not issued before the request waited for a rate limit
+func (s *DPoPSigner) preRequest(_ *resty.Client, req *http.Request) error {
+	accessToken, ok := strings.CutPrefix(req.Header.Get("Authorization"), dpopAuthScheme+" ")
+	if !ok {
+		accessToken = ""
+	}
+
+	proof, err := s.Proof(req.Method, req.URL.String(), accessToken)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("DPoP", proof)
+
+	return nil
+}
+
+// afterResponse remembers the nonces issued by servers
+func (s *DPoPSigner) afterResponse(_ *resty.Client, resp *resty.Response) error {
+	if nonce := resp.Header().Get("DPoP-Nonce"); nonce != "" {
+		s.SetNonce(resp.Request.URL, nonce)
+	}
+
+	return nil
+}
+
+// retryWithNonce retries a request sent with a DPoP proof once the server rejected the proof for a missing or stale nonce
+func (s *DPoPSigner) retryWithNonce(resp *resty.Response, err error) bool {
+	if err != nil || resp == nil || resp.Header().Get("DPoP-Nonce") == "" {
+		return false
+	}
+	if resp.Request == nil || resp.Request.RawRequest == nil || resp.Request.RawRequest.Header.Get("DPoP") == "" {
+		return false
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusBadRequest:
+		return strings.Contains(resp.String(), dpopNonceError)
+	case http.StatusUnauthorized:
+		return strings.Contains(resp.Header().Get("WWW-Authenticate"), dpopNonceError)
+	default:
+		return false
+	}
+}
+
+// ValidateDPoP validates a DPoP-bound access token and the DPoP proof sent along with the request.
+// The proof must be signed with the key of the cnf.jkt claim of the token, match the method and URL
+// of the request and must not have been used before.
+func (v *TokenValidator) ValidateDPoP(ctx context.Context, accessToken, proof, httpMethod, httpURL string) (*jwt.Token, *jwt.MapClaims, error) {
+	accessToken = strings.TrimPrefix(accessToken, dpopAuthScheme+" ")
+
+	token, claims, err := v.Validate(ctx, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var jkt string
+	if cnf, ok := (*claims)["cnf"].(map[string]interface{}); ok {
+		jkt, _ = cnf["jkt"].(string)
+	}
+	if jkt == "" {
+		return nil, nil, validationError(TokenValidationCheckDPoP, nil, "token is not bound to a dpop key")
+	}
+
+	if err := v.validateDPoPProof(proof, httpMethod, httpURL, accessToken, jkt); err != nil {
+		return nil, nil, err
+	}
+
+	return token, claims, nil
+}
+
+func (v *TokenValidator) validateDPoPProof(proof, httpMethod, httpURL, accessToken, jkt string) error {
+	claims, thumbprint, err := parseDPoPProof(proof)
+	if err != nil {
+		return validationError(TokenValidationCheckDPoP, err, "invalid proof")
+	}
+	if thumbprint != jkt {
+		return validationError(TokenValidationCheckDPoP, nil, "proof is not signed with the key the token is bound to")
+	}
+
+	if err := checkDPoPProofRequest(claims, httpMethod, httpURL, accessToken); err != nil {
+		return err
+	}
+
+	return v.checkDPoPProofReplay(claims)
+}
+
+// parseDPoPProof verifies the proof with the key of its jwk header and returns its claims and the thumbprint of the key
+func parseDPoPProof(proof string) (jwt.MapClaims, string, error) {
+	var thumbprint string
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(proof, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != dpopProofType {
+			return nil, errors.Errorf("unexpected typ %q", typ)
+		}
+
+		data, err := json.Marshal(token.Header["jwk"])
+		if err != nil {
+			return nil, err
+		}
+		var jwk jwx.JWK
+		if err := json.Unmarshal(data, &jwk); err != nil {
+			return nil, err
+		}
+
+		if thumbprint, err = jwk.Thumbprint(); err != nil {
+			return nil, err
+		}
+
+		return jwk.PublicKey()
+	}, jwt.WithValidMethods(dpopSigningMethods), jwt.WithoutClaimsValidation())
+
+	return claims, thumbprint, err
+}
+
+// checkDPoPProofRequest checks that the proof was created for the method and URL of the request and the access token
+func checkDPoPProofRequest(claims jwt.MapClaims, httpMethod, httpURL, accessToken string) error {
+	htu, _, err := dpopTarget(httpURL)
+	if err != nil {
+		return validationError(TokenValidationCheckDPoP, err, "invalid request url")
+	}
+	if htm, _ := claims["htm"].(string); htm != strings.ToUpper(httpMethod) {
+		return validationError(TokenValidationCheckDPoP, nil, "unexpected htm %q", htm)
+	}
+	if claimedHTU, _ := claims["htu"].(string); claimedHTU != htu {
+		return validationError(TokenValidationCheckDPoP, nil, "unexpected htu %q", claimedHTU)
+	}
+	if ath, _ := claims["ath"].(string); ath != dpopAccessTokenHash(accessToken) {
+		return validationError(TokenValidationCheckDPoP, nil, "proof is not bound to the access token")
+	}
+
+	return nil
+}
+
+// checkDPoPProofReplay checks that the proof is recent and was not used before
+func (v *TokenValidator) checkDPoPProofReplay(claims jwt.MapClaims) error {
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return validationError(TokenValidationCheckDPoP, err, "proof has no iat")
+	}
+	now := v.now()
+	if issuedAt.After(now.Add(v.leeway)) || issuedAt.Before(now.Add(-v.dpopProofMaxAge-v.leeway)) {
+		return validationError(TokenValidationCheckDPoP, nil, "proof was issued at %s", issuedAt.Time)
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return validationError(TokenValidationCheckDPoP, nil, "proof has no jti")
+	}
+	if !v.acceptDPoPProofID(jti, issuedAt.Add(v.dpopProofMaxAge+v.leeway)) {
+		return validationError(TokenValidationCheckDPoP, nil, "proof %q was already used", jti)
+	}
+
+	return nil
+}
+
+// acceptDPoPProofID reports whether the proof id is used for the first time and remembers it until expiry
+func (v *TokenValidator) acceptDPoPProofID(jti string, expiry time.Time) bool {
+	v.dpopLock.Lock()
+	defer v.dpopLock.Unlock()
+
+	now := v.now()
+	for id, idExpiry := range v.dpopProofIDs {
+		if now.After(idExpiry) {
+			delete(v.dpopProofIDs, id)
+		}
+	}
+
+	if _, ok := v.dpopProofIDs[jti]; ok {
+		return false
+	}
+	v.dpopProofIDs[jti] = expiry
+
+	return true
+}
+
+// dpopTarget returns the htu claim and the origin of the URL
+func dpopTarget(httpURL string) (string, string, error) {
+	parsedURL, err := url.Parse(httpURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	parsedURL.RawQuery = ""
+	parsedURL.Fragment = ""
+	parsedURL.RawFragment = ""
+
+	return parsedURL.String(), parsedURL.Scheme + "://" + parsedURL.Host, nil
+}
+
+func dpopAccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
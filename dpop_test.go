@@ -0,0 +1,226 @@
+package gocloak_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func newDPoPSigner(t *testing.T) *gocloak.DPoPSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := gocloak.NewDPoPSigner(key, jwt.SigningMethodES256)
+	require.NoError(t, err)
+	return signer
+}
+
+func parseDPoPProof(t *testing.T, request recordedRequest) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	token, _, err := jwt.NewParser().ParseUnverified(request.Header.Get("DPoP"), claims)
+	require.NoError(t, err)
+	require.Equal(t, "dpop+jwt", token.Header["typ"])
+	require.NotNil(t, token.Header["jwk"])
+	return claims
+}
+
+func TestDPoP_Client(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// the first proof is rejected as it doesn't contain the nonce of the server
+		if len(server.recorded(tokenPath)) == 1 {
+			w.Header().Set("DPoP-Nonce", "server-nonce")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "use_dpop_nonce"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "bound-token", TokenType: "DPoP"})
+	})
+	server.handle("/admin/serverinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	client := gocloak.NewClient(server.URL, gocloak.SetDPoP(newDPoPSigner(t)))
+	ctx := context.Background()
+
+	token, err := client.LoginClient(ctx, "client", "secret", "test")
+	require.NoError(t, err)
+	require.Equal(t, "bound-token", token.AccessToken)
+	tokenRequests := server.recorded(tokenPath)
+	require.Len(t, tokenRequests, 2)
+	for i, request := range tokenRequests {
+		claims := parseDPoPProof(t, request)
+		require.Equal(t, "POST", claims["htm"])
+		require.Equal(t, server.URL+tokenPath, claims["htu"])
+		require.NotContains(t, claims, "ath")
+		if i == 0 {
+			require.NotContains(t, claims, "nonce")
+		} else {
+			require.Equal(t, "server-nonce", claims["nonce"])
+		}
+	}
+
+	_, err = client.GetServerInfo(ctx, token.AccessToken)
+	require.NoError(t, err)
+	request := server.last(t, "/admin/serverinfo")
+	require.Equal(t, "DPoP bound-token", request.Header.Get("Authorization"))
+	claims := parseDPoPProof(t, request)
+	hash := sha256.Sum256([]byte("bound-token"))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(hash[:]), claims["ath"])
+	require.Equal(t, "GET", claims["htm"])
+}
+
+func TestDPoP_ProofAfterRateLimitWait(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sub":"user"}`))
+	})
+
+	// the rate limit is set after DPoP, its wait must still happen before the proof is created
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetDPoP(newDPoPSigner(t)),
+		gocloak.SetRateLimit(gocloak.RateLimit{RequestsPerSecond: 1, Burst: 1}))
+	ctx := context.Background()
+
+	_, err := client.GetUserInfo(ctx, "token", "test")
+	require.NoError(t, err)
+
+	// the second request waits a second for the rate limit
+	start := time.Now()
+	_, err = client.GetUserInfo(ctx, "token", "test")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	requests := server.recorded("")
+	require.Len(t, requests, 2)
+	require.GreaterOrEqual(t, int64(parseDPoPProof(t, requests[1])["iat"].(float64)), start.Unix()+1)
+}
+
+func TestDPoP_OnlyNonceRetried(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("DPoP-Nonce", "server-nonce")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := gocloak.NewClient(server.URL, gocloak.SetDPoP(newDPoPSigner(t)))
+
+	_, err := client.LoginClient(context.Background(), "client", "secret", "test")
+	require.Error(t, err)
+	require.Len(t, server.recorded(tokenPath), 1)
+}
+
+func TestDPoP_PreRequestHooks(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sub":"user"}`))
+	})
+
+	var hooks []string
+	hook := func(name string) resty.PreRequestHook {
+		return func(_ *resty.Client, req *http.Request) error {
+			hooks = append(hooks, name)
+			req.Header.Set("X-Hook", name)
+			return nil
+		}
+	}
+	client := gocloak.NewClient(server.URL,
+		gocloak.AddPreRequestHook(hook("first")),
+		gocloak.SetDPoP(newDPoPSigner(t)),
+		gocloak.AddPreRequestHook(hook("second")))
+
+	_, err := client.GetUserInfo(context.Background(), "token", "test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, hooks)
+	request := server.last(t, "")
+	require.Equal(t, "second", request.Header.Get("X-Hook"))
+	require.Equal(t, "GET", parseDPoPProof(t, request)["htm"])
+}
+
+func TestDPoP_SetRestyClient(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sub":"user"}`))
+	})
+
+	client := gocloak.NewClient(server.URL, gocloak.SetDPoP(newDPoPSigner(t)))
+	client.SetRestyClient(resty.New())
+
+	_, err := client.GetUserInfo(context.Background(), "token", "test")
+	require.NoError(t, err)
+	request := server.last(t, "")
+	require.Equal(t, "DPoP token", request.Header.Get("Authorization"))
+	require.Equal(t, "GET", parseDPoPProof(t, request)["htm"])
+}
+
+func TestTokenValidator_ValidateDPoP(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "test")
+	client := gocloak.NewClient(realm.server.URL)
+	validator := gocloak.NewTokenValidator(client, "test")
+	signer := newDPoPSigner(t)
+	ctx := context.Background()
+
+	claims := realm.claims()
+	claims["cnf"] = map[string]string{"jkt": signer.Thumbprint()}
+	accessToken := realm.sign(t, claims)
+	resourceURL := "https://api.example.com/orders"
+
+	proof, err := signer.Proof(http.MethodGet, resourceURL+"?page=2", accessToken)
+	require.NoError(t, err)
+
+	_, validated, err := validator.ValidateDPoP(ctx, "DPoP "+accessToken, proof, http.MethodGet, resourceURL)
+	require.NoError(t, err)
+	require.Equal(t, "user-id", (*validated)["sub"])
+
+	otherProof, err := newDPoPSigner(t).Proof(http.MethodGet, resourceURL, accessToken)
+	require.NoError(t, err)
+	wrongMethod, err := signer.Proof(http.MethodPost, resourceURL, accessToken)
+	require.NoError(t, err)
+	wrongURL, err := signer.Proof(http.MethodGet, "https://api.example.com/other", accessToken)
+	require.NoError(t, err)
+	unbound, err := signer.Proof(http.MethodGet, resourceURL, "")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Token string
+		Proof string
+	}{
+		"replayed proof":  {Token: accessToken, Proof: proof},
+		"other key":       {Token: accessToken, Proof: otherProof},
+		"wrong method":    {Token: accessToken, Proof: wrongMethod},
+		"wrong url":       {Token: accessToken, Proof: wrongURL},
+		"missing ath":     {Token: accessToken, Proof: unbound},
+		"malformed proof": {Token: accessToken, Proof: strings.Repeat("x", 10)},
+		"unbound token":   {Token: realm.sign(t, realm.claims()), Proof: proof},
+	}
+	for name, testCase := range testCases {
+		_, _, err := validator.ValidateDPoP(ctx, testCase.Token, testCase.Proof, http.MethodGet, resourceURL)
+		var validationErr *gocloak.TokenValidationError
+		require.True(t, errors.As(err, &validationErr), "%s: unexpected error: %v", name, err)
+		require.Equal(t, gocloak.TokenValidationCheckDPoP, validationErr.Check, name)
+	}
+}
@@ -1,14 +1,22 @@
 package gocloak
 
 import (
+	"net/http"
 	"strings"
+
+	"github.com/go-resty/resty/v2"
 )
 
-// HTTPErrorResponse is a model of an error response
+// HTTPErrorResponse is a model of an error response.
+// Validation errors of Keycloak name the invalid Field and the Params of the message,
+// multiple validation errors are returned as Errors.
 type HTTPErrorResponse struct {
-	Error       string `json:"error,omitempty"`
-	Message     string `json:"errorMessage,omitempty"`
-	Description string `json:"error_description,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	Message     string              `json:"errorMessage,omitempty"`
+	Description string              `json:"error_description,omitempty"`
+	Field       string              `json:"field,omitempty"`
+	Params      []string            `json:"params,omitempty"`
+	Errors      []HTTPErrorResponse `json:"errors,omitempty"`
 }
 
 // String returns a string representation of an error
@@ -29,10 +37,137 @@ func (e HTTPErrorResponse) String() string {
 		}
 		res.WriteString(e.Description)
 	}
+	if len(e.Field) > 0 {
+		if res.Len() > 0 {
+			res.WriteString(" ")
+		}
+		res.WriteString("(field " + e.Field + ")")
+	}
+	for _, nested := range e.Errors {
+		if res.Len() > 0 {
+			res.WriteString("; ")
+		}
+		res.WriteString(nested.String())
+	}
 	return res.String()
 }
 
 // NotEmpty validates that error is not emptyp
 func (e HTTPErrorResponse) NotEmpty() bool {
-	return len(e.Error) > 0 || len(e.Message) > 0 || len(e.Description) > 0
+	return len(e.Error) > 0 || len(e.Message) > 0 || len(e.Description) > 0 || len(e.Field) > 0 || len(e.Errors) > 0
+}
+
+var (
+	// ErrNotFound is returned if the requested entity doesn't exist
+	ErrNotFound = &APIError{Type: APIErrTypeNotFound, Message: "not found"}
+	// ErrConflict is returned if the entity conflicts with an existing one, e.g. a user with the same username
+	ErrConflict = &APIError{Type: APIErrTypeConflict, Message: "conflict"}
+	// ErrUnauthorized is returned if the token is missing, invalid or expired
+	ErrUnauthorized = &APIError{Type: APIErrTypeUnauthorized, Message: "unauthorized"}
+	// ErrForbidden is returned if the token lacks the permissions for the request
+	ErrForbidden = &APIError{Type: APIErrTypeForbidden, Message: "forbidden"}
+	// ErrInvalidGrant is returned if the credentials, code or refresh token of a token request are invalid
+	ErrInvalidGrant = &APIError{Type: APIErrTypeInvalidGrant, Message: "invalid grant"}
+	// ErrInvalidClient is returned if the client authentication failed
+	ErrInvalidClient = &APIError{Type: APIErrTypeInvalidClient, Message: "invalid client"}
+	// ErrUnauthorizedClient is returned if the client is not allowed to use the grant
+	ErrUnauthorizedClient = &APIError{Type: APIErrTypeUnauthorizedClient, Message: "unauthorized client"}
+	// ErrTokenExpired is returned if a token or its session expired, e.g. when refreshing a token.
+	ErrTokenExpired = &APIError{Type: APIErrTypeTokenExpired, Message: "token expired"}
+	// ErrRateLimited is returned if the server rejected the request due to too many requests
+	ErrRateLimited = &APIError{Type: APIErrTypeRateLimited, Message: "rate limited"}
+)
+
+// Is reports whether target is an APIError of a type the error belongs to.
+// An error can belong to several types, e.g. an expired refresh token is also an invalid grant.
+func (apiError APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && apiError.hasType(t.Type)
+}
+
+// Unwrap returns the error of the transport, if the request failed before a response was received
+func (apiError APIError) Unwrap() error {
+	return apiError.err
+}
+
+func (apiError APIError) hasType(errType APIErrType) bool {
+	switch errType {
+	case APIErrTypeNotFound:
+		return apiError.Code == http.StatusNotFound
+	case APIErrTypeConflict:
+		return apiError.Code == http.StatusConflict
+	case APIErrTypeUnauthorized:
+		return apiError.Code == http.StatusUnauthorized
+	case APIErrTypeForbidden:
+		return apiError.Code == http.StatusForbidden
+	case APIErrTypeRateLimited:
+		return apiError.Code == http.StatusTooManyRequests
+	case APIErrTypeInvalidGrant:
+		return apiError.Type == APIErrTypeInvalidGrant || apiError.oauthError() == "invalid_grant"
+	case APIErrTypeInvalidClient:
+		return apiError.oauthError() == "invalid_client"
+	case APIErrTypeUnauthorizedClient:
+		return apiError.oauthError() == "unauthorized_client"
+	case APIErrTypeTokenExpired:
+		return apiError.tokenExpired()
+	default:
+		return apiError.Type == errType
+	}
+}
+
+func (apiError APIError) oauthError() string {
+	if apiError.Response == nil {
+		return ""
+	}
+	return apiError.Response.Error
+}
+
+// tokenExpired reports whether a token was rejected because it or its session is not active anymore
+func (apiError APIError) tokenExpired() bool {
+	if code := apiError.oauthError(); code != "invalid_grant" && code != "invalid_token" {
+		return false
+	}
+
+	description := strings.ToLower(apiError.Response.Description)
+	return strings.Contains(description, "not active") || strings.Contains(description, "expired")
+}
+
+// newAPIError creates an APIError for an error response and derives its most specific type
+func newAPIError(resp *resty.Response, message string, response *HTTPErrorResponse) *APIError {
+	apiError := &APIError{
+		Code:     resp.StatusCode(),
+		Message:  message,
+		Type:     APIErrTypeUnknown,
+		Response: response,
+	}
+	setRequestDetails(apiError, resp)
+
+	for _, errType := range []APIErrType{
+		APIErrTypeRateLimited,
+		APIErrTypeTokenExpired,
+		APIErrTypeInvalidGrant,
+		APIErrTypeInvalidClient,
+		APIErrTypeUnauthorizedClient,
+		APIErrTypeUnauthorized,
+		APIErrTypeForbidden,
+		APIErrTypeNotFound,
+		APIErrTypeConflict,
+	} {
+		if apiError.hasType(errType) {
+			apiError.Type = errType
+			break
+		}
+	}
+
+	return apiError
+}
+
+// setRequestDetails adds the method and URL of the request to the APIError
+func setRequestDetails(apiError *APIError, resp *resty.Response) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+
+	apiError.Method = resp.Request.Method
+	apiError.URL = resp.Request.URL
 }
@@ -0,0 +1,140 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func TestAPIErrorTypes(t *testing.T) {
+	t.Parallel()
+	responses := map[string]struct {
+		Status int
+		Body   interface{}
+	}{
+		"missing":       {http.StatusNotFound, gocloak.HTTPErrorResponse{Error: "User not found"}},
+		"duplicate":     {http.StatusConflict, gocloak.HTTPErrorResponse{Message: "User exists with same username"}},
+		"unauthorized":  {http.StatusUnauthorized, nil},
+		"forbidden":     {http.StatusForbidden, gocloak.HTTPErrorResponse{Error: "unknown_error"}},
+		"rate-limited":  {http.StatusTooManyRequests, nil},
+		"expired":       {http.StatusBadRequest, gocloak.HTTPErrorResponse{Error: "invalid_grant", Description: "Token is not active"}},
+		"invalid-grant": {http.StatusBadRequest, gocloak.HTTPErrorResponse{Error: "invalid_grant", Description: "Invalid user credentials"}},
+		"invalid-client": {
+			http.StatusUnauthorized,
+			gocloak.HTTPErrorResponse{Error: "invalid_client", Description: "Invalid client or Invalid client credentials"},
+		},
+		"unauthorized-client": {
+			http.StatusBadRequest,
+			gocloak.HTTPErrorResponse{Error: "unauthorized_client", Description: "Client not allowed for direct access grants"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := responses[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(response.Status)
+		if response.Body != nil {
+			_ = json.NewEncoder(w).Encode(response.Body)
+		}
+	}))
+	defer server.Close()
+	client := gocloak.NewClient(server.URL)
+
+	sentinels := []*gocloak.APIError{
+		gocloak.ErrNotFound,
+		gocloak.ErrConflict,
+		gocloak.ErrUnauthorized,
+		gocloak.ErrForbidden,
+		gocloak.ErrInvalidGrant,
+		gocloak.ErrInvalidClient,
+		gocloak.ErrUnauthorizedClient,
+		gocloak.ErrTokenExpired,
+		gocloak.ErrRateLimited,
+	}
+	testCases := []struct {
+		UserID string
+		Type   gocloak.APIErrType
+		Is     []*gocloak.APIError
+	}{
+		{"missing", gocloak.APIErrTypeNotFound, []*gocloak.APIError{gocloak.ErrNotFound}},
+		{"duplicate", gocloak.APIErrTypeConflict, []*gocloak.APIError{gocloak.ErrConflict}},
+		{"unauthorized", gocloak.APIErrTypeUnauthorized, []*gocloak.APIError{gocloak.ErrUnauthorized}},
+		{"forbidden", gocloak.APIErrTypeForbidden, []*gocloak.APIError{gocloak.ErrForbidden}},
+		{"rate-limited", gocloak.APIErrTypeRateLimited, []*gocloak.APIError{gocloak.ErrRateLimited}},
+		{"expired", gocloak.APIErrTypeTokenExpired, []*gocloak.APIError{gocloak.ErrTokenExpired, gocloak.ErrInvalidGrant}},
+		{"invalid-grant", gocloak.APIErrTypeInvalidGrant, []*gocloak.APIError{gocloak.ErrInvalidGrant}},
+		{"invalid-client", gocloak.APIErrTypeInvalidClient, []*gocloak.APIError{gocloak.ErrInvalidClient, gocloak.ErrUnauthorized}},
+		{"unauthorized-client", gocloak.APIErrTypeUnauthorizedClient, []*gocloak.APIError{gocloak.ErrUnauthorizedClient}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.UserID, func(t *testing.T) {
+			_, err := client.GetUserByID(context.Background(), "token", "test", testCase.UserID)
+			require.Error(t, err)
+
+			var apiErr *gocloak.APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, testCase.Type, apiErr.Type)
+			require.Equal(t, http.MethodGet, apiErr.Method)
+			require.Equal(t, server.URL+"/admin/realms/test/users/"+testCase.UserID, apiErr.URL)
+
+			for _, sentinel := range sentinels {
+				expected := false
+				for _, is := range testCase.Is {
+					expected = expected || is == sentinel
+				}
+				require.Equal(t, expected, errors.Is(err, sentinel), "%s: %s", err, sentinel)
+			}
+		})
+	}
+}
+
+func TestAPIError_ValidationDetails(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[` +
+			`{"field":"email","errorMessage":"invalidEmailMessage","params":["email","not-an-email"]},` +
+			`{"field":"username","errorMessage":"error-username-invalid-character","params":["username"]}]}`))
+	}))
+	defer server.Close()
+	client := gocloak.NewClient(server.URL)
+
+	_, err := client.CreateUser(context.Background(), "token", "test", gocloak.User{Email: gocloak.StringP("not-an-email")})
+	require.Error(t, err)
+
+	var apiErr *gocloak.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.MethodPost, apiErr.Method)
+	require.NotNil(t, apiErr.Response)
+	require.Len(t, apiErr.Response.Errors, 2)
+	require.Equal(t, "email", apiErr.Response.Errors[0].Field)
+	require.Equal(t, []string{"email", "not-an-email"}, apiErr.Response.Errors[0].Params)
+	require.Equal(t, "400 Bad Request: invalidEmailMessage (field email); error-username-invalid-character (field username)", apiErr.Message)
+}
+
+func TestAPIError_TransportError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := gocloak.NewClient(server.URL)
+
+	_, err := client.GetUserByID(context.Background(), "token", "test", "user-id")
+	require.Error(t, err)
+
+	var apiErr *gocloak.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, 0, apiErr.Code)
+	require.Equal(t, http.MethodGet, apiErr.Method)
+	require.Error(t, errors.Unwrap(apiErr))
+	require.False(t, errors.Is(err, gocloak.ErrNotFound))
+}
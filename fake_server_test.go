@@ -0,0 +1,91 @@
+package gocloak_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is a test server which records the requests to its handlers,
+// so that tests assert on them after the call instead of inside the handler goroutine
+type fakeServer struct {
+	*httptest.Server
+	mux *http.ServeMux
+
+	lock     sync.Mutex
+	requests []recordedRequest
+}
+
+// recordedRequest is a request received by a fakeServer
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Form   url.Values
+	Body   []byte
+}
+
+// BasicAuth returns the credentials of the Authorization header of the request
+func (r recordedRequest) BasicAuth() (string, string, bool) {
+	return (&http.Request{Header: r.Header}).BasicAuth()
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	f := &fakeServer{mux: http.NewServeMux()}
+	f.Server = httptest.NewServer(f.mux)
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+// handle registers the handler for the pattern. The request is recorded before the handler is called,
+// the handler can use r.PostForm and still read the body.
+func (f *fakeServer) handle(pattern string, handler http.HandlerFunc) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		_ = r.ParseForm()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		f.lock.Lock()
+		f.requests = append(f.requests, recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Header: r.Header.Clone(),
+			Form:   r.PostForm,
+			Body:   body,
+		})
+		f.lock.Unlock()
+
+		handler(w, r)
+	})
+}
+
+// recorded returns the requests to the path, or all requests if path is empty
+func (f *fakeServer) recorded(path string) []recordedRequest {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var requests []recordedRequest
+	for _, request := range f.requests {
+		if path == "" || request.Path == path {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// last returns the last request to the path, or the last request at all if path is empty
+func (f *fakeServer) last(t *testing.T, path string) recordedRequest {
+	requests := f.recorded(path)
+	require.NotEmpty(t, requests, "no request to %q was recorded", path)
+
+	return requests[len(requests)-1]
+}
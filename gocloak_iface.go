@@ -5,6 +5,7 @@ package gocloak
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -34,17 +35,66 @@ type GoCloakIface interface {
 	// GetRawUserInfo calls the UserInfo endpoint and returns a raw json object
 	GetRawUserInfo(ctx context.Context, accessToken, realm string) (map[string]interface{}, error)
 	// GetCerts fetches certificates for the given realm from the public /open-id-connect/certs endpoint
+	// The certs are cached and refreshed in the background shortly before CertsInvalidateTime has passed.
+	// If the certs can't be refreshed, the cached certs are returned.
 	GetCerts(ctx context.Context, realm string) (*CertResponse, error)
+	// GetCertKey returns the key with the given key id from the certs of the realm.
+	// If the key is unknown, e.g. after a key rotation, the certs are refetched at most once per CertsMinRefetchInterval.
+	GetCertKey(ctx context.Context, realm, kid string) (*CertResponseKey, error)
+	// GetOpenIDConfiguration fetches the OpenID Connect discovery document of the given realm.
+	// The document is cached for DiscoveryInvalidateTime.
+	GetOpenIDConfiguration(ctx context.Context, realm string) (*OpenIDConfiguration, error)
+	// GetUMA2Configuration fetches the UMA 2.0 discovery document of the given realm.
+	// The document is cached for DiscoveryInvalidateTime.
+	GetUMA2Configuration(ctx context.Context, realm string) (*UMA2Configuration, error)
 	// GetIssuer gets the issuer of the given realm
 	GetIssuer(ctx context.Context, realm string) (*IssuerResponse, error)
-	// RetrospectToken calls the openid-connect introspect endpoint
+	// RetrospectToken calls the openid-connect introspect endpoint with a requesting party token.
+	// Use IntrospectToken to introspect other token types.
 	RetrospectToken(ctx context.Context, accessToken, clientID, clientSecret, realm string) (*IntroSpectTokenResult, error)
+	// ExchangeToken exchanges a token at the token endpoint of the realm (RFC 8693).
+	// The type of the issued token is returned as IssuedTokenType, the token itself as AccessToken.
+	// Requires token exchange to be enabled: https://www.keycloak.org/securing-apps/token-exchange
+	ExchangeToken(ctx context.Context, realm string, options TokenExchangeOptions) (*JWT, error)
+	// IntrospectToken calls the token introspection endpoint (RFC 7662) of the realm.
+	// If an introspection cache is set with SetIntrospectionCache, results are cached for its ttl,
+	// but active results never beyond the expiry of the token.
+	IntrospectToken(ctx context.Context, realm string, options IntrospectTokenOptions) (*IntroSpectTokenResult, error)
 	// DecodeAccessToken decodes the accessToken
 	DecodeAccessToken(ctx context.Context, accessToken, realm string) (*jwt.Token, *jwt.MapClaims, error)
 	// DecodeAccessTokenCustomClaims decodes the accessToken and writes claims into the given claims
 	DecodeAccessTokenCustomClaims(ctx context.Context, accessToken, realm string, claims jwt.Claims) (*jwt.Token, error)
 	// GetToken uses TokenOptions to fetch a token.
 	GetToken(ctx context.Context, realm string, options TokenOptions) (*JWT, error)
+	// GetAuthCodeURL builds the URL the user agent is redirected to, to start the Authorization Code flow.
+	// If the options contain a code challenge without a method, S256 is assumed.
+	GetAuthCodeURL(ctx context.Context, realm string, options AuthCodeURLOptions) (string, error)
+	// ExchangeAuthCode exchanges the code of the Authorization Code flow for tokens.
+	// options.CodeVerifier must be set if a code challenge was sent.
+	// If nonce is not empty, the returned ID token is validated and its nonce claim must match.
+	ExchangeAuthCode(ctx context.Context, realm string, options TokenOptions, nonce string) (*JWT, error)
+	// CreateRequestObject signs the authorization parameters as a JWT secured authorization request (JAR).
+	// The request object is issued by the client, addressed to the realm and valid for the given lifetime.
+	CreateRequestObject(realm string, options AuthCodeURLOptions, key interface{}, signedMethod jwt.SigningMethod, lifetime time.Duration) (string, error)
+	// PushAuthorizationRequest pushes the authorization parameters to the realm (PAR).
+	// The returned request uri is passed as AuthCodeURLOptions.RequestURI to GetAuthCodeURL before it expires.
+	PushAuthorizationRequest(ctx context.Context, realm string, options PushedAuthorizationRequestOptions) (*PushedAuthorizationResponse, error)
+	// GetDeviceAuthorization starts the Device Authorization Grant and returns the codes to present to the user
+	GetDeviceAuthorization(ctx context.Context, realm string, options DeviceAuthorizationOptions) (*DeviceAuthorizationResponse, error)
+	// PollDeviceToken polls the token endpoint until the user completed the device authorization.
+	// The interval of the device authorization is respected and increased on slow_down responses.
+	// ErrAuthorizationExpired or ErrAccessDenied is returned if the authorization can't complete anymore.
+	PollDeviceToken(ctx context.Context, realm string, options TokenOptions, deviceAuthorization *DeviceAuthorizationResponse) (*JWT, error)
+	// GetBackchannelAuthentication starts a Client-Initiated Backchannel Authentication (CIBA).
+	// Keycloak asks the user identified by the login hint to approve the authentication on the authentication device.
+	GetBackchannelAuthentication(ctx context.Context, realm string, options BackchannelAuthenticationOptions) (*BackchannelAuthenticationResponse, error)
+	// GetBackchannelToken asks the token endpoint once for the token of a backchannel authentication.
+	// ErrAuthorizationPending and ErrSlowDown are returned while the user has not approved the authentication.
+	GetBackchannelToken(ctx context.Context, realm string, options TokenOptions, authReqID string) (*JWT, error)
+	// PollBackchannelToken polls the token endpoint until the user approved the backchannel authentication.
+	// The interval of the authentication is respected and increased on slow_down responses.
+	// ErrAuthorizationExpired or ErrAccessDenied is returned if the authentication can't complete anymore.
+	PollBackchannelToken(ctx context.Context, realm string, options TokenOptions, authentication *BackchannelAuthenticationResponse) (*JWT, error)
 	// GetRequestingPartyToken returns a requesting party token with permissions granted by the server
 	GetRequestingPartyToken(ctx context.Context, token, realm string, options RequestingPartyTokenOptions) (*JWT, error)
 	// GetRequestingPartyPermissions returns a requesting party permissions granted by the server
@@ -60,11 +110,16 @@ type GoCloakIface interface {
 	LoginClient(ctx context.Context, clientID, clientSecret, realm string, scopes ...string) (*JWT, error)
 	// LoginClientTokenExchange will exchange the presented token for a user's token
 	// Requires Token-Exchange is enabled: https://www.keycloak.org/docs/latest/securing_apps/index.html#_token-exchange
+	// Use ExchangeToken for other token types, scopes or multiple audiences.
 	LoginClientTokenExchange(ctx context.Context, clientID, token, clientSecret, realm, targetClient, userID string) (*JWT, error)
 	// DirectNakedImpersonationTokenExchange performs "Direct Naked Impersonation"
 	// See: https://www.keycloak.org/docs/latest/securing_apps/index.html#direct-naked-impersonation
 	DirectNakedImpersonationTokenExchange(ctx context.Context, clientID, clientSecret, realm, userID string) (*JWT, error)
+	// LoginClientTLS performs a login with client credentials, authenticating the client with its TLS certificate
+	// (tls_client_auth or self_signed_tls_client_auth). The certificate is set with SetClientCertificate.
+	LoginClientTLS(ctx context.Context, clientID, realm string, scopes ...string) (*JWT, error)
 	// LoginClientSignedJWT performs a login with client credentials and signed jwt claims
+	// For other grants or signing options use PrivateKeyJWT as ClientAuthenticator.
 	LoginClientSignedJWT(ctx context.Context, clientID, realm string, key interface{}, signedMethod jwt.SigningMethod, expiresAt *jwt.NumericDate) (*JWT, error)
 	// Login performs a login with user credentials and a client
 	Login(ctx context.Context, clientID, clientSecret, realm, username, password string) (*JWT, error)
@@ -0,0 +1,111 @@
+package gocloak
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// clientHooks are the hooks of the options of a GoCloak instance. The options only configure them,
+// registerHooks installs them on the resty client of NewClient and on every client set with SetRestyClient.
+type clientHooks struct {
+	client      *resty.Client
+	observer    *requestObserver
+	rateLimiter *rateLimiter
+	dpop        *DPoPSigner
+	logger      *requestLogger
+	retryPolicy *RetryPolicy
+	preRequest  []resty.PreRequestHook
+}
+
+// registerHooks installs the hooks on the resty client, once per client
+func (g *GoCloak) registerHooks() {
+	hooks := &g.hooks
+	client := g.restyClient
+	if hooks.client == client {
+		return
+	}
+	hooks.client = client
+
+	if hooks.observer != nil {
+		client.OnBeforeRequest(hooks.observer.beforeRequest)
+	}
+	if hooks.rateLimiter != nil {
+		client.OnBeforeRequest(hooks.rateLimiter.beforeRequest)
+	}
+	if hooks.dpop != nil {
+		client.OnBeforeRequest(hooks.dpop.beforeRequest)
+		client.OnAfterResponse(hooks.dpop.afterResponse)
+	}
+	if hooks.logger != nil {
+		client.OnBeforeRequest(hooks.logger.beforeRequest)
+		client.OnAfterResponse(hooks.logger.afterResponse)
+		client.OnError(hooks.logger.onError)
+	}
+	if hooks.dpop != nil || len(hooks.preRequest) > 0 {
+		client.SetPreRequestHook(hooks.beforeSend)
+	}
+
+	client.OnSuccess(func(_ *resty.Client, resp *resty.Response) {
+		hooks.finish(resp.Request, resp, nil)
+	})
+	client.OnError(func(req *resty.Request, err error) {
+		hooks.finish(req, nil, err)
+	})
+	client.OnPanic(func(req *resty.Request, err error) {
+		hooks.finish(req, nil, err)
+	})
+
+	hooks.registerRetries(client)
+}
+
+// registerRetries configures the retries of the retry policy and the DPoP nonce retries.
+// Without a retry policy, only requests rejected for a missing DPoP nonce are retried.
+func (h *clientHooks) registerRetries(client *resty.Client) {
+	switch {
+	case h.retryPolicy != nil:
+		h.retryPolicy.apply(client)
+		client.AddRetryHook(traceRetry(client))
+	case h.dpop != nil && client.RetryCount == 0:
+		client.SetRetryCount(1)
+	case h.dpop == nil:
+		return
+	}
+
+	client.AddRetryCondition(h.shouldRetry)
+}
+
+// beforeSend runs the pre-request hooks in the order they were added and adds the DPoP proof last,
+// so the proof is created right before the request is sent, after any rate limit wait
+func (h *clientHooks) beforeSend(client *resty.Client, req *http.Request) error {
+	for _, hook := range h.preRequest {
+		if err := hook(client, req); err != nil {
+			return err
+		}
+	}
+
+	if h.dpop != nil {
+		return h.dpop.preRequest(client, req)
+	}
+
+	return nil
+}
+
+// finish releases the rate limits of the request and reports it to the observer once its last attempt finished
+func (h *clientHooks) finish(req *resty.Request, resp *resty.Response, err error) {
+	if h.rateLimiter != nil {
+		h.rateLimiter.releaseRequest(req)
+	}
+	if h.observer != nil {
+		h.observer.finish(req, resp, err)
+	}
+}
+
+// shouldRetry reports whether the request is retried after the response or error
+func (h *clientHooks) shouldRetry(resp *resty.Response, err error) bool {
+	if h.dpop != nil && h.dpop.retryWithNonce(resp, err) {
+		return true
+	}
+
+	return h.retryPolicy != nil && h.retryPolicy.shouldRetry(resp, err)
+}
@@ -0,0 +1,222 @@
+// Command authgen generates the AuthenticatedClient methods from GoCloakIface.
+//
+// Every method of the interface that takes the access token as second argument
+// is wrapped by a method without the token argument, which obtains the token
+// from the TokenSource of the AuthenticatedClient.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+// methods which take a token that is not the token of the caller
+var skipped = map[string]bool{
+	"GetRequestWithBearerAuthNoCache":      true,
+	"GetRequestWithBearerAuth":             true,
+	"GetRequestWithBearerAuthXMLHeader":    true,
+	"GetUserInfo":                          true,
+	"GetRawUserInfo":                       true,
+	"RetrospectToken":                      true,
+	"DecodeAccessToken":                    true,
+	"DecodeAccessTokenCustomClaims":        true,
+	"GetRequestingPartyToken":              true,
+	"GetRequestingPartyPermissions":        true,
+	"GetRequestingPartyPermissionDecision": true,
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func main() {
+	in := flag.String("in", "gocloak_iface.go", "file containing GoCloakIface")
+	out := flag.String("out", "authenticated_client_gen.go", "output file")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != "GoCloakIface" {
+			return true
+		}
+		for _, method := range spec.Type.(*ast.InterfaceType).Methods.List {
+			writeMethod(&body, fset, method)
+		}
+		return false
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by authgen; DO NOT EDIT.\n\n")
+	buf.WriteString("package gocloak\n\n")
+	buf.WriteString("import (\n\"context\"\n")
+	if bytes.Contains(body.Bytes(), []byte("io.")) {
+		buf.WriteString("\"io\"\n")
+	}
+	if bytes.Contains(body.Bytes(), []byte("jwt.")) {
+		buf.WriteString("\n\"github.com/golang-jwt/jwt/v5\"\n")
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeMethod(buf *bytes.Buffer, fset *token.FileSet, method *ast.Field) {
+	name := method.Names[0].Name
+	if skipped[name] {
+		return
+	}
+
+	fn := method.Type.(*ast.FuncType)
+	params, tokenName, ok := tokenParams(flatten(fset, fn.Params))
+	if !ok {
+		return
+	}
+
+	results := flatten(fset, fn.Results)
+	if len(results) == 0 || results[len(results)-1].typ != "error" {
+		return
+	}
+
+	if method.Doc != nil {
+		for _, comment := range method.Doc.List {
+			buf.WriteString(comment.Text + "\n")
+		}
+	}
+
+	var resultTypes, resultNames []string
+	for i, r := range results {
+		resultTypes = append(resultTypes, r.typ)
+		if i < len(results)-1 {
+			resultNames = append(resultNames, fmt.Sprintf("r%d", i))
+		}
+	}
+
+	fmt.Fprintf(buf, "func (c *AuthenticatedClient) %s(%s) (%s) {\n",
+		name, signature(params), strings.Join(resultTypes, ", "))
+	call := fmt.Sprintf("c.client.%s(%s)", name, callArgs(tokenName, params))
+	do := doFunc(params)
+
+	if len(resultNames) == 0 {
+		fmt.Fprintf(buf, "return c.%s(ctx, func(%s string) error {\nreturn %s\n})\n}\n\n", do, tokenName, call)
+		return
+	}
+
+	for i, r := range resultNames {
+		fmt.Fprintf(buf, "var %s %s\n", r, results[i].typ)
+	}
+	fmt.Fprintf(buf, "err := c.%s(ctx, func(%s string) error {\nvar err error\n%s, err = %s\nreturn err\n})\n",
+		do, tokenName, strings.Join(resultNames, ", "), call)
+	fmt.Fprintf(buf, "return %s, err\n}\n\n", strings.Join(resultNames, ", "))
+}
+
+// tokenParams removes the token argument following the context from the parameters.
+// It reports false if the method doesn't take the access token of the caller.
+func tokenParams(params []param) ([]param, string, bool) {
+	if len(params) < 2 || params[0].typ != "context.Context" || params[1].typ != "string" {
+		return nil, "", false
+	}
+	if params[1].name != "token" && params[1].name != "accessToken" {
+		return nil, "", false
+	}
+
+	return append(params[:1:1], params[2:]...), params[1].name, true
+}
+
+// signature renders the parameter list, merging the types of consecutive parameters of the same type
+func signature(params []param) string {
+	var signature []string
+	for i, p := range params {
+		if i+1 < len(params) && params[i+1].typ == p.typ {
+			signature = append(signature, p.name)
+			continue
+		}
+		signature = append(signature, p.name+" "+p.typ)
+	}
+
+	return strings.Join(signature, ", ")
+}
+
+// callArgs renders the arguments of the call of the wrapped method
+func callArgs(tokenName string, params []param) string {
+	args := []string{"ctx", tokenName}
+	for _, p := range params[1:] {
+		if p.variadic {
+			args = append(args, p.name+"...")
+		} else {
+			args = append(args, p.name)
+		}
+	}
+
+	return strings.Join(args, ", ")
+}
+
+// doFunc returns the AuthenticatedClient method running the call. Calls reading an io.Reader
+// are not retried, as the reader was already consumed by the first attempt.
+func doFunc(params []param) string {
+	for _, p := range params {
+		if p.typ == "io.Reader" {
+			return "doOnce"
+		}
+	}
+
+	return "do"
+}
+
+func flatten(fset *token.FileSet, fields *ast.FieldList) []param {
+	if fields == nil {
+		return nil
+	}
+
+	var res []param
+	for _, field := range fields.List {
+		typ := field.Type
+		variadic := false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			variadic = true
+			typ = ellipsis.Elt
+		}
+
+		var b bytes.Buffer
+		if variadic {
+			b.WriteString("...")
+		}
+		if err := format.Node(&b, fset, typ); err != nil {
+			log.Fatal(err)
+		}
+
+		if len(field.Names) == 0 {
+			res = append(res, param{typ: b.String(), variadic: variadic})
+			continue
+		}
+		for _, name := range field.Names {
+			res = append(res, param{name: name.Name, typ: b.String(), variadic: variadic})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,155 @@
+package gocloak
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TokenTypeHintAccessToken hints that an access token is introspected
+	TokenTypeHintAccessToken = "access_token"
+	// TokenTypeHintRefreshToken hints that a refresh token is introspected
+	TokenTypeHintRefreshToken = "refresh_token"
+	// TokenTypeHintRequestingPartyToken hints that a requesting party token (RPT) is introspected
+	TokenTypeHintRequestingPartyToken = "requesting_party_token"
+)
+
+const (
+	// introspectionCacheSweepInterval is how often expired introspection results are removed
+	introspectionCacheSweepInterval = time.Minute
+	// introspectionCacheSweepSize is the number of cached results which triggers a sweep before the interval passed
+	introspectionCacheSweepSize = 1024
+)
+
+// introspectionCache caches introspection results by a hash of the token, so the token itself is not kept in memory.
+// Results are kept as JSON, so every caller gets its own copy.
+type introspectionCache struct {
+	lock      sync.Mutex
+	entries   map[string]introspectionEntry
+	sweptAt   time.Time
+	sweepSize int
+}
+
+type introspectionEntry struct {
+	result    []byte
+	expiresAt time.Time
+}
+
+func (c *introspectionCache) load(key string, now time.Time) (*IntroSpectTokenResult, bool) {
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	c.lock.Unlock()
+
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	var result IntroSpectTokenResult
+	if err := json.Unmarshal(entry.result, &result); err != nil {
+		return nil, false
+	}
+
+	return &result, true
+}
+
+func (c *introspectionCache) store(key string, result []byte, expiresAt time.Time, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]introspectionEntry)
+		c.sweptAt = now
+		c.sweepSize = introspectionCacheSweepSize
+	}
+	if now.Sub(c.sweptAt) >= introspectionCacheSweepInterval || len(c.entries) >= c.sweepSize {
+		c.sweep(now)
+	}
+
+	c.entries[key] = introspectionEntry{result: result, expiresAt: expiresAt}
+}
+
+// sweep removes the expired results, it has to be called with c.lock held
+func (c *introspectionCache) sweep(now time.Time) {
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	// if most results are still valid, grow the threshold so that sweeping stays amortized
+	c.sweptAt = now
+	c.sweepSize = introspectionCacheSweepSize
+	if 2*len(c.entries) > c.sweepSize {
+		c.sweepSize = 2 * len(c.entries)
+	}
+}
+
+func introspectionCacheKey(realm string, options IntrospectTokenOptions) string {
+	hash := sha256.New()
+	for _, value := range []string{realm, PString(options.ClientID), PString(options.TokenTypeHint), PString(options.Token)} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// IntrospectToken calls the token introspection endpoint (RFC 7662) of the realm.
+// If an introspection cache is set with SetIntrospectionCache, results are cached for its ttl,
+// but active results never beyond the expiry of the token.
+func (g *GoCloak) IntrospectToken(ctx context.Context, realm string, options IntrospectTokenOptions) (*IntroSpectTokenResult, error) {
+	const errMessage = "could not introspect token"
+
+	ctx, span := g.startSpan(ctx, "IntrospectToken")
+	defer span.End()
+
+	if NilOrEmpty(options.Token) {
+		return nil, errors.New(errMessage + ": token is required")
+	}
+
+	var cacheKey string
+	if g.Config.IntrospectionCacheTTL > 0 {
+		cacheKey = introspectionCacheKey(realm, options)
+		if result, ok := g.introspectionCache.load(cacheKey, time.Now()); ok {
+			return result, nil
+		}
+	}
+
+	introspectionURL, err := g.getIntrospectionURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, PString(options.ClientID), PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	var result IntroSpectTokenResult
+	resp, err := req.SetFormData(options.FormData()).
+		SetResult(&result).
+		Post(introspectionURL)
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	if cacheKey != "" {
+		now := time.Now()
+		expiresAt := now.Add(g.Config.IntrospectionCacheTTL)
+		if PBool(result.Active) && result.Exp != nil {
+			if exp := time.Unix(int64(*result.Exp), 0); exp.Before(expiresAt) {
+				expiresAt = exp
+			}
+		}
+		g.introspectionCache.store(cacheKey, resp.Body(), expiresAt, now)
+	}
+
+	return &result, nil
+}
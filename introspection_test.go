@@ -0,0 +1,125 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const introspectionPath = "/realms/test/protocol/openid-connect/token/introspect"
+
+func newFakeIntrospectionServer(t *testing.T, exp time.Time) *fakeServer {
+	server := newFakeServer(t)
+	server.handle(introspectionPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"active":     true,
+			"exp":        exp.Unix(),
+			"scope":      "openid profile",
+			"client_id":  "frontend",
+			"username":   "alice",
+			"token_type": r.PostForm.Get("token_type_hint"),
+			"realm_access": map[string]interface{}{
+				"roles": []string{"user"},
+			},
+			"resource_access": map[string]interface{}{
+				"backend": map[string]interface{}{"roles": []string{"admin"}},
+			},
+			"cnf": map[string]interface{}{"x5t#S256": "thumbprint"},
+		})
+	})
+
+	return server
+}
+
+func introspectionOptions(hint string) gocloak.IntrospectTokenOptions {
+	return gocloak.IntrospectTokenOptions{
+		ClientID:      gocloak.StringP("backend"),
+		ClientSecret:  gocloak.StringP("secret"),
+		Token:         gocloak.StringP("access-token"),
+		TokenTypeHint: gocloak.StringP(hint),
+	}
+}
+
+func TestIntrospectToken(t *testing.T) {
+	t.Parallel()
+	server := newFakeIntrospectionServer(t, time.Now().Add(time.Hour))
+	client := gocloak.NewClient(server.URL)
+
+	result, err := client.IntrospectToken(context.Background(), "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+	require.NoError(t, err)
+	require.True(t, *result.Active)
+	require.Equal(t, "openid profile", *result.Scope)
+	require.Equal(t, "frontend", *result.ClientID)
+	require.Equal(t, "alice", *result.Username)
+	require.Equal(t, gocloak.TokenTypeHintAccessToken, *result.TokenType)
+	require.Equal(t, []string{"user"}, *result.RealmAccess.Roles)
+	require.Equal(t, []string{"admin"}, *(*result.ResourceAccess)["backend"].Roles)
+	require.Equal(t, "thumbprint", *result.Cnf.X5tS256)
+	request := server.last(t, introspectionPath)
+	clientID, clientSecret, ok := request.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "backend", clientID)
+	require.Equal(t, "secret", clientSecret)
+	require.Equal(t, "access-token", request.Form.Get("token"))
+
+	_, err = client.IntrospectToken(context.Background(), "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(server.recorded(introspectionPath)), "results are not cached by default")
+}
+
+func TestIntrospectToken_RequiresToken(t *testing.T) {
+	t.Parallel()
+	client := gocloak.NewClient("http://localhost")
+
+	_, err := client.IntrospectToken(context.Background(), "test", gocloak.IntrospectTokenOptions{ClientID: gocloak.StringP("backend")})
+	require.Error(t, err)
+}
+
+func TestIntrospectToken_Cache(t *testing.T) {
+	t.Parallel()
+	server := newFakeIntrospectionServer(t, time.Now().Add(time.Hour))
+	client := gocloak.NewClient(server.URL, gocloak.SetIntrospectionCache(time.Minute))
+	ctx := context.Background()
+
+	first, err := client.IntrospectToken(ctx, "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+	require.NoError(t, err)
+	second, err := client.IntrospectToken(ctx, "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	require.Equal(t, 1, len(server.recorded(introspectionPath)))
+
+	// cached results are copies, changes of a caller don't leak into the cache
+	*first.Active = false
+	(*first.RealmAccess.Roles)[0] = "admin"
+	(*first.ResourceAccess)["other"] = gocloak.RoleAccess{}
+	third, err := client.IntrospectToken(ctx, "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+	require.NoError(t, err)
+	require.True(t, *third.Active)
+	require.Equal(t, []string{"user"}, *third.RealmAccess.Roles)
+	require.NotContains(t, *third.ResourceAccess, "other")
+	require.Equal(t, 1, len(server.recorded(introspectionPath)))
+
+	_, err = client.IntrospectToken(ctx, "test", introspectionOptions(gocloak.TokenTypeHintRefreshToken))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(server.recorded(introspectionPath)), "the token type hint is part of the cache key")
+}
+
+func TestIntrospectToken_CacheExpiresWithToken(t *testing.T) {
+	t.Parallel()
+	server := newFakeIntrospectionServer(t, time.Now().Add(-time.Second))
+	client := gocloak.NewClient(server.URL, gocloak.SetIntrospectionCache(time.Minute))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.IntrospectToken(ctx, "test", introspectionOptions(gocloak.TokenTypeHintAccessToken))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, len(server.recorded(introspectionPath)), "results are not cached beyond the token expiry")
+}
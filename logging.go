@@ -0,0 +1,221 @@
+package gocloak
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// redacted replaces the values of credentials in logs
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are the headers carrying credentials
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Dpop":                true,
+}
+
+// sensitiveFields are the form fields, query parameters and JSON fields carrying credentials
+var sensitiveFields = map[string]bool{
+	"password":                true,
+	"client_secret":           true,
+	"client_assertion":        true,
+	"assertion":               true,
+	"access_token":            true,
+	"refresh_token":           true,
+	"id_token":                true,
+	"id_token_hint":           true,
+	"subject_token":           true,
+	"actor_token":             true,
+	"token":                   true,
+	"code":                    true,
+	"code_verifier":           true,
+	"device_code":             true,
+	"secret":                  true,
+	"secretData":              true,
+	"clientSecret":            true,
+	"registrationAccessToken": true,
+}
+
+// requestLogger logs the requests of the resty client and their responses with credentials redacted
+type requestLogger struct {
+	logger *slog.Logger
+}
+
+func (l *requestLogger) beforeRequest(_ *resty.Client, req *resty.Request) error {
+	ctx := req.Context()
+	if !l.logger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+
+	attributes := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", loggedURL(req)),
+		slog.Int("attempt", req.Attempt),
+		redactHeaders(req.Header),
+	}
+	if len(req.FormData) > 0 {
+		attributes = append(attributes, redactForm(req.FormData))
+	}
+	if req.Body != nil {
+		attributes = append(attributes, redactRequestBody(req.Body))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "keycloak request", attributes...)
+	return nil
+}
+
+func (l *requestLogger) afterResponse(_ *resty.Client, resp *resty.Response) error {
+	ctx := resp.Request.Context()
+	if !l.logger.Enabled(ctx, slog.LevelDebug) {
+		return nil
+	}
+
+	attributes := []slog.Attr{
+		slog.String("method", resp.Request.Method),
+		slog.String("url", loggedURL(resp.Request)),
+		slog.Int("status", resp.StatusCode()),
+		slog.Duration("duration", resp.Time()),
+		redactHeaders(resp.Header()),
+	}
+	if body := resp.Body(); len(body) > 0 {
+		attributes = append(attributes, redactBody(body))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "keycloak response", attributes...)
+	return nil
+}
+
+func (l *requestLogger) onError(req *resty.Request, err error) {
+	l.logger.LogAttrs(req.Context(), slog.LevelWarn, "keycloak request failed",
+		slog.String("method", req.Method),
+		slog.String("url", loggedURL(req)),
+		slog.Int("attempt", req.Attempt),
+		slog.String("error", loggedError(err)),
+	)
+}
+
+// loggedError returns the message of the error without the URL of the request, which is logged redacted instead
+func loggedError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+
+	return err.Error()
+}
+
+func redactHeaders(header http.Header) slog.Attr {
+	attributes := make([]any, 0, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+			value = redacted
+		}
+		attributes = append(attributes, slog.String(key, value))
+	}
+
+	return slog.Group("headers", attributes...)
+}
+
+func redactForm(form url.Values) slog.Attr {
+	attributes := make([]any, 0, len(form))
+	for key, values := range form {
+		value := strings.Join(values, ", ")
+		if sensitiveFields[key] {
+			value = redacted
+		}
+		attributes = append(attributes, slog.String(key, value))
+	}
+
+	return slog.Group("form", attributes...)
+}
+
+// loggedURL returns the URL of the request with the sensitive query parameters redacted.
+// Before the first attempt is sent, the query parameters are not yet part of the URL.
+func loggedURL(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return redactURL(req.RawRequest.URL, nil)
+	}
+
+	parsedURL, err := url.Parse(req.URL)
+	if err != nil {
+		return redacted
+	}
+
+	return redactURL(parsedURL, req.QueryParam)
+}
+
+func redactURL(requestURL *url.URL, query url.Values) string {
+	parsedURL := *requestURL
+	values := parsedURL.Query()
+	for key, value := range query {
+		values[key] = append(values[key], value...)
+	}
+	for key := range values {
+		if sensitiveFields[key] {
+			values[key] = []string{redacted}
+		}
+	}
+	parsedURL.RawQuery = values.Encode()
+	parsedURL.User = nil
+
+	return parsedURL.String()
+}
+
+func redactRequestBody(body interface{}) slog.Attr {
+	switch b := body.(type) {
+	case []byte:
+		return redactBody(b)
+	case string:
+		return redactBody([]byte(b))
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return slog.String("body", redacted)
+		}
+		return redactBody(data)
+	}
+}
+
+// redactBody redacts the credentials of JSON bodies, other bodies are only logged with their size
+func redactBody(body []byte) slog.Attr {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return slog.Int("body_size", len(body))
+	}
+
+	return slog.Any("body", redactJSON(value))
+}
+
+// redactJSON redacts the sensitive fields of JSON values,
+// including the value of credentials like the CredentialRepresentation
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		_, isCredential := v["type"]
+		for key, field := range v {
+			if sensitiveFields[key] || (isCredential && key == "value") {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSON(field)
+		}
+		return v
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactJSON(element)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,146 @@
+package gocloak_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use by the log handler
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
+
+func newLoggingClient(t *testing.T) (*gocloak.GoCloak, *syncBuffer, string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "KEYCLOAK_SESSION=session-cookie")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			_, _ = w.Write([]byte(`{"access_token":"issued-access-token","refresh_token":"issued-refresh-token","token_type":"Bearer"}`))
+		case r.Method == http.MethodPost:
+			w.Header().Set("Location", r.URL.String()+"/created-id")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			_, _ = w.Write([]byte(`[{"username":"visible-user","credentials":[{"type":"password","value":"stored-password"}]}]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var output syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	return gocloak.NewClient(server.URL, gocloak.SetLogger(logger)), &output, server.URL
+}
+
+func TestLogger_RedactsTokenRequests(t *testing.T) {
+	t.Parallel()
+	client, output, _ := newLoggingClient(t)
+
+	_, err := client.Login(context.Background(), "client-id", "client-secret-value", "realm", "visible-user", "user-password")
+	require.NoError(t, err)
+
+	logs := output.String()
+	require.Contains(t, logs, `"msg":"keycloak request"`)
+	require.Contains(t, logs, `"msg":"keycloak response"`)
+	require.Contains(t, logs, "visible-user")
+	require.Contains(t, logs, "[REDACTED]")
+	for _, secret := range []string{"client-secret-value", "user-password", "issued-access-token", "issued-refresh-token", "session-cookie"} {
+		require.NotContains(t, logs, secret)
+	}
+}
+
+func TestLogger_RedactsAdminRequests(t *testing.T) {
+	t.Parallel()
+	client, output, _ := newLoggingClient(t)
+	ctx := context.Background()
+
+	_, err := client.CreateUser(ctx, "admin-bearer-token", "realm", gocloak.User{
+		Username: gocloak.StringP("visible-user"),
+		Credentials: &[]gocloak.CredentialRepresentation{{
+			Type:  gocloak.StringP("password"),
+			Value: gocloak.StringP("initial-password"),
+		}},
+	})
+	require.NoError(t, err)
+
+	_, err = client.CreateClient(ctx, "admin-bearer-token", "realm", gocloak.Client{
+		ClientID:                gocloak.StringP("visible-client"),
+		Secret:                  gocloak.StringP("generated-client-secret"),
+		RegistrationAccessToken: gocloak.StringP("registration-access-token"),
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetUsers(ctx, "admin-bearer-token", "realm", gocloak.GetUsersParams{})
+	require.NoError(t, err)
+
+	logs := output.String()
+	require.Contains(t, logs, "visible-user")
+	require.Contains(t, logs, "visible-client")
+	for _, secret := range []string{"admin-bearer-token", "initial-password", "generated-client-secret", "registration-access-token", "stored-password"} {
+		require.NotContains(t, logs, secret)
+	}
+}
+
+func TestLogger_RedactsQueryParameters(t *testing.T) {
+	t.Parallel()
+	client, output, serverURL := newLoggingClient(t)
+
+	_, err := client.GetRequest(context.Background()).
+		SetQueryParams(map[string]string{"id_token_hint": "hinted-id-token", "state": "visible-state"}).
+		Get(serverURL + "/realms/realm/protocol/openid-connect/logout")
+	require.NoError(t, err)
+
+	logs := output.String()
+	require.Contains(t, logs, "visible-state")
+	require.NotContains(t, logs, "hinted-id-token")
+}
+
+func TestLogger_LogsFailedRequests(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	var output syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	client := gocloak.NewClient(server.URL, gocloak.SetLogger(logger))
+
+	ctx := context.Background()
+
+	_, err := client.GetUsers(ctx, "admin-bearer-token", "realm", gocloak.GetUsersParams{})
+	require.Error(t, err)
+	_, err = client.GetRequest(ctx).
+		SetQueryParam("id_token_hint", "hinted-id-token").
+		Get(server.URL + "/realms/realm/protocol/openid-connect/logout")
+	require.Error(t, err)
+
+	logs := output.String()
+	require.Contains(t, logs, `"msg":"keycloak request failed"`)
+	require.NotContains(t, logs, `"msg":"keycloak request"`)
+	require.Contains(t, logs, "connection refused")
+	for _, secret := range []string{"admin-bearer-token", "hinted-id-token"} {
+		require.NotContains(t, logs, secret)
+	}
+}
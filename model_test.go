@@ -258,10 +258,16 @@ func TestStringerOmitEmpty(t *testing.T) {
 		&gocloak.CertResponseKey{},
 		&gocloak.CertResponse{},
 		&gocloak.IssuerResponse{},
+		&gocloak.OpenIDConfiguration{},
+		&gocloak.MTLSEndpointAliases{},
+		&gocloak.UMA2Configuration{},
 		&gocloak.ResourcePermission{},
 		&gocloak.PermissionResource{},
 		&gocloak.PermissionScope{},
 		&gocloak.IntroSpectTokenResult{},
+		&gocloak.RoleAccess{},
+		&gocloak.ConfirmationClaim{},
+		&gocloak.IntrospectTokenOptions{},
 		&gocloak.User{},
 		&gocloak.SetPasswordRequest{},
 		&gocloak.Component{},
@@ -334,6 +340,11 @@ func TestStringerOmitEmpty(t *testing.T) {
 		&gocloak.GetComponentsParams{},
 		&gocloak.GetClientsParams{},
 		&gocloak.RequestingPartyTokenOptions{},
+		&gocloak.AuthCodeURLOptions{},
+		&gocloak.DeviceAuthorizationOptions{},
+		&gocloak.PushedAuthorizationRequestOptions{},
+		&gocloak.BackchannelAuthenticationOptions{},
+		&gocloak.TokenExchangeOptions{},
 		&gocloak.RequestingPartyPermission{},
 		&gocloak.GetClientUserSessionsParams{},
 		&gocloak.GetOrganizationsParams{},
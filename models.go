@@ -100,6 +100,33 @@ const (
 	// APIErrTypeInvalidGrant corresponds with Keycloak's
 	// OAuthErrorException due to "invalid_grant".
 	APIErrTypeInvalidGrant = "oauth: invalid grant"
+
+	// APIErrTypeInvalidClient corresponds with the OAuth error "invalid_client",
+	// i.e. the client authentication failed.
+	APIErrTypeInvalidClient APIErrType = "oauth: invalid client"
+
+	// APIErrTypeUnauthorizedClient corresponds with the OAuth error "unauthorized_client",
+	// i.e. the client is not allowed to use the grant.
+	APIErrTypeUnauthorizedClient APIErrType = "oauth: unauthorized client"
+
+	// APIErrTypeTokenExpired is for invalid grants and tokens
+	// due to an expired token or session.
+	APIErrTypeTokenExpired APIErrType = "oauth: token expired"
+
+	// APIErrTypeNotFound is for responses with status 404.
+	APIErrTypeNotFound APIErrType = "not found"
+
+	// APIErrTypeConflict is for responses with status 409.
+	APIErrTypeConflict APIErrType = "conflict"
+
+	// APIErrTypeUnauthorized is for responses with status 401.
+	APIErrTypeUnauthorized APIErrType = "unauthorized"
+
+	// APIErrTypeForbidden is for responses with status 403.
+	APIErrTypeForbidden APIErrType = "forbidden"
+
+	// APIErrTypeRateLimited is for responses with status 429.
+	APIErrTypeRateLimited APIErrType = "rate limited"
 )
 
 // ParseAPIErrType is a convenience method for returning strongly
@@ -116,11 +143,17 @@ func ParseAPIErrType(err error) APIErrType {
 	}
 }
 
-// APIError holds message and statusCode for api errors
+// APIError holds message and statusCode for api errors.
+// Compare it against the sentinel errors like ErrNotFound or ErrInvalidGrant with errors.Is.
+// Response holds the parsed error response, if the server sent one.
 type APIError struct {
-	Code    int        `json:"code"`
-	Message string     `json:"message"`
-	Type    APIErrType `json:"type"`
+	Code     int                `json:"code"`
+	Message  string             `json:"message"`
+	Type     APIErrType         `json:"type"`
+	Method   string             `json:"method,omitempty"`
+	URL      string             `json:"url,omitempty"`
+	Response *HTTPErrorResponse `json:"response,omitempty"`
+	err      error
 }
 
 // Error stringifies the APIError
@@ -141,6 +174,7 @@ type CertResponseKey struct {
 	X       *string   `json:"x,omitempty"`
 	Y       *string   `json:"y,omitempty"`
 	Crv     *string   `json:"crv,omitempty"`
+	K       *string   `json:"k,omitempty"`
 	KeyOps  *[]string `json:"key_ops,omitempty"`
 	X5u     *string   `json:"x5u,omitempty"`
 	X5c     *[]string `json:"x5c,omitempty"`
@@ -162,6 +196,88 @@ type IssuerResponse struct {
 	TokensNotBefore *int    `json:"tokens-not-before,omitempty"`
 }
 
+// OpenIDConfiguration is returned by the .well-known/openid-configuration endpoint
+type OpenIDConfiguration struct {
+	Issuer                                           *string              `json:"issuer,omitempty"`
+	AuthorizationEndpoint                            *string              `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint                                    *string              `json:"token_endpoint,omitempty"`
+	IntrospectionEndpoint                            *string              `json:"introspection_endpoint,omitempty"`
+	UserinfoEndpoint                                 *string              `json:"userinfo_endpoint,omitempty"`
+	EndSessionEndpoint                               *string              `json:"end_session_endpoint,omitempty"`
+	JWKSURI                                          *string              `json:"jwks_uri,omitempty"`
+	CheckSessionIframe                               *string              `json:"check_session_iframe,omitempty"`
+	RegistrationEndpoint                             *string              `json:"registration_endpoint,omitempty"`
+	RevocationEndpoint                               *string              `json:"revocation_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint                      *string              `json:"device_authorization_endpoint,omitempty"`
+	BackchannelAuthenticationEndpoint                *string              `json:"backchannel_authentication_endpoint,omitempty"`
+	PushedAuthorizationRequestEndpoint               *string              `json:"pushed_authorization_request_endpoint,omitempty"`
+	RequirePushedAuthorizationRequests               *bool                `json:"require_pushed_authorization_requests,omitempty"`
+	FrontchannelLogoutSupported                      *bool                `json:"frontchannel_logout_supported,omitempty"`
+	FrontchannelLogoutSessionSupported               *bool                `json:"frontchannel_logout_session_supported,omitempty"`
+	BackchannelLogoutSupported                       *bool                `json:"backchannel_logout_supported,omitempty"`
+	BackchannelLogoutSessionSupported                *bool                `json:"backchannel_logout_session_supported,omitempty"`
+	GrantTypesSupported                              *[]string            `json:"grant_types_supported,omitempty"`
+	ACRValuesSupported                               *[]string            `json:"acr_values_supported,omitempty"`
+	ResponseTypesSupported                           *[]string            `json:"response_types_supported,omitempty"`
+	ResponseModesSupported                           *[]string            `json:"response_modes_supported,omitempty"`
+	SubjectTypesSupported                            *[]string            `json:"subject_types_supported,omitempty"`
+	ScopesSupported                                  *[]string            `json:"scopes_supported,omitempty"`
+	ClaimsSupported                                  *[]string            `json:"claims_supported,omitempty"`
+	ClaimTypesSupported                              *[]string            `json:"claim_types_supported,omitempty"`
+	ClaimsParameterSupported                         *bool                `json:"claims_parameter_supported,omitempty"`
+	RequestParameterSupported                        *bool                `json:"request_parameter_supported,omitempty"`
+	RequestURIParameterSupported                     *bool                `json:"request_uri_parameter_supported,omitempty"`
+	RequireRequestURIRegistration                    *bool                `json:"require_request_uri_registration,omitempty"`
+	CodeChallengeMethodsSupported                    *[]string            `json:"code_challenge_methods_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported                 *[]string            `json:"id_token_signing_alg_values_supported,omitempty"`
+	IDTokenEncryptionAlgValuesSupported              *[]string            `json:"id_token_encryption_alg_values_supported,omitempty"`
+	IDTokenEncryptionEncValuesSupported              *[]string            `json:"id_token_encryption_enc_values_supported,omitempty"`
+	UserinfoSigningAlgValuesSupported                *[]string            `json:"userinfo_signing_alg_values_supported,omitempty"`
+	RequestObjectSigningAlgValuesSupported           *[]string            `json:"request_object_signing_alg_values_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported                *[]string            `json:"token_endpoint_auth_methods_supported,omitempty"`
+	TokenEndpointAuthSigningAlgValuesSupported       *[]string            `json:"token_endpoint_auth_signing_alg_values_supported,omitempty"`
+	IntrospectionEndpointAuthMethodsSupported        *[]string            `json:"introspection_endpoint_auth_methods_supported,omitempty"`
+	RevocationEndpointAuthMethodsSupported           *[]string            `json:"revocation_endpoint_auth_methods_supported,omitempty"`
+	BackchannelTokenDeliveryModesSupported           *[]string            `json:"backchannel_token_delivery_modes_supported,omitempty"`
+	BackchannelAuthenticationRequestSigningAlgValues *[]string            `json:"backchannel_authentication_request_signing_alg_values_supported,omitempty"`
+	DPoPSigningAlgValuesSupported                    *[]string            `json:"dpop_signing_alg_values_supported,omitempty"`
+	TLSClientCertificateBoundAccessTokens            *bool                `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+	AuthorizationResponseIssParameterSupported       *bool                `json:"authorization_response_iss_parameter_supported,omitempty"`
+	MTLSEndpointAliases                              *MTLSEndpointAliases `json:"mtls_endpoint_aliases,omitempty"`
+}
+
+// MTLSEndpointAliases holds the endpoints to be used by clients authenticating with mutual TLS
+type MTLSEndpointAliases struct {
+	TokenEndpoint                      *string `json:"token_endpoint,omitempty"`
+	RevocationEndpoint                 *string `json:"revocation_endpoint,omitempty"`
+	IntrospectionEndpoint              *string `json:"introspection_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint        *string `json:"device_authorization_endpoint,omitempty"`
+	RegistrationEndpoint               *string `json:"registration_endpoint,omitempty"`
+	UserinfoEndpoint                   *string `json:"userinfo_endpoint,omitempty"`
+	PushedAuthorizationRequestEndpoint *string `json:"pushed_authorization_request_endpoint,omitempty"`
+	BackchannelAuthenticationEndpoint  *string `json:"backchannel_authentication_endpoint,omitempty"`
+}
+
+// UMA2Configuration is returned by the .well-known/uma2-configuration endpoint
+type UMA2Configuration struct {
+	Issuer                                     *string   `json:"issuer,omitempty"`
+	AuthorizationEndpoint                      *string   `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint                              *string   `json:"token_endpoint,omitempty"`
+	IntrospectionEndpoint                      *string   `json:"introspection_endpoint,omitempty"`
+	EndSessionEndpoint                         *string   `json:"end_session_endpoint,omitempty"`
+	JWKSURI                                    *string   `json:"jwks_uri,omitempty"`
+	RegistrationEndpoint                       *string   `json:"registration_endpoint,omitempty"`
+	ResourceRegistrationEndpoint               *string   `json:"resource_registration_endpoint,omitempty"`
+	PermissionEndpoint                         *string   `json:"permission_endpoint,omitempty"`
+	PolicyEndpoint                             *string   `json:"policy_endpoint,omitempty"`
+	GrantTypesSupported                        *[]string `json:"grant_types_supported,omitempty"`
+	ResponseTypesSupported                     *[]string `json:"response_types_supported,omitempty"`
+	ResponseModesSupported                     *[]string `json:"response_modes_supported,omitempty"`
+	ScopesSupported                            *[]string `json:"scopes_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported          *[]string `json:"token_endpoint_auth_methods_supported,omitempty"`
+	TokenEndpointAuthSigningAlgValuesSupported *[]string `json:"token_endpoint_auth_signing_alg_values_supported,omitempty"`
+}
+
 // ResourcePermission represents a permission granted to a resource
 type ResourcePermission struct {
 	RSID           *string   `json:"rsid,omitempty"`
@@ -183,18 +299,67 @@ type PermissionScope struct {
 	ScopeName *string `json:"name,omitempty"`
 }
 
-// IntroSpectTokenResult is returned when a token was checked
+// IntroSpectTokenResult is returned when a token was checked.
+// It holds the fields of RFC 7662 and the claims Keycloak adds to the introspection response.
 type IntroSpectTokenResult struct {
-	Permissions *[]ResourcePermission `json:"permissions,omitempty"`
-	Exp         *int                  `json:"exp,omitempty"`
-	Nbf         *int                  `json:"nbf,omitempty"`
-	Iat         *int                  `json:"iat,omitempty"`
-	Aud         *StringOrArray        `json:"aud,omitempty"`
-	Active      *bool                 `json:"active,omitempty"`
-	AuthTime    *int                  `json:"auth_time,omitempty"`
-	Jti         *string               `json:"jti,omitempty"`
-	Type        *string               `json:"typ,omitempty"`
-	Azp         *string               `json:"azp,omitempty"`
+	Permissions       *[]ResourcePermission  `json:"permissions,omitempty"`
+	Exp               *int                   `json:"exp,omitempty"`
+	Nbf               *int                   `json:"nbf,omitempty"`
+	Iat               *int                   `json:"iat,omitempty"`
+	Aud               *StringOrArray         `json:"aud,omitempty"`
+	Active            *bool                  `json:"active,omitempty"`
+	AuthTime          *int                   `json:"auth_time,omitempty"`
+	Jti               *string                `json:"jti,omitempty"`
+	Type              *string                `json:"typ,omitempty"`
+	Azp               *string                `json:"azp,omitempty"`
+	Scope             *string                `json:"scope,omitempty"`
+	ClientID          *string                `json:"client_id,omitempty"`
+	Username          *string                `json:"username,omitempty"`
+	TokenType         *string                `json:"token_type,omitempty"`
+	Sub               *string                `json:"sub,omitempty"`
+	Iss               *string                `json:"iss,omitempty"`
+	Sid               *string                `json:"sid,omitempty"`
+	SessionState      *string                `json:"session_state,omitempty"`
+	Acr               *string                `json:"acr,omitempty"`
+	AllowedOrigins    *[]string              `json:"allowed-origins,omitempty"`
+	RealmAccess       *RoleAccess            `json:"realm_access,omitempty"`
+	ResourceAccess    *map[string]RoleAccess `json:"resource_access,omitempty"`
+	Cnf               *ConfirmationClaim     `json:"cnf,omitempty"`
+	PreferredUsername *string                `json:"preferred_username,omitempty"`
+	Email             *string                `json:"email,omitempty"`
+	EmailVerified     *bool                  `json:"email_verified,omitempty"`
+	Name              *string                `json:"name,omitempty"`
+	GivenName         *string                `json:"given_name,omitempty"`
+	FamilyName        *string                `json:"family_name,omitempty"`
+}
+
+// RoleAccess holds the roles of the realm_access claim or of a client in the resource_access claim
+type RoleAccess struct {
+	Roles *[]string `json:"roles,omitempty"`
+}
+
+// ConfirmationClaim holds the key a token is bound to, see DPoP (RFC 9449) and mutual TLS (RFC 8705)
+type ConfirmationClaim struct {
+	Jkt     *string `json:"jkt,omitempty"`
+	X5tS256 *string `json:"x5t#S256,omitempty"`
+}
+
+// IntrospectTokenOptions represents the options to introspect a token.
+// The client is authenticated with ClientSecret or ClientAuthenticator.
+type IntrospectTokenOptions struct {
+	ClientID            *string             `json:"-"`
+	ClientSecret        *string             `json:"-"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
+	Token               *string             `json:"token,omitempty"`
+	TokenTypeHint       *string             `json:"token_type_hint,omitempty"`
+}
+
+// FormData returns a map of options to be used in SetFormData function
+func (i *IntrospectTokenOptions) FormData() map[string]string {
+	m, _ := json.Marshal(i)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	return res
 }
 
 // User represents the Keycloak User Structure
@@ -1104,26 +1269,30 @@ type AuthorizationResponse struct {
 
 // TokenOptions represents the options to obtain a token
 type TokenOptions struct {
-	ClientID            *string   `json:"client_id,omitempty"`
-	ClientSecret        *string   `json:"-"`
-	GrantType           *string   `json:"grant_type,omitempty"`
-	RefreshToken        *string   `json:"refresh_token,omitempty"`
-	Scopes              *[]string `json:"-"`
-	Scope               *string   `json:"scope,omitempty"`
-	ResponseTypes       *[]string `json:"-"`
-	ResponseType        *string   `json:"response_type,omitempty"`
-	Permission          *string   `json:"permission,omitempty"`
-	Username            *string   `json:"username,omitempty"`
-	Password            *string   `json:"password,omitempty"`
-	Totp                *string   `json:"totp,omitempty"`
-	Code                *string   `json:"code,omitempty"`
-	RedirectURI         *string   `json:"redirect_uri,omitempty"`
-	ClientAssertionType *string   `json:"client_assertion_type,omitempty"`
-	ClientAssertion     *string   `json:"client_assertion,omitempty"`
-	SubjectToken        *string   `json:"subject_token,omitempty"`
-	RequestedSubject    *string   `json:"requested_subject,omitempty"`
-	Audience            *string   `json:"audience,omitempty"`
-	RequestedTokenType  *string   `json:"requested_token_type,omitempty"`
+	ClientID            *string             `json:"client_id,omitempty"`
+	ClientSecret        *string             `json:"-"`
+	GrantType           *string             `json:"grant_type,omitempty"`
+	RefreshToken        *string             `json:"refresh_token,omitempty"`
+	Scopes              *[]string           `json:"-"`
+	Scope               *string             `json:"scope,omitempty"`
+	ResponseTypes       *[]string           `json:"-"`
+	ResponseType        *string             `json:"response_type,omitempty"`
+	Permission          *string             `json:"permission,omitempty"`
+	Username            *string             `json:"username,omitempty"`
+	Password            *string             `json:"password,omitempty"`
+	Totp                *string             `json:"totp,omitempty"`
+	Code                *string             `json:"code,omitempty"`
+	RedirectURI         *string             `json:"redirect_uri,omitempty"`
+	ClientAssertionType *string             `json:"client_assertion_type,omitempty"`
+	ClientAssertion     *string             `json:"client_assertion,omitempty"`
+	SubjectToken        *string             `json:"subject_token,omitempty"`
+	RequestedSubject    *string             `json:"requested_subject,omitempty"`
+	Audience            *string             `json:"audience,omitempty"`
+	RequestedTokenType  *string             `json:"requested_token_type,omitempty"`
+	CodeVerifier        *string             `json:"code_verifier,omitempty"`
+	DeviceCode          *string             `json:"device_code,omitempty"`
+	AuthReqID           *string             `json:"auth_req_id,omitempty"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
 }
 
 // FormData returns a map of options to be used in SetFormData function
@@ -1143,6 +1312,191 @@ func (t *TokenOptions) FormData() map[string]string {
 	return res
 }
 
+// AuthCodeURLOptions represents the options to build the authorization URL of the Authorization Code flow
+type AuthCodeURLOptions struct {
+	ClientID            *string   `json:"client_id,omitempty"`
+	RedirectURI         *string   `json:"redirect_uri,omitempty"`
+	Scopes              *[]string `json:"-"`
+	Scope               *string   `json:"scope,omitempty"`
+	ResponseType        *string   `json:"response_type,omitempty"`
+	ResponseMode        *string   `json:"response_mode,omitempty"`
+	State               *string   `json:"state,omitempty"`
+	Nonce               *string   `json:"nonce,omitempty"`
+	CodeChallenge       *string   `json:"code_challenge,omitempty"`
+	CodeChallengeMethod *string   `json:"code_challenge_method,omitempty"`
+	Prompt              *string   `json:"prompt,omitempty"`
+	LoginHint           *string   `json:"login_hint,omitempty"`
+	IDPHint             *string   `json:"kc_idp_hint,omitempty"`
+	MaxAge              *int      `json:"max_age,string,omitempty"`
+	UILocales           *string   `json:"ui_locales,omitempty"`
+	ACRValues           *string   `json:"acr_values,omitempty"`
+	Request             *string   `json:"request,omitempty"`
+	RequestURI          *string   `json:"request_uri,omitempty"`
+}
+
+// FormData returns a map of options to be used as query parameters of the authorization URL
+func (a *AuthCodeURLOptions) FormData() map[string]string {
+	if !NilOrEmptySlice(a.Scopes) {
+		a.Scope = StringP(strings.Join(*a.Scopes, " "))
+	}
+	// the parameters of a pushed authorization request are referenced by the request uri
+	if NilOrEmpty(a.RequestURI) {
+		if NilOrEmpty(a.Scope) {
+			a.Scope = StringP("openid")
+		}
+		if NilOrEmpty(a.ResponseType) {
+			a.ResponseType = StringP("code")
+		}
+	}
+	if !NilOrEmpty(a.CodeChallenge) && NilOrEmpty(a.CodeChallengeMethod) {
+		a.CodeChallengeMethod = StringP(CodeChallengeMethodS256)
+	}
+	m, _ := json.Marshal(a)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	return res
+}
+
+// BackchannelAuthenticationOptions represents the options to start a Client-Initiated Backchannel Authentication (CIBA).
+// The client is authenticated with ClientSecret, ClientAssertion or ClientAuthenticator.
+type BackchannelAuthenticationOptions struct {
+	ClientID            *string             `json:"client_id,omitempty"`
+	ClientSecret        *string             `json:"-"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
+	ClientAssertionType *string             `json:"client_assertion_type,omitempty"`
+	ClientAssertion     *string             `json:"client_assertion,omitempty"`
+	Scopes              *[]string           `json:"-"`
+	Scope               *string             `json:"scope,omitempty"`
+	LoginHint           *string             `json:"login_hint,omitempty"`
+	LoginHintToken      *string             `json:"login_hint_token,omitempty"`
+	IDTokenHint         *string             `json:"id_token_hint,omitempty"`
+	BindingMessage      *string             `json:"binding_message,omitempty"`
+	UserCode            *string             `json:"user_code,omitempty"`
+	RequestedExpiry     *int                `json:"requested_expiry,string,omitempty"`
+	ACRValues           *string             `json:"acr_values,omitempty"`
+}
+
+// FormData returns a map of options to be used in SetFormData function
+func (b *BackchannelAuthenticationOptions) FormData() map[string]string {
+	if !NilOrEmptySlice(b.Scopes) {
+		b.Scope = StringP(strings.Join(*b.Scopes, " "))
+	}
+	if NilOrEmpty(b.Scope) {
+		b.Scope = StringP("openid")
+	}
+	m, _ := json.Marshal(b)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	return res
+}
+
+// BackchannelAuthenticationResponse is returned by the backchannel authentication endpoint
+type BackchannelAuthenticationResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	ExpiresIn int    `json:"expires_in"`
+	Interval  int    `json:"interval"`
+}
+
+// TokenExchangeOptions represents the options of a token exchange (RFC 8693).
+// Keycloak's standard token exchange (v2) requires SubjectToken and supports Audiences, Scopes and RequestedTokenType.
+// RequestedSubject (impersonation) and SubjectIssuer (external to internal exchange) are only supported by the
+// legacy token exchange (v1). SubjectTokenType defaults to an access token if SubjectToken is set.
+type TokenExchangeOptions struct {
+	ClientID            *string             `json:"client_id,omitempty"`
+	ClientSecret        *string             `json:"-"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
+	SubjectToken        *string             `json:"subject_token,omitempty"`
+	SubjectTokenType    *string             `json:"subject_token_type,omitempty"`
+	SubjectIssuer       *string             `json:"subject_issuer,omitempty"`
+	ActorToken          *string             `json:"actor_token,omitempty"`
+	ActorTokenType      *string             `json:"actor_token_type,omitempty"`
+	RequestedTokenType  *string             `json:"requested_token_type,omitempty"`
+	RequestedSubject    *string             `json:"requested_subject,omitempty"`
+	Audiences           *[]string           `json:"-"`
+	Scopes              *[]string           `json:"-"`
+	Scope               *string             `json:"scope,omitempty"`
+}
+
+// FormData returns a map of options to be used in SetFormData function.
+// Audiences are sent as repeated form values and not part of the map.
+func (t *TokenExchangeOptions) FormData() map[string]string {
+	if !NilOrEmptySlice(t.Scopes) {
+		t.Scope = StringP(strings.Join(*t.Scopes, " "))
+	}
+	if !NilOrEmpty(t.SubjectToken) && NilOrEmpty(t.SubjectTokenType) {
+		t.SubjectTokenType = StringP(TokenTypeAccessToken)
+	}
+	m, _ := json.Marshal(t)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	res["grant_type"] = GrantTypeTokenExchange
+	return res
+}
+
+// PushedAuthorizationRequestOptions represents the options of a pushed authorization request.
+// If Request is set, only the client id and the request object are pushed.
+// The client is authenticated with ClientSecret, ClientAssertion or ClientAuthenticator.
+type PushedAuthorizationRequestOptions struct {
+	AuthCodeURLOptions
+	ClientSecret        *string             `json:"-"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
+	ClientAssertionType *string             `json:"client_assertion_type,omitempty"`
+	ClientAssertion     *string             `json:"client_assertion,omitempty"`
+}
+
+// FormData returns a map of options to be used in SetFormData function
+func (p *PushedAuthorizationRequestOptions) FormData() map[string]string {
+	res := map[string]string{}
+	if !NilOrEmpty(p.Request) {
+		res["client_id"] = PString(p.ClientID)
+		res["request"] = PString(p.Request)
+	} else {
+		res = p.AuthCodeURLOptions.FormData()
+	}
+	if !NilOrEmpty(p.ClientAssertion) {
+		res["client_assertion_type"] = PString(p.ClientAssertionType)
+		res["client_assertion"] = PString(p.ClientAssertion)
+	}
+	return res
+}
+
+// PushedAuthorizationResponse is returned by the pushed authorization request endpoint
+type PushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// DeviceAuthorizationOptions represents the options to start the Device Authorization Grant.
+// A confidential client is authenticated with ClientSecret or ClientAuthenticator.
+type DeviceAuthorizationOptions struct {
+	ClientID            *string             `json:"client_id,omitempty"`
+	ClientSecret        *string             `json:"-"`
+	ClientAuthenticator ClientAuthenticator `json:"-"`
+	Scopes              *[]string           `json:"-"`
+	Scope               *string             `json:"scope,omitempty"`
+}
+
+// FormData returns a map of options to be used in SetFormData function
+func (d *DeviceAuthorizationOptions) FormData() map[string]string {
+	if !NilOrEmptySlice(d.Scopes) {
+		d.Scope = StringP(strings.Join(*d.Scopes, " "))
+	}
+	m, _ := json.Marshal(d)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	return res
+}
+
+// DeviceAuthorizationResponse is returned by the device authorization endpoint
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
 // RequestingPartyTokenOptions represents the options to obtain a requesting party token
 type RequestingPartyTokenOptions struct {
 	GrantType                     *string   `json:"grant_type,omitempty"`
@@ -1694,6 +2048,12 @@ func prettyStringStruct(t interface{}) string {
 func (v *CertResponseKey) String() string                           { return prettyStringStruct(v) }
 func (v *CertResponse) String() string                              { return prettyStringStruct(v) }
 func (v *IssuerResponse) String() string                            { return prettyStringStruct(v) }
+func (v *RoleAccess) String() string                                { return prettyStringStruct(v) }
+func (v *ConfirmationClaim) String() string                         { return prettyStringStruct(v) }
+func (v *IntrospectTokenOptions) String() string                    { return prettyStringStruct(v) }
+func (v *OpenIDConfiguration) String() string                       { return prettyStringStruct(v) }
+func (v *MTLSEndpointAliases) String() string                       { return prettyStringStruct(v) }
+func (v *UMA2Configuration) String() string                         { return prettyStringStruct(v) }
 func (v *ResourcePermission) String() string                        { return prettyStringStruct(v) }
 func (v *PermissionResource) String() string                        { return prettyStringStruct(v) }
 func (v *PermissionScope) String() string                           { return prettyStringStruct(v) }
@@ -1747,6 +2107,14 @@ func (v *RealmRepresentation) String() string                       { return pre
 func (v *MultiValuedHashMap) String() string                        { return prettyStringStruct(v) }
 func (t *TokenOptions) String() string                              { return prettyStringStruct(t) }
 func (t *RequestingPartyTokenOptions) String() string               { return prettyStringStruct(t) }
+func (v *AuthCodeURLOptions) String() string                        { return prettyStringStruct(v) }
+func (v *DeviceAuthorizationOptions) String() string                { return prettyStringStruct(v) }
+func (v *PushedAuthorizationRequestOptions) String() string         { return prettyStringStruct(v) }
+func (v *PushedAuthorizationResponse) String() string               { return prettyStringStruct(v) }
+func (v *BackchannelAuthenticationOptions) String() string          { return prettyStringStruct(v) }
+func (v *BackchannelAuthenticationResponse) String() string         { return prettyStringStruct(v) }
+func (v *TokenExchangeOptions) String() string                      { return prettyStringStruct(v) }
+func (v *DeviceAuthorizationResponse) String() string               { return prettyStringStruct(v) }
 func (v *RequestingPartyPermission) String() string                 { return prettyStringStruct(v) }
 func (v *UserSessionRepresentation) String() string                 { return prettyStringStruct(v) }
 func (v *SystemInfoRepresentation) String() string                  { return prettyStringStruct(v) }
@@ -0,0 +1,62 @@
+package gocloak
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// LoadPKCS12Certificate decodes a PKCS#12 keystore holding a single certificate and its private key
+func LoadPKCS12Certificate(keystore []byte, password string) (tls.Certificate, error) {
+	privateKey, certificate, err := pkcs12.Decode(keystore, password)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "could not decode pkcs12 keystore")
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{certificate.Raw},
+		PrivateKey:  privateKey,
+		Leaf:        certificate,
+	}, nil
+}
+
+// CertificateThumbprint computes the SHA-256 thumbprint of the certificate,
+// which Keycloak binds tokens to as cnf.x5t#S256 claim
+func CertificateThumbprint(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// ValidateCertificateBound validates a certificate-bound access token (RFC 8705).
+// The cnf.x5t#S256 claim of the token must match the certificate the client presented in the TLS handshake,
+// e.g. r.TLS.PeerCertificates[0].
+func (v *TokenValidator) ValidateCertificateBound(ctx context.Context, accessToken string, peerCertificate *x509.Certificate) (*jwt.Token, *jwt.MapClaims, error) {
+	token, claims, err := v.Validate(ctx, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var thumbprint string
+	if cnf, ok := (*claims)["cnf"].(map[string]interface{}); ok {
+		thumbprint, _ = cnf["x5t#S256"].(string)
+	}
+	if thumbprint == "" {
+		return nil, nil, validationError(TokenValidationCheckCertificate, nil, "token is not bound to a certificate")
+	}
+	if peerCertificate == nil {
+		return nil, nil, validationError(TokenValidationCheckCertificate, nil, "no client certificate was presented")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(thumbprint), []byte(CertificateThumbprint(peerCertificate))) != 1 {
+		return nil, nil, validationError(TokenValidationCheckCertificate, nil, "token is bound to another certificate")
+	}
+
+	return token, claims, nil
+}
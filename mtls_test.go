@@ -0,0 +1,95 @@
+package gocloak_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func loadTestKeystore(t *testing.T) ([]byte, tls.Certificate) {
+	keystore, err := os.ReadFile(filepath.Join("testdata", "keystore.p12"))
+	require.NoError(t, err)
+	certificate, err := gocloak.LoadPKCS12Certificate(keystore, "secret")
+	require.NoError(t, err)
+	return keystore, certificate
+}
+
+func TestLoginClientTLS(t *testing.T) {
+	t.Parallel()
+	keystore, certificate := loadTestKeystore(t)
+
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		requests <- r
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "bound-token"})
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	client := gocloak.NewClient(server.URL, gocloak.SetClientCertificate(certificate))
+	client.RestyClient().SetRootCertificateFromString(serverCA)
+
+	token, err := client.LoginClientTLS(context.Background(), "mtls-client", "test")
+	require.NoError(t, err)
+	require.Equal(t, "bound-token", token.AccessToken)
+	request := <-requests
+	require.Equal(t, tokenPath, request.URL.Path)
+	require.Equal(t, "mtls-client", request.PostForm.Get("client_id"))
+	require.Empty(t, request.Header.Get("Authorization"))
+	require.Len(t, request.TLS.PeerCertificates, 1)
+	require.Equal(t, certificate.Leaf.Raw, request.TLS.PeerCertificates[0].Raw)
+
+	_, err = gocloak.LoadPKCS12Certificate(keystore, "wrong")
+	require.Error(t, err)
+}
+
+func TestTokenValidator_ValidateCertificateBound(t *testing.T) {
+	t.Parallel()
+	_, certificate := loadTestKeystore(t)
+	realm := newFakeRealm(t, "test")
+	validator := gocloak.NewTokenValidator(gocloak.NewClient(realm.server.URL), "test")
+	ctx := context.Background()
+
+	claims := realm.claims()
+	claims["cnf"] = map[string]string{"x5t#S256": gocloak.CertificateThumbprint(certificate.Leaf)}
+	boundToken := realm.sign(t, claims)
+
+	_, validated, err := validator.ValidateCertificateBound(ctx, boundToken, certificate.Leaf)
+	require.NoError(t, err)
+	require.Equal(t, "user-id", (*validated)["sub"])
+
+	otherServer := httptest.NewTLSServer(http.NotFoundHandler())
+	otherServer.Close()
+
+	testCases := map[string]struct {
+		Token string
+		Peer  *x509.Certificate
+	}{
+		"unbound token":     {Token: realm.sign(t, realm.claims()), Peer: certificate.Leaf},
+		"no certificate":    {Token: boundToken},
+		"other certificate": {Token: boundToken, Peer: otherServer.Certificate()},
+	}
+	for name, testCase := range testCases {
+		_, _, err := validator.ValidateCertificateBound(ctx, testCase.Token, testCase.Peer)
+		var validationErr *gocloak.TokenValidationError
+		require.True(t, errors.As(err, &validationErr), "%s: unexpected error: %v", name, err)
+		require.Equal(t, gocloak.TokenValidationCheckCertificate, validationErr.Check, name)
+	}
+}
@@ -0,0 +1,109 @@
+package gocloak
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var operationContextKey = contextKey("operation")
+
+// CertsCacheEvent is an event of the certs cache reported to the Observer
+type CertsCacheEvent string
+
+const (
+	// CertsCacheHit is reported if GetCerts returns cached certs
+	CertsCacheHit CertsCacheEvent = "hit"
+	// CertsCacheMiss is reported if GetCerts has to fetch the certs because none or only expired certs are cached
+	CertsCacheMiss CertsCacheEvent = "miss"
+	// CertsCacheRefresh is reported whenever certs were fetched from Keycloak, also in background refreshes
+	CertsCacheRefresh CertsCacheEvent = "refresh"
+)
+
+// RequestMetrics describes a request to Keycloak.
+// Operation is the GoCloak method which sent the request, e.g. GetUsers, and empty for requests
+// created with GetRequest by the caller. StatusCode is zero if no response was received
+// and ErrorType is empty if the request succeeded. Duration spans from the first attempt until the last attempt finished.
+type RequestMetrics struct {
+	Operation  string
+	Realm      string
+	Method     string
+	StatusCode int
+	ErrorType  APIErrType
+	Retries    int
+	Duration   time.Duration
+}
+
+// Observer receives metrics of a GoCloak client, see SetObserver.
+// Implementations have to be safe for concurrent use.
+type Observer interface {
+	// ObserveRequest is called once a request to Keycloak finished, including its retries
+	ObserveRequest(ctx context.Context, metrics RequestMetrics)
+	// ObserveCertsCache is called on the events of the certs cache of the realm
+	ObserveCertsCache(ctx context.Context, realm string, event CertsCacheEvent)
+}
+
+// requestObserver reports the requests of the resty client to the observer
+type requestObserver struct {
+	observer Observer
+	started  sync.Map
+}
+
+func (o *requestObserver) beforeRequest(_ *resty.Client, req *resty.Request) error {
+	o.started.LoadOrStore(req, time.Now())
+	return nil
+}
+
+func (o *requestObserver) finish(req *resty.Request, resp *resty.Response, err error) {
+	if req == nil {
+		return
+	}
+	started, ok := o.started.LoadAndDelete(req)
+	if !ok {
+		return
+	}
+
+	_, realm := classifyEndpoint(req.URL)
+	metrics := RequestMetrics{
+		Operation: operationFromContext(req.Context()),
+		Realm:     realm,
+		Method:    req.Method,
+		Retries:   req.Attempt - 1,
+		Duration:  time.Since(started.(time.Time)),
+	}
+	if metrics.Retries < 0 {
+		metrics.Retries = 0
+	}
+
+	var responseErr *resty.ResponseError
+	if errors.As(err, &responseErr) {
+		resp, err = responseErr.Response, responseErr.Err
+	}
+	if resp != nil && resp.RawResponse != nil {
+		metrics.StatusCode = resp.StatusCode()
+	}
+	if apiError := responseError(resp, err, ""); apiError != nil {
+		metrics.ErrorType = apiError.Type
+	}
+
+	o.observer.ObserveRequest(req.Context(), metrics)
+}
+
+// observeCertsCache reports an event of the certs cache to the observer, if one is set
+func (g *GoCloak) observeCertsCache(ctx context.Context, realm string, event CertsCacheEvent) {
+	if g.Config.observer != nil {
+		g.Config.observer.ObserveCertsCache(ctx, realm, event)
+	}
+}
+
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationContextKey).(string)
+	return operation
+}
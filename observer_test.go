@@ -0,0 +1,135 @@
+package gocloak_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+type recordingObserver struct {
+	lock        sync.Mutex
+	requests    []gocloak.RequestMetrics
+	certsEvents []gocloak.CertsCacheEvent
+}
+
+func (o *recordingObserver) ObserveRequest(_ context.Context, metrics gocloak.RequestMetrics) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.requests = append(o.requests, metrics)
+}
+
+func (o *recordingObserver) ObserveCertsCache(_ context.Context, realm string, event gocloak.CertsCacheEvent) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if realm == "test" {
+		o.certsEvents = append(o.certsEvents, event)
+	}
+}
+
+func (o *recordingObserver) lastRequest() gocloak.RequestMetrics {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.requests[len(o.requests)-1]
+}
+
+func TestObserver_Requests(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name   string
+		Status int
+		Want   gocloak.RequestMetrics
+	}{
+		{"success", http.StatusOK, gocloak.RequestMetrics{StatusCode: http.StatusOK}},
+		{"not found", http.StatusNotFound, gocloak.RequestMetrics{StatusCode: http.StatusNotFound, ErrorType: gocloak.APIErrTypeNotFound}},
+		{"forbidden", http.StatusForbidden, gocloak.RequestMetrics{StatusCode: http.StatusForbidden, ErrorType: gocloak.APIErrTypeForbidden}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			server, _ := tracedServer(t, tc.Status)
+			observer := &recordingObserver{}
+			client := gocloak.NewClient(server.URL, gocloak.SetObserver(observer))
+
+			_, _ = client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+
+			metrics := observer.lastRequest()
+			require.Equal(t, "GetUsers", metrics.Operation)
+			require.Equal(t, "realm", metrics.Realm)
+			require.Equal(t, http.MethodGet, metrics.Method)
+			require.Equal(t, tc.Want.StatusCode, metrics.StatusCode)
+			require.Equal(t, tc.Want.ErrorType, metrics.ErrorType)
+			require.Zero(t, metrics.Retries)
+			require.Positive(t, metrics.Duration)
+		})
+	}
+}
+
+func TestObserver_RetriesAndTransportErrors(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	observer := &recordingObserver{}
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetObserver(observer),
+		gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}),
+	)
+
+	_, err := client.GetServerInfo(context.Background(), "token")
+	require.NoError(t, err)
+	require.Len(t, observer.requests, 1)
+	require.Equal(t, "GetServerInfo", observer.requests[0].Operation)
+	require.Equal(t, 2, observer.requests[0].Retries)
+	require.Equal(t, http.StatusOK, observer.requests[0].StatusCode)
+
+	server.Close()
+	_, err = client.GetServerInfo(context.Background(), "token")
+	require.Error(t, err)
+	metrics := observer.lastRequest()
+	require.Zero(t, metrics.StatusCode)
+	require.Equal(t, gocloak.APIErrTypeUnknown, metrics.ErrorType)
+}
+
+func TestObserver_CertsCache(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeCertsEndpoint{kids: []string{"kid"}}
+	observer := &recordingObserver{}
+	client := newFakeCertsClient(t, endpoint, gocloak.SetObserver(observer), gocloak.SetCertCacheInvalidationTime(time.Hour))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCerts(ctx, "test")
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []gocloak.CertsCacheEvent{gocloak.CertsCacheMiss, gocloak.CertsCacheRefresh, gocloak.CertsCacheHit}, observer.certsEvents)
+	require.Equal(t, "GetCerts", observer.lastRequest().Operation)
+	require.Equal(t, "test", observer.lastRequest().Realm)
+}
+
+func TestObserver_Panic(t *testing.T) {
+	t.Parallel()
+	server, _ := tracedServer(t, http.StatusOK)
+	observer := &recordingObserver{}
+	client := gocloak.NewClient(server.URL, gocloak.SetObserver(observer))
+	client.RestyClient().OnAfterResponse(func(*resty.Client, *resty.Response) error {
+		panic(errors.New("hook failed"))
+	})
+
+	require.Panics(t, func() {
+		_, _ = client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+	})
+
+	// the request started before the panic is reported instead of being kept forever
+	metrics := observer.lastRequest()
+	require.Equal(t, "GetUsers", metrics.Operation)
+	require.Equal(t, gocloak.APIErrTypeUnknown, metrics.ErrorType)
+}
@@ -0,0 +1,83 @@
+package gocloak
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+// CreateRequestObject signs the authorization parameters as a JWT secured authorization request (JAR).
+// The request object is issued by the client, addressed to the realm and valid for the given lifetime.
+func (g *GoCloak) CreateRequestObject(realm string, options AuthCodeURLOptions, key interface{}, signedMethod jwt.SigningMethod, lifetime time.Duration) (string, error) {
+	const errMessage = "could not create request object"
+
+	if NilOrEmpty(options.ClientID) {
+		return "", errors.New(errMessage + ": client id is required")
+	}
+
+	// a request object can't reference another request
+	options.Request = nil
+	options.RequestURI = nil
+
+	claims := jwt.MapClaims{}
+	for name, value := range options.FormData() {
+		claims[name] = value
+	}
+	if options.MaxAge != nil {
+		claims["max_age"] = *options.MaxAge
+	}
+
+	now := time.Now()
+	claims["iss"] = *options.ClientID
+	claims["aud"] = g.getRealmURL(realm)
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = now.Add(lifetime).Unix()
+	claims["jti"] = ksuid.New().String()
+
+	requestObject, err := jwx.SignClaims(claims, key, signedMethod)
+	if err != nil {
+		return "", errors.Wrap(err, errMessage)
+	}
+
+	return requestObject, nil
+}
+
+// PushAuthorizationRequest pushes the authorization parameters to the realm (PAR).
+// The returned request uri is passed as AuthCodeURLOptions.RequestURI to GetAuthCodeURL before it expires.
+func (g *GoCloak) PushAuthorizationRequest(ctx context.Context, realm string, options PushedAuthorizationRequestOptions) (*PushedAuthorizationResponse, error) {
+	const errMessage = "could not push authorization request"
+
+	ctx, span := g.startSpan(ctx, "PushAuthorizationRequest")
+	defer span.End()
+
+	if NilOrEmpty(options.ClientID) {
+		return nil, errors.New(errMessage + ": client id is required")
+	}
+
+	parURL, err := g.getPushedAuthorizationRequestURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, *options.ClientID, PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	var result PushedAuthorizationResponse
+	resp, err := req.SetFormData(options.FormData()).
+		SetResult(&result).
+		Post(parURL)
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
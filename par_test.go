@@ -0,0 +1,91 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const parPath = "/realms/test/protocol/openid-connect/ext/par/request"
+
+func TestPushAuthorizationRequest(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "test")
+	realm.server.handle(parPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(gocloak.PushedAuthorizationResponse{
+			RequestURI: "urn:ietf:params:oauth:request_uri:abc",
+			ExpiresIn:  60,
+		})
+	})
+	client := gocloak.NewClient(realm.server.URL)
+	ctx := context.Background()
+
+	response, err := client.PushAuthorizationRequest(ctx, "test", gocloak.PushedAuthorizationRequestOptions{
+		AuthCodeURLOptions: gocloak.AuthCodeURLOptions{
+			ClientID:    gocloak.StringP("frontend"),
+			RedirectURI: gocloak.StringP("https://app.example.com/callback"),
+			State:       gocloak.StringP("state"),
+		},
+		ClientAssertionType: gocloak.StringP("urn:ietf:params:oauth:client-assertion-type:jwt-bearer"),
+		ClientAssertion:     gocloak.StringP("assertion"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "urn:ietf:params:oauth:request_uri:abc", response.RequestURI)
+	require.Equal(t, 60, response.ExpiresIn)
+	pushed := realm.server.last(t, parPath).Form
+	require.Equal(t, "code", pushed.Get("response_type"))
+	require.Equal(t, "state", pushed.Get("state"))
+	require.Equal(t, "assertion", pushed.Get("client_assertion"))
+
+	authURL, err := client.GetAuthCodeURL(ctx, "test", gocloak.AuthCodeURLOptions{
+		ClientID:   gocloak.StringP("frontend"),
+		RequestURI: &response.RequestURI,
+	})
+	require.NoError(t, err)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"client_id":   {"frontend"},
+		"request_uri": {response.RequestURI},
+	}, parsed.Query())
+
+	// signed request object
+	requestObject, err := client.CreateRequestObject("test", gocloak.AuthCodeURLOptions{
+		ClientID: gocloak.StringP("frontend"),
+		State:    gocloak.StringP("state"),
+		MaxAge:   gocloak.IntP(300),
+	}, realm.key, jwt.SigningMethodRS256, time.Minute)
+	require.NoError(t, err)
+
+	_, err = client.PushAuthorizationRequest(ctx, "test", gocloak.PushedAuthorizationRequestOptions{
+		AuthCodeURLOptions: gocloak.AuthCodeURLOptions{
+			ClientID: gocloak.StringP("frontend"),
+			State:    gocloak.StringP("ignored"),
+			Request:  &requestObject,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"client_id": {"frontend"},
+		"request":   {requestObject},
+	}, realm.server.last(t, parPath).Form)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(requestObject, claims, func(*jwt.Token) (interface{}, error) {
+		return &realm.key.PublicKey, nil
+	}, jwt.WithAudience(realm.issuer()), jwt.WithIssuer("frontend"))
+	require.NoError(t, err)
+	require.Equal(t, "state", claims["state"])
+	require.Equal(t, "code", claims["response_type"])
+	require.EqualValues(t, 300, claims["max_age"])
+}
@@ -0,0 +1,334 @@
+// Package enforcer provides a UMA policy enforcer for resource servers protected by Keycloak Authorization Services.
+package enforcer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const (
+	// decisionCacheSweepInterval is how often expired decisions are removed
+	decisionCacheSweepInterval = time.Minute
+	// decisionCacheSweepSize is the number of cached decisions which triggers a sweep before the interval passed
+	decisionCacheSweepSize = 1024
+)
+
+// Decision is the result of enforcing the permissions of a request
+type Decision struct {
+	// Allowed is true if the request may access the resource
+	Allowed bool
+	// Unauthenticated is true if the request has no access token or the server rejected it
+	Unauthenticated bool
+	// Ticket is the permission ticket the client can exchange for a requesting party token
+	Ticket string
+}
+
+// PolicyEnforcer maps requests to protected resources and scopes of a resource server
+// and asks the server whether the access token of a request grants the permission.
+// Denied requests receive a UMA permission ticket, which clients exchange for a requesting party token.
+type PolicyEnforcer struct {
+	client          gocloak.GoCloakIface
+	realm           string
+	clientID        string
+	protectionToken *gocloak.TokenSource
+	permissive      bool
+	cacheTTL        time.Duration
+	now             func() time.Time
+
+	pathsLock   sync.RWMutex
+	paths       []Path
+	loadedPaths []Path
+
+	resourcesLock sync.Mutex
+	resources     map[string]resourceInfo
+	resourceIDs   map[string]string
+
+	issuerLock sync.Mutex
+	issuer     string
+
+	decisionsLock      sync.Mutex
+	decisions          map[string]cachedDecision
+	decisionsSweptAt   time.Time
+	decisionsSweepSize int
+}
+
+type cachedDecision struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// New creates a new PolicyEnforcer for the resource server with the given client credentials.
+// The credentials are used to obtain the protection API token, which requires the uma_protection role.
+// Decisions are cached for 30 seconds, but never beyond the expiry of the access token.
+func New(client gocloak.GoCloakIface, realm, clientID, clientSecret string, options ...func(e *PolicyEnforcer)) *PolicyEnforcer {
+	e := &PolicyEnforcer{
+		client:          client,
+		realm:           realm,
+		clientID:        clientID,
+		protectionToken: gocloak.NewClientTokenSource(client, clientID, clientSecret, realm),
+		cacheTTL:        30 * time.Second,
+		now:             time.Now,
+		resources:       make(map[string]resourceInfo),
+		resourceIDs:     make(map[string]string),
+		decisions:       make(map[string]cachedDecision),
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	return e
+}
+
+// ==== Functional Options ===
+
+// SetPaths sets the paths mapping requests to protected resources
+func SetPaths(paths ...Path) func(e *PolicyEnforcer) {
+	return func(e *PolicyEnforcer) {
+		e.paths = append(e.paths, paths...)
+	}
+}
+
+// SetPermissive allows requests to paths which are not mapped to a resource, which are denied by default
+func SetPermissive() func(e *PolicyEnforcer) {
+	return func(e *PolicyEnforcer) {
+		e.permissive = true
+	}
+}
+
+// SetDecisionCacheTTL sets how long decisions of the server are cached. Zero disables the cache.
+func SetDecisionCacheTTL(ttl time.Duration) func(e *PolicyEnforcer) {
+	return func(e *PolicyEnforcer) {
+		e.cacheTTL = ttl
+	}
+}
+
+// SetProtectionTokenSource sets the token source of the protection API token, e.g. to authenticate with a signed JWT
+func SetProtectionTokenSource(tokenSource *gocloak.TokenSource) func(e *PolicyEnforcer) {
+	return func(e *PolicyEnforcer) {
+		e.protectionToken = tokenSource
+	}
+}
+
+// Enforce decides whether the access token grants access to the resource mapped to the method and path.
+// The path is cleaned before it is matched, so /admin/, //admin and /api/../admin all map to /admin.
+// accessToken may be empty, in which case only a permission ticket is created.
+func (e *PolicyEnforcer) Enforce(ctx context.Context, accessToken, method, requestPath string) (*Decision, error) {
+	path := e.matchPath(cleanPath(requestPath))
+	if path == nil {
+		return &Decision{Allowed: e.permissive}, nil
+	}
+
+	scopes, ok := path.requiredScopes(method)
+	if !ok {
+		return &Decision{}, nil
+	}
+
+	decision := &Decision{Unauthenticated: accessToken == ""}
+	if accessToken != "" {
+		allowed, err := e.decide(ctx, accessToken, path.permission(scopes))
+		switch {
+		case errors.Is(err, gocloak.ErrUnauthorized):
+			decision.Unauthenticated = true
+		case err != nil:
+			return nil, err
+		case allowed:
+			return &Decision{Allowed: true}, nil
+		}
+	}
+
+	ticket, err := e.createTicket(ctx, path, scopes)
+	if err != nil {
+		return nil, err
+	}
+	decision.Ticket = ticket
+
+	return decision, nil
+}
+
+// decide asks the server for the decision on the permission, using the cache if enabled
+func (e *PolicyEnforcer) decide(ctx context.Context, accessToken, permission string) (bool, error) {
+	key := decisionCacheKey(accessToken, permission)
+	if allowed, ok := e.cachedDecision(key); ok {
+		return allowed, nil
+	}
+
+	var allowed bool
+	result, err := e.client.GetRequestingPartyPermissionDecision(ctx, accessToken, e.realm, gocloak.RequestingPartyTokenOptions{
+		Audience:    gocloak.StringP(e.clientID),
+		Permissions: &[]string{permission},
+	})
+	switch {
+	case errors.Is(err, gocloak.ErrForbidden):
+	case err != nil:
+		return false, err
+	default:
+		allowed = gocloak.PBool(result.Result)
+	}
+
+	e.storeDecision(key, accessToken, allowed)
+
+	return allowed, nil
+}
+
+func (e *PolicyEnforcer) cachedDecision(key string) (bool, bool) {
+	e.decisionsLock.Lock()
+	defer e.decisionsLock.Unlock()
+
+	decision, ok := e.decisions[key]
+	if !ok || !e.now().Before(decision.expiresAt) {
+		return false, false
+	}
+
+	return decision.allowed, true
+}
+
+func (e *PolicyEnforcer) storeDecision(key, accessToken string, allowed bool) {
+	if e.cacheTTL <= 0 {
+		return
+	}
+
+	now := e.now()
+	expiresAt := now.Add(e.cacheTTL)
+	// the server already accepted the token, its expiry is only used to limit the cache lifetime
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, &claims); err == nil && claims.ExpiresAt != nil {
+		if claims.ExpiresAt.Before(expiresAt) {
+			expiresAt = claims.ExpiresAt.Time
+		}
+	}
+
+	e.decisionsLock.Lock()
+	defer e.decisionsLock.Unlock()
+
+	if e.decisionsSweepSize == 0 {
+		e.decisionsSweptAt = now
+		e.decisionsSweepSize = decisionCacheSweepSize
+	}
+	if now.Sub(e.decisionsSweptAt) >= decisionCacheSweepInterval || len(e.decisions) >= e.decisionsSweepSize {
+		e.sweepDecisions(now)
+	}
+	e.decisions[key] = cachedDecision{allowed: allowed, expiresAt: expiresAt}
+}
+
+// sweepDecisions removes the expired decisions, it has to be called with e.decisionsLock held
+func (e *PolicyEnforcer) sweepDecisions(now time.Time) {
+	for k, decision := range e.decisions {
+		if !now.Before(decision.expiresAt) {
+			delete(e.decisions, k)
+		}
+	}
+
+	// if most decisions are still valid, grow the threshold so that sweeping stays amortized
+	e.decisionsSweptAt = now
+	e.decisionsSweepSize = decisionCacheSweepSize
+	if 2*len(e.decisions) > e.decisionsSweepSize {
+		e.decisionsSweepSize = 2 * len(e.decisions)
+	}
+}
+
+func decisionCacheKey(accessToken, permission string) string {
+	hash := sha256.Sum256([]byte(accessToken + "\x00" + permission))
+	return hex.EncodeToString(hash[:])
+}
+
+// createTicket creates a permission ticket for the resource and scopes of the path.
+// Without required scopes the ticket requests all scopes of the resource.
+func (e *PolicyEnforcer) createTicket(ctx context.Context, path *Path, scopes []string) (string, error) {
+	pat, err := e.protectionToken.AccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	resource, err := e.resource(ctx, pat, path)
+	if err != nil {
+		return "", err
+	}
+	if len(scopes) == 0 {
+		scopes = resource.scopes
+	}
+	if resource.id == "" || len(scopes) == 0 {
+		return "", nil
+	}
+
+	ticket, err := e.client.CreatePermissionTicket(ctx, pat, e.realm, []gocloak.CreatePermissionTicketParams{{
+		ResourceID:     gocloak.StringP(resource.id),
+		ResourceScopes: &scopes,
+	}})
+	if err != nil {
+		return "", err
+	}
+
+	return gocloak.PString(ticket.Ticket), nil
+}
+
+// Handler returns a handler which calls next only for requests the access token grants access to.
+// Denied requests are answered with 401 if they are unauthenticated and with 403 otherwise.
+// If a permission ticket was created, it is sent in the WWW-Authenticate header as defined by UMA 2.0.
+func (e *PolicyEnforcer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, err := e.Enforce(r.Context(), bearerToken(r), r.Method, r.URL.Path)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if decision.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if decision.Ticket != "" {
+			asURI, err := e.getIssuer(r.Context())
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf("UMA realm=%q, as_uri=%q, ticket=%q", e.realm, asURI, decision.Ticket))
+		}
+
+		status := http.StatusForbidden
+		if decision.Unauthenticated {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, http.StatusText(status), status)
+	})
+}
+
+// getIssuer returns the issuer URL of the realm, which is the as_uri of the UMA challenge
+func (e *PolicyEnforcer) getIssuer(ctx context.Context) (string, error) {
+	e.issuerLock.Lock()
+	defer e.issuerLock.Unlock()
+
+	if e.issuer != "" {
+		return e.issuer, nil
+	}
+
+	issuer, err := e.client.GetIssuer(ctx, e.realm)
+	if err != nil {
+		return "", err
+	}
+	e.issuer = strings.TrimSuffix(gocloak.PString(issuer.TokenService), "/protocol/openid-connect")
+
+	return e.issuer, nil
+}
+
+// bearerToken returns the token of the authorization header of the request
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,439 @@
+package enforcer_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/enforcer"
+)
+
+// fakeAuthorizationServer grants the permissions of each access token and counts the decision requests
+type fakeAuthorizationServer struct {
+	server    *httptest.Server
+	grants    map[string][]string
+	resources map[string]gocloak.ResourceRepresentation
+
+	lock        sync.Mutex
+	decisions   int
+	permissions []string
+}
+
+func newFakeAuthorizationServer(t *testing.T) *fakeAuthorizationServer {
+	f := &fakeAuthorizationServer{
+		grants: map[string][]string{
+			"alice":   {"orders#view", "admin-id"},
+			"mallory": {"orders#view"},
+		},
+		resources: map[string]gocloak.ResourceRepresentation{
+			"orders-id": {
+				ID:             gocloak.StringP("orders-id"),
+				Name:           gocloak.StringP("orders"),
+				ResourceScopes: &[]gocloak.ScopeRepresentation{{Name: gocloak.StringP("view")}, {Name: gocloak.StringP("delete")}},
+			},
+			"admin-id": {
+				ID:             gocloak.StringP("admin-id"),
+				Name:           gocloak.StringP("admin"),
+				URIs:           &[]string{"/admin/*"},
+				ResourceScopes: &[]gocloak.ScopeRepresentation{{Name: gocloak.StringP("manage")}},
+			},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/test/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.PostForm.Get("grant_type") == "client_credentials" {
+			if clientID, clientSecret, ok := r.BasicAuth(); !ok || clientID != "rs" || clientSecret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "invalid_client"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "pat", ExpiresIn: 300})
+			return
+		}
+
+		if r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:uma-ticket" ||
+			r.PostForm.Get("response_mode") != "decision" || r.PostForm.Get("audience") != "rs" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "invalid_request"})
+			return
+		}
+		permission := r.PostForm.Get("permission")
+		accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		f.lock.Lock()
+		f.decisions++
+		f.permissions = append(f.permissions, permission)
+		f.lock.Unlock()
+
+		if accessToken == "invalid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "invalid_grant"})
+			return
+		}
+		grants := f.grants[accessToken]
+		if len(grants) == 0 {
+			grants = f.grants["alice"]
+		}
+		for _, grant := range grants {
+			if grant == permission {
+				_ = json.NewEncoder(w).Encode(gocloak.RequestingPartyPermissionDecision{Result: gocloak.BoolP(true)})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(gocloak.HTTPErrorResponse{Error: "access_denied", Description: "not_authorized"})
+	})
+	mux.HandleFunc("/realms/test/authz/protection/resource_set", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var ids []string
+		for id, resource := range f.resources {
+			if name := r.URL.Query().Get("name"); name == "" || name == *resource.Name {
+				ids = append(ids, id)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ids)
+	})
+	mux.HandleFunc("/realms/test/authz/protection/resource_set/", func(w http.ResponseWriter, r *http.Request) {
+		resource := f.resources[strings.TrimPrefix(r.URL.Path, "/realms/test/authz/protection/resource_set/")]
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resource)
+	})
+	mux.HandleFunc("/realms/test/authz/protection/permission", func(w http.ResponseWriter, r *http.Request) {
+		var permissions []gocloak.CreatePermissionTicketParams
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&permissions); err != nil || len(permissions) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ticket := *permissions[0].ResourceID + ":" + strings.Join(*permissions[0].ResourceScopes, ",")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.PermissionTicketResponseRepresentation{Ticket: &ticket})
+	})
+	mux.HandleFunc("/realms/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.IssuerResponse{
+			TokenService: gocloak.StringP(f.server.URL + "/realms/test/protocol/openid-connect"),
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeAuthorizationServer) decisionCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.decisions
+}
+
+func (f *fakeAuthorizationServer) lastPermission() string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.permissions[len(f.permissions)-1]
+}
+
+var ordersPath = enforcer.Path{
+	Pattern:  "/orders/{id}",
+	Resource: "orders",
+	Methods: map[string][]string{
+		http.MethodGet:    {"view"},
+		http.MethodDelete: {"delete"},
+	},
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	client := gocloak.NewClient(server.server.URL)
+	umaHeader := `UMA realm="test", as_uri="` + server.server.URL + `/realms/test", ticket="orders-id:delete"`
+
+	testCases := []struct {
+		Name            string
+		Options         []func(e *enforcer.PolicyEnforcer)
+		Method          string
+		Path            string
+		AccessToken     string
+		Status          int
+		WWWAuthenticate string
+	}{
+		{
+			Name:        "granted",
+			Method:      http.MethodGet,
+			Path:        "/orders/1",
+			AccessToken: "alice",
+			Status:      http.StatusOK,
+		},
+		{
+			Name:            "denied",
+			Method:          http.MethodDelete,
+			Path:            "/orders/1",
+			AccessToken:     "alice",
+			Status:          http.StatusForbidden,
+			WWWAuthenticate: umaHeader,
+		},
+		{
+			Name:            "no access token",
+			Method:          http.MethodDelete,
+			Path:            "/orders/1",
+			Status:          http.StatusUnauthorized,
+			WWWAuthenticate: umaHeader,
+		},
+		{
+			Name:            "invalid access token",
+			Method:          http.MethodDelete,
+			Path:            "/orders/1",
+			AccessToken:     "invalid",
+			Status:          http.StatusUnauthorized,
+			WWWAuthenticate: umaHeader,
+		},
+		{
+			Name:        "method not mapped",
+			Method:      http.MethodPost,
+			Path:        "/orders/1",
+			AccessToken: "alice",
+			Status:      http.StatusForbidden,
+		},
+		{
+			Name:        "path not mapped",
+			Method:      http.MethodGet,
+			Path:        "/customers/1",
+			AccessToken: "alice",
+			Status:      http.StatusForbidden,
+		},
+		{
+			Name:        "path not mapped in permissive mode",
+			Options:     []func(e *enforcer.PolicyEnforcer){enforcer.SetPermissive()},
+			Method:      http.MethodGet,
+			Path:        "/customers/1",
+			AccessToken: "alice",
+			Status:      http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			options := append([]func(e *enforcer.PolicyEnforcer){enforcer.SetPaths(ordersPath)}, testCase.Options...)
+			handler := enforcer.New(client, "test", "rs", "secret", options...).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(testCase.Method, testCase.Path, nil)
+			if testCase.AccessToken != "" {
+				req.Header.Set("Authorization", "Bearer "+testCase.AccessToken)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, testCase.Status, rec.Code)
+			require.Equal(t, testCase.WWWAuthenticate, rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestHandler_PathNormalization(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	handler := enforcer.New(gocloak.NewClient(server.server.URL), "test", "rs", "secret", enforcer.SetPaths(
+		enforcer.Path{Pattern: "/admin", Resource: "admin"},
+		enforcer.Path{Pattern: "/api/admin/*", Resource: "admin"},
+	), enforcer.SetPermissive()).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := map[string]int{
+		"/admin":              http.StatusForbidden,
+		"/admin/":             http.StatusForbidden,
+		"//admin":             http.StatusForbidden,
+		"/api/./admin":        http.StatusForbidden,
+		"/api//admin/users":   http.StatusForbidden,
+		"/api/admin/":         http.StatusForbidden,
+		"/public/../admin":    http.StatusForbidden,
+		"/admins":             http.StatusOK,
+		"/api/administration": http.StatusOK,
+	}
+	for path, status := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		req.Header.Set("Authorization", "Bearer mallory")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, status, rec.Code, path)
+	}
+}
+
+func TestEnforce_MostSpecificPath(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	for _, name := range []string{"all-orders", "export"} {
+		server.resources[name+"-id"] = gocloak.ResourceRepresentation{
+			ID:             gocloak.StringP(name + "-id"),
+			Name:           gocloak.StringP(name),
+			ResourceScopes: &[]gocloak.ScopeRepresentation{{Name: gocloak.StringP("view")}},
+		}
+	}
+	e := enforcer.New(gocloak.NewClient(server.server.URL), "test", "rs", "secret", enforcer.SetPaths(
+		enforcer.Path{Pattern: "/orders/*", Resource: "all-orders"},
+		ordersPath,
+		enforcer.Path{Pattern: "/orders/export", Resource: "export"},
+	), enforcer.SetDecisionCacheTTL(0))
+	ctx := context.Background()
+
+	testCases := map[string]string{
+		"/orders":          "all-orders",
+		"/orders/1/items":  "all-orders",
+		"/orders/1":        "orders#view",
+		"/orders/export":   "export",
+		"/orders/1/export": "all-orders",
+	}
+	for path, permission := range testCases {
+		_, err := e.Enforce(ctx, "bob", http.MethodGet, path)
+		require.NoError(t, err)
+		require.Equal(t, permission, server.lastPermission(), path)
+	}
+}
+
+func TestEnforce_ResourceLookup(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	server.resources["other-orders-id"] = gocloak.ResourceRepresentation{
+		ID:   gocloak.StringP("other-orders-id"),
+		Name: gocloak.StringP("orders"),
+	}
+	client := gocloak.NewClient(server.server.URL)
+	ctx := context.Background()
+
+	testCases := []struct {
+		Name   string
+		Path   enforcer.Path
+		Ticket string
+	}{
+		{
+			Name:   "by id",
+			Path:   enforcer.Path{Pattern: "/orders/{id}", ResourceID: "orders-id"},
+			Ticket: "orders-id:view,delete",
+		},
+		{
+			Name:   "by id with ambiguous name",
+			Path:   enforcer.Path{Pattern: "/orders/{id}", Resource: "orders", ResourceID: "orders-id"},
+			Ticket: "orders-id:view,delete",
+		},
+		{
+			Name: "by ambiguous name",
+			Path: enforcer.Path{Pattern: "/orders/{id}", Resource: "orders"},
+		},
+		{
+			Name: "by unknown name",
+			Path: enforcer.Path{Pattern: "/orders/{id}", Resource: "unknown"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		e := enforcer.New(client, "test", "rs", "secret", enforcer.SetPaths(testCase.Path))
+
+		decision, err := e.Enforce(ctx, "", http.MethodGet, "/orders/1")
+		if testCase.Ticket == "" {
+			require.Error(t, err, testCase.Name)
+			continue
+		}
+		require.NoError(t, err, testCase.Name)
+		require.Equal(t, testCase.Ticket, decision.Ticket, testCase.Name)
+	}
+}
+
+func TestEnforce_DecisionCache(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	client := gocloak.NewClient(server.server.URL)
+	ctx := context.Background()
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Second)),
+	}).SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name        string
+		Options     []func(e *enforcer.PolicyEnforcer)
+		AccessToken string
+		Decisions   int
+	}{
+		{
+			Name:        "cached",
+			AccessToken: "alice",
+			Decisions:   1,
+		},
+		{
+			Name:        "cache disabled",
+			Options:     []func(e *enforcer.PolicyEnforcer){enforcer.SetDecisionCacheTTL(0)},
+			AccessToken: "alice",
+			Decisions:   2,
+		},
+		{
+			Name:        "not cached beyond token expiry",
+			AccessToken: expired,
+			Decisions:   2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		options := append([]func(e *enforcer.PolicyEnforcer){enforcer.SetPaths(ordersPath)}, testCase.Options...)
+		e := enforcer.New(client, "test", "rs", "secret", options...)
+
+		before := server.decisionCount()
+		for i := 0; i < 2; i++ {
+			decision, err := e.Enforce(ctx, testCase.AccessToken, http.MethodGet, "/orders/1")
+			require.NoError(t, err, testCase.Name)
+			require.True(t, decision.Allowed, testCase.Name)
+		}
+		require.Equal(t, testCase.Decisions, server.decisionCount()-before, testCase.Name)
+	}
+}
+
+func TestLoadPaths(t *testing.T) {
+	t.Parallel()
+	server := newFakeAuthorizationServer(t)
+	e := enforcer.New(gocloak.NewClient(server.server.URL), "test", "rs", "secret")
+	ctx := context.Background()
+
+	decision, err := e.Enforce(ctx, "alice", http.MethodPost, "/admin/users")
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+
+	require.NoError(t, e.LoadPaths(ctx))
+
+	decision, err = e.Enforce(ctx, "alice", http.MethodPost, "/admin/users")
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, "admin-id", server.lastPermission())
+
+	decision, err = e.Enforce(ctx, "", http.MethodGet, "/admin/users")
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.True(t, decision.Unauthenticated)
+	require.Equal(t, "admin-id:manage", decision.Ticket)
+}
@@ -0,0 +1,214 @@
+package enforcer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// Path maps requests to a protected resource of the resource server.
+//
+// Pattern is matched against the cleaned request path. It is either an exact path, a path ending with /*
+// matching all sub paths, or a path with {placeholder} segments matching any single segment.
+// Methods maps HTTP methods to the scopes required for them. Without methods any permission
+// for the resource grants access, otherwise requests with a method not listed are denied.
+// The resource is identified by ResourceID if set, otherwise by Resource, which must name exactly one resource.
+type Path struct {
+	Pattern    string
+	Resource   string
+	ResourceID string
+	Methods    map[string][]string
+}
+
+// requiredScopes returns the scopes required for the method and whether the method is allowed at all
+func (p *Path) requiredScopes(method string) ([]string, bool) {
+	if len(p.Methods) == 0 {
+		return nil, true
+	}
+
+	for m, scopes := range p.Methods {
+		if strings.EqualFold(m, method) {
+			return scopes, true
+		}
+	}
+
+	return nil, false
+}
+
+// permission returns the permission of the resource and scopes in the format resource#scope1,scope2
+func (p *Path) permission(scopes []string) string {
+	resource := p.Resource
+	if p.ResourceID != "" {
+		resource = p.ResourceID
+	}
+	if len(scopes) == 0 {
+		return resource
+	}
+
+	return resource + "#" + strings.Join(scopes, ",")
+}
+
+// cleanPath returns the shortest rooted path equivalent to p, without a trailing slash
+func cleanPath(p string) string {
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	return path.Clean(p)
+}
+
+// match reports whether the cleaned request path matches the pattern and how specific the match is.
+// The pattern is cleaned as well, a trailing /* still matches the path itself and all sub paths.
+func (p *Path) match(requestPath string) (int, bool) {
+	if prefix, ok := strings.CutSuffix(p.Pattern, "/*"); ok {
+		prefix = strings.TrimSuffix(cleanPath(prefix), "/")
+		if requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/") {
+			return len(prefix), true
+		}
+		return 0, false
+	}
+
+	pattern := cleanPath(p.Pattern)
+	if pattern == requestPath {
+		return len(pattern) + 1, true
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	requestSegments := strings.Split(requestPath, "/")
+	if len(patternSegments) != len(requestSegments) {
+		return 0, false
+	}
+
+	specificity := 0
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != requestSegments[i] {
+			return 0, false
+		}
+		specificity += len(segment) + 1
+	}
+
+	return specificity, true
+}
+
+// matchPath returns the most specific path matching the request path or nil
+func (e *PolicyEnforcer) matchPath(requestPath string) *Path {
+	e.pathsLock.RLock()
+	defer e.pathsLock.RUnlock()
+
+	var matched *Path
+	best := -1
+	for _, paths := range [][]Path{e.paths, e.loadedPaths} {
+		for i := range paths {
+			if specificity, ok := paths[i].match(requestPath); ok && specificity > best {
+				matched = &paths[i]
+				best = specificity
+			}
+		}
+	}
+
+	return matched
+}
+
+// LoadPaths adds a path for every URI of the resources of the resource server, replacing previously loaded paths.
+// Paths configured with SetPaths take precedence over loaded paths with the same pattern.
+func (e *PolicyEnforcer) LoadPaths(ctx context.Context) error {
+	pat, err := e.protectionToken.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	resources, err := e.client.GetResourcesClient(ctx, pat, e.realm, gocloak.GetResourceParams{Max: gocloak.IntP(-1)})
+	if err != nil {
+		return err
+	}
+
+	var paths []Path
+	for _, resource := range resources {
+		e.storeResource(resource)
+		for _, uri := range gocloak.PStringSlice(resource.URIs) {
+			paths = append(paths, Path{
+				Pattern:    uri,
+				Resource:   gocloak.PString(resource.Name),
+				ResourceID: gocloak.PString(resource.ID),
+			})
+		}
+	}
+
+	e.pathsLock.Lock()
+	defer e.pathsLock.Unlock()
+
+	e.loadedPaths = paths
+
+	return nil
+}
+
+// resourceInfo holds what is needed to create permission tickets for a resource
+type resourceInfo struct {
+	id     string
+	scopes []string
+}
+
+func (e *PolicyEnforcer) storeResource(resource *gocloak.ResourceRepresentation) resourceInfo {
+	info := resourceInfo{id: gocloak.PString(resource.ID)}
+	if resource.ResourceScopes != nil {
+		for _, scope := range *resource.ResourceScopes {
+			info.scopes = append(info.scopes, gocloak.PString(scope.Name))
+		}
+	}
+
+	e.resourcesLock.Lock()
+	defer e.resourcesLock.Unlock()
+
+	e.resources[info.id] = info
+	e.resourceIDs[gocloak.PString(resource.Name)] = info.id
+
+	return info
+}
+
+// cachedResource returns the cached resource with the id, or with the name if id is empty
+func (e *PolicyEnforcer) cachedResource(id, name string) (resourceInfo, bool) {
+	e.resourcesLock.Lock()
+	defer e.resourcesLock.Unlock()
+
+	if id == "" {
+		id = e.resourceIDs[name]
+	}
+	info, ok := e.resources[id]
+
+	return info, ok
+}
+
+// resource returns the id and scopes of the resource of the path.
+// On first use the resource is looked up by ResourceID if set, otherwise by its unique name.
+func (e *PolicyEnforcer) resource(ctx context.Context, pat string, path *Path) (resourceInfo, error) {
+	if info, ok := e.cachedResource(path.ResourceID, path.Resource); ok {
+		return info, nil
+	}
+
+	if path.ResourceID != "" {
+		resource, err := e.client.GetResourceClient(ctx, pat, e.realm, path.ResourceID)
+		if err != nil {
+			return resourceInfo{}, err
+		}
+		return e.storeResource(resource), nil
+	}
+
+	resources, err := e.client.GetResourcesClient(ctx, pat, e.realm, gocloak.GetResourceParams{
+		Name:      gocloak.StringP(path.Resource),
+		ExactName: gocloak.BoolP(true),
+	})
+	if err != nil {
+		return resourceInfo{}, err
+	}
+	if len(resources) != 1 {
+		return resourceInfo{}, fmt.Errorf("found %d resources named %q instead of one", len(resources), path.Resource)
+	}
+
+	return e.storeResource(resources[0]), nil
+}
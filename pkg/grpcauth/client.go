@@ -0,0 +1,79 @@
+package grpcauth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// UnaryClientInterceptor returns an interceptor sending the access token of the token source with every unary call.
+// If the server rejects a call as unauthenticated, the sent token is invalidated in the token source.
+func UnaryClientInterceptor(tokenSource *gocloak.TokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, accessToken, err := withAccessToken(ctx, tokenSource)
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) == codes.Unauthenticated {
+			tokenSource.InvalidateToken(accessToken)
+		}
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor sending the access token of the token source with every streaming call.
+// If the server rejects the stream as unauthenticated, when it is opened or when a message is received,
+// the sent token is invalidated in the token source.
+func StreamClientInterceptor(tokenSource *gocloak.TokenSource) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, accessToken, err := withAccessToken(ctx, tokenSource)
+		if err != nil {
+			return nil, err
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			if status.Code(err) == codes.Unauthenticated {
+				tokenSource.InvalidateToken(accessToken)
+			}
+			return nil, err
+		}
+
+		return &authenticatedClientStream{ClientStream: stream, tokenSource: tokenSource, accessToken: accessToken}, nil
+	}
+}
+
+// authenticatedClientStream invalidates the sent token if the server ends the stream as unauthenticated
+type authenticatedClientStream struct {
+	grpc.ClientStream
+	tokenSource *gocloak.TokenSource
+	accessToken string
+}
+
+// RecvMsg implements grpc.ClientStream
+func (s *authenticatedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if status.Code(err) == codes.Unauthenticated {
+		s.tokenSource.InvalidateToken(s.accessToken)
+	}
+
+	return err
+}
+
+// withAccessToken adds the access token of the token source to the outgoing metadata and returns the sent token
+func withAccessToken(ctx context.Context, tokenSource *gocloak.TokenSource) (context.Context, string, error) {
+	accessToken, err := tokenSource.AccessToken(ctx)
+	if err != nil {
+		return nil, "", status.Errorf(codes.Unauthenticated, "could not get access token: %v", err)
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+accessToken), accessToken, nil
+}
@@ -0,0 +1,281 @@
+package grpcauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/grpcauth"
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+const (
+	testKeyID   = "test-key"
+	checkMethod = "/grpc.health.v1.Health/Check"
+	watchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+// fakeRealm serves the certs and issuer of the realm and issues tokens with the given realm roles
+type fakeRealm struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	roles  []string
+	logins int32
+}
+
+func newFakeRealm(t *testing.T, roles ...string) *fakeRealm {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	f := &fakeRealm{key: key, roles: roles}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/test/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &[]gocloak.CertResponseKey{{
+			Kid: gocloak.StringP(testKeyID),
+			Kty: gocloak.StringP("RSA"),
+			Alg: gocloak.StringP("RS256"),
+			N:   &n,
+			E:   &e,
+		}}})
+	})
+	mux.HandleFunc("/realms/test/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&f.logins, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: f.sign(t), ExpiresIn: 300})
+	})
+	mux.HandleFunc("/realms/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.IssuerResponse{
+			TokenService: gocloak.StringP(f.server.URL + "/realms/test/protocol/openid-connect"),
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeRealm) sign(t *testing.T) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":          f.server.URL + "/realms/test",
+		"aud":          "backend",
+		"typ":          "Bearer",
+		"sub":          "service-account",
+		"scope":        "health",
+		"iat":          now.Unix(),
+		"exp":          now.Add(5 * time.Minute).Unix(),
+		"realm_access": map[string]interface{}{"roles": f.roles},
+	})
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+// dialHealthServer starts a health server with the authenticator and dials it with the dial options
+func dialHealthServer(t *testing.T, authenticator *grpcauth.Authenticator, options ...grpc.DialOption) healthpb.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamServerInterceptor()),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	options = append(options,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufconn", options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "monitor")
+	client := gocloak.NewClient(realm.server.URL)
+	tokenSource := gocloak.NewClientTokenSource(client, "probe", "secret", "test")
+	withToken := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(grpcauth.UnaryClientInterceptor(tokenSource)),
+		grpc.WithStreamInterceptor(grpcauth.StreamClientInterceptor(tokenSource)),
+	}
+
+	testCases := []struct {
+		Name        string
+		Options     []func(a *grpcauth.Authenticator)
+		DialOptions []grpc.DialOption
+		Code        codes.Code
+		StreamCode  codes.Code
+	}{
+		{
+			Name:        "valid token",
+			Options:     []func(a *grpcauth.Authenticator){grpcauth.SetAudience("backend")},
+			DialOptions: withToken,
+			Code:        codes.OK,
+			StreamCode:  codes.OK,
+		},
+		{
+			Name:       "no token",
+			Code:       codes.Unauthenticated,
+			StreamCode: codes.Unauthenticated,
+		},
+		{
+			Name:        "unexpected audience",
+			Options:     []func(a *grpcauth.Authenticator){grpcauth.SetAudience("billing")},
+			DialOptions: withToken,
+			Code:        codes.Unauthenticated,
+			StreamCode:  codes.Unauthenticated,
+		},
+		{
+			Name: "method policies",
+			Options: []func(a *grpcauth.Authenticator){
+				grpcauth.SetMethodPolicy(checkMethod, jwx.AllOf(jwx.RealmRole("monitor"), jwx.Scope("health"))),
+				grpcauth.SetDefaultPolicy(jwx.RealmRole("admin")),
+			},
+			DialOptions: withToken,
+			Code:        codes.OK,
+			StreamCode:  codes.PermissionDenied,
+		},
+		{
+			Name: "public method",
+			Options: []func(a *grpcauth.Authenticator){
+				grpcauth.SetPublicMethods(checkMethod),
+			},
+			Code:       codes.OK,
+			StreamCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			healthClient := dialHealthServer(t, grpcauth.New(client, "test", testCase.Options...), testCase.DialOptions...)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+			require.Equal(t, testCase.Code, status.Code(err), "check: %v", err)
+
+			stream, err := healthClient.Watch(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, testCase.StreamCode, status.Code(err), "watch: %v", err)
+		})
+	}
+}
+
+func TestStreamClientInterceptor_InvalidatesRejectedToken(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "monitor")
+	client := gocloak.NewClient(realm.server.URL)
+	tokenSource := gocloak.NewClientTokenSource(client, "probe", "secret", "test")
+	healthClient := dialHealthServer(t, grpcauth.New(client, "test", grpcauth.SetAudience("billing")),
+		grpc.WithStreamInterceptor(grpcauth.StreamClientInterceptor(tokenSource)))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the server rejects the stream after it was opened, the token is dropped when the rejection is received
+	for i := 1; i <= 2; i++ {
+		stream, err := healthClient.Watch(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.Unauthenticated, status.Code(err), "watch: %v", err)
+		require.EqualValues(t, i, atomic.LoadInt32(&realm.logins))
+	}
+}
+
+func TestUnaryServerInterceptor_Context(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "monitor")
+	authenticator := grpcauth.New(gocloak.NewClient(realm.server.URL), "test")
+	accessToken := realm.sign(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+accessToken))
+
+	_, err := authenticator.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: checkMethod}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		token, ok := grpcauth.AccessTokenFromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, accessToken, token)
+
+		parsed, ok := grpcauth.TokenFromContext(ctx)
+		require.True(t, ok)
+		require.True(t, parsed.Valid)
+
+		claims, ok := grpcauth.ClaimsFromContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, "service-account", claims.Subject)
+		require.True(t, claims.HasRealmRole("monitor"))
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	_, ok := grpcauth.ClaimsFromContext(ctx)
+	require.False(t, ok)
+}
+
+func TestStreamServerInterceptor_Context(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t)
+	authenticator := grpcauth.New(gocloak.NewClient(realm.server.URL), "test")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+realm.sign(t)))
+
+	err := authenticator.StreamServerInterceptor()(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: watchMethod}, func(_ interface{}, stream grpc.ServerStream) error {
+		claims, ok := grpcauth.ClaimsFromContext(stream.Context())
+		require.True(t, ok)
+		require.Equal(t, "service-account", claims.Subject)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestClientInterceptor_TokenSourceError(t *testing.T) {
+	t.Parallel()
+	tokenSource := gocloak.NewClientTokenSource(gocloak.NewClient("http://127.0.0.1:0"), "probe", "secret", "test")
+
+	err := grpcauth.UnaryClientInterceptor(tokenSource)(context.Background(), checkMethod, nil, nil, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			t.Fatal("the call must not be invoked without a token")
+			return nil
+		})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,177 @@
+// Package grpcauth provides gRPC interceptors authenticating calls with Keycloak access tokens.
+package grpcauth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+// authorizationKey is the metadata key of the access token
+const authorizationKey = "authorization"
+
+// Authenticator validates the access tokens of gRPC calls offline with a gocloak.TokenValidator
+// and authorizes them with per-method policies.
+type Authenticator struct {
+	validator      *gocloak.TokenValidator
+	audiences      []string
+	defaultPolicy  jwx.Policy
+	methodPolicies map[string]jwx.Policy
+	publicMethods  map[string]bool
+}
+
+// New creates a new Authenticator for the given realm.
+// Without further options every call with a valid access token of the realm is accepted.
+func New(client gocloak.GoCloakIface, realm string, options ...func(a *Authenticator)) *Authenticator {
+	a := &Authenticator{
+		methodPolicies: make(map[string]jwx.Policy),
+		publicMethods:  make(map[string]bool),
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	if a.validator == nil {
+		a.validator = gocloak.NewTokenValidator(client, realm, gocloak.SetValidatorAudience(a.audiences...))
+	}
+
+	return a
+}
+
+// ==== Functional Options ===
+
+// SetAudience sets the audiences of which the token must contain at least one
+func SetAudience(audiences ...string) func(a *Authenticator) {
+	return func(a *Authenticator) {
+		a.audiences = audiences
+	}
+}
+
+// SetValidator sets the validator used for the tokens, e.g. to configure the issuer or leeway.
+// The audiences set with SetAudience are ignored in favour of the configuration of the validator.
+func SetValidator(validator *gocloak.TokenValidator) func(a *Authenticator) {
+	return func(a *Authenticator) {
+		a.validator = validator
+	}
+}
+
+// SetMethodPolicy sets the policy the claims must satisfy to call the method,
+// given by its full name like /package.Service/Method, e.g.
+//
+//	SetMethodPolicy("/orders.Orders/Delete", jwx.AllOf(jwx.Scope("orders"), jwx.ClientRole("orders", "admin")))
+func SetMethodPolicy(fullMethod string, policy jwx.Policy) func(a *Authenticator) {
+	return func(a *Authenticator) {
+		a.methodPolicies[fullMethod] = policy
+	}
+}
+
+// SetDefaultPolicy sets the policy for methods without a policy set with SetMethodPolicy
+func SetDefaultPolicy(policy jwx.Policy) func(a *Authenticator) {
+	return func(a *Authenticator) {
+		a.defaultPolicy = policy
+	}
+}
+
+// SetPublicMethods sets methods which can be called without an access token, e.g. health checks
+func SetPublicMethods(fullMethods ...string) func(a *Authenticator) {
+	return func(a *Authenticator) {
+		for _, method := range fullMethods {
+			a.publicMethods[method] = true
+		}
+	}
+}
+
+// UnaryServerInterceptor returns an interceptor authenticating unary calls.
+// The validated token and its claims are available to the handler via TokenFromContext and ClaimsFromContext.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor authenticating streaming calls.
+// The validated token and its claims are available to the handler via the context of the stream.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate validates and authorizes the access token of the call and returns a context holding it.
+// Errors are status errors with the codes Unauthenticated or PermissionDenied.
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if a.publicMethods[fullMethod] {
+		return ctx, nil
+	}
+
+	accessToken := bearerToken(ctx)
+	if accessToken == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+
+	var claims jwx.Claims
+	token, err := a.validator.ValidateCustomClaims(ctx, accessToken, &claims)
+	var validationErr *gocloak.TokenValidationError
+	if errors.As(err, &validationErr) {
+		return nil, status.Error(codes.Unauthenticated, validationErr.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	policy, ok := a.methodPolicies[fullMethod]
+	if !ok {
+		policy = a.defaultPolicy
+	}
+	if policy != nil && !policy.Evaluate(&claims) {
+		return nil, status.Errorf(codes.PermissionDenied, "access to %s denied", fullMethod)
+	}
+
+	return context.WithValue(ctx, contextKey{}, &authentication{
+		accessToken: accessToken,
+		token:       token,
+		claims:      &claims,
+	}), nil
+}
+
+// bearerToken returns the token of the authorization metadata of the incoming call
+func bearerToken(ctx context.Context) string {
+	for _, value := range metadata.ValueFromIncomingContext(ctx, authorizationKey) {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
+
+// authenticatedStream replaces the context of a server stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context holding the validated token
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,56 @@
+package jwx
+
+import "strings"
+
+// HasRealmRole reports whether the user has the given realm role
+func (c *Claims) HasRealmRole(role string) bool {
+	return contains(c.RealmAccess.Roles, role)
+}
+
+// HasClientRole reports whether the user has the given role of the client
+func (c *Claims) HasClientRole(clientID, role string) bool {
+	return contains(c.ResourceAccess[clientID].Roles, role)
+}
+
+// Scopes returns the scopes of the scope claim
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScope reports whether the token was granted the given scope
+func (c *Claims) HasScope(scope string) bool {
+	return contains(c.Scopes(), scope)
+}
+
+// HasAnyScope reports whether the token was granted at least one of the given scopes
+func (c *Claims) HasAnyScope(scopes ...string) bool {
+	granted := c.Scopes()
+	for _, scope := range scopes {
+		if contains(granted, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Groups returns the groups of the user. The groups claim is only present if a group membership
+// mapper is configured, which adds either the group names or the full paths like /parent/child.
+func (c *Claims) Groups() []string {
+	return c.GroupMembership
+}
+
+// HasGroup reports whether the user is member of the given group, matched by name or full path
+func (c *Claims) HasGroup(group string) bool {
+	return contains(c.GroupMembership, group)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package jwx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testClaims = `{
+	"sub": "user-id",
+	"scope": "openid profile orders",
+	"groups": ["/staff/support", "billing"],
+	"realm_access": {"roles": ["user", "offline_access"]},
+	"resource_access": {
+		"orders": {"roles": ["reader"]},
+		"account": {"roles": ["manage-account"]}
+	}
+}`
+
+func decodeTestClaims(t *testing.T) *Claims {
+	var claims Claims
+	require.NoError(t, json.Unmarshal([]byte(testClaims), &claims))
+	return &claims
+}
+
+func TestClaimsHelpers(t *testing.T) {
+	claims := decodeTestClaims(t)
+
+	require.True(t, claims.HasRealmRole("user"))
+	require.False(t, claims.HasRealmRole("admin"))
+
+	require.True(t, claims.HasClientRole("orders", "reader"))
+	require.True(t, claims.HasClientRole("account", "manage-account"))
+	require.False(t, claims.HasClientRole("orders", "writer"))
+	require.False(t, claims.HasClientRole("billing", "reader"))
+	require.Equal(t, []string{"manage-account"}, claims.ResourceAccess["account"].Roles)
+
+	require.Equal(t, []string{"openid", "profile", "orders"}, claims.Scopes())
+	require.True(t, claims.HasScope("orders"))
+	require.True(t, claims.HasAnyScope("billing", "profile"))
+	require.False(t, claims.HasAnyScope("billing", "email"))
+	require.False(t, claims.HasAnyScope())
+
+	require.Equal(t, []string{"/staff/support", "billing"}, claims.Groups())
+	require.True(t, claims.HasGroup("/staff/support"))
+	require.False(t, claims.HasGroup("support"))
+}
+
+func TestPolicy(t *testing.T) {
+	claims := decodeTestClaims(t)
+
+	testCases := []struct {
+		Name     string
+		Policy   Policy
+		Expected bool
+	}{
+		{"realm role", RealmRole("user"), true},
+		{"missing realm role", RealmRole("admin"), false},
+		{"client role", ClientRole("orders", "reader"), true},
+		{"scope", Scope("profile"), true},
+		{"group", Group("billing"), true},
+		{"all of", AllOf(RealmRole("user"), Scope("orders")), true},
+		{"not all of", AllOf(RealmRole("user"), Scope("email")), false},
+		{"empty all of", AllOf(), true},
+		{"any of", AnyOf(RealmRole("admin"), ClientRole("orders", "reader")), true},
+		{"none of", AnyOf(RealmRole("admin"), Group("staff")), false},
+		{"empty any of", AnyOf(), false},
+		{"not", Not(RealmRole("admin")), true},
+		{
+			"nested",
+			AnyOf(RealmRole("admin"), AllOf(ClientRole("orders", "reader"), Not(Group("blocked")))),
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			require.Equal(t, testCase.Expected, testCase.Policy.Evaluate(claims))
+		})
+	}
+
+	require.False(t, RealmRole("user").Evaluate(nil))
+}
@@ -3,6 +3,7 @@ package jwx
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"encoding/base64"
@@ -81,6 +82,24 @@ func decodeECDSAPublicKey(x, y, crv *string) (*ecdsa.PublicKey, error) {
 	return &ecdsa.PublicKey{X: xInt, Y: yInt, Curve: c}, nil
 }
 
+func decodeEdDSAPublicKey(x, crv *string) (ed25519.PublicKey, error) {
+	const errMessage = "could not decode public key"
+
+	if crv == nil || *crv != "Ed25519" {
+		return nil, errors.Wrap(fmt.Errorf("unsupported curve: %v", crv), errMessage)
+	}
+
+	decX, err := base64.RawURLEncoding.DecodeString(*x)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	if len(decX) != ed25519.PublicKeySize {
+		return nil, errors.Wrap(fmt.Errorf("invalid key size: %d", len(decX)), errMessage)
+	}
+
+	return ed25519.PublicKey(decX), nil
+}
+
 func decodeRSAPublicKey(e, n *string) (*rsa.PublicKey, error) {
 	const errMessage = "could not decode public key"
 
@@ -114,7 +133,8 @@ func decodeRSAPublicKey(e, n *string) (*rsa.PublicKey, error) {
 }
 
 // DecodeAccessTokenRSACustomClaims decodes string access token into jwt.Token
-func DecodeAccessTokenRSACustomClaims(accessToken string, e, n *string, customClaims jwt.Claims) (*jwt.Token, error) {
+// The given parser options are passed on to jwt.ParseWithClaims.
+func DecodeAccessTokenRSACustomClaims(accessToken string, e, n *string, customClaims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
 	const errMessage = "could not decode accessToken with custom claims"
 	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
 
@@ -129,7 +149,86 @@ func DecodeAccessTokenRSACustomClaims(accessToken string, e, n *string, customCl
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return rsaPublicKey, nil
-	})
+	}, opts...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	return token2, nil
+}
+
+// DecodeAccessTokenRSAPSSCustomClaims decodes string access token signed with RSA-PSS into jwt.Token
+// The given parser options are passed on to jwt.ParseWithClaims.
+func DecodeAccessTokenRSAPSSCustomClaims(accessToken string, e, n *string, customClaims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	const errMessage = "could not decode accessToken with custom claims"
+	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
+
+	rsaPublicKey, err := decodeRSAPublicKey(e, n)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	token2, err := jwt.ParseWithClaims(accessToken, customClaims, func(token *jwt.Token) (interface{}, error) {
+		// Don't forget to validate the alg is what you expect:
+		if _, ok := token.Method.(*jwt.SigningMethodRSAPSS); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return rsaPublicKey, nil
+	}, opts...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	return token2, nil
+}
+
+// DecodeAccessTokenEdDSACustomClaims decodes string access token signed with EdDSA into jwt.Token
+// The given parser options are passed on to jwt.ParseWithClaims.
+func DecodeAccessTokenEdDSACustomClaims(accessToken string, x, crv *string, customClaims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	const errMessage = "could not decode accessToken"
+	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
+
+	publicKey, err := decodeEdDSAPublicKey(x, crv)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	token2, err := jwt.ParseWithClaims(accessToken, customClaims, func(token *jwt.Token) (interface{}, error) {
+		// Don't forget to validate the alg is what you expect:
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return publicKey, nil
+	}, opts...)
+
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+	return token2, nil
+}
+
+// DecodeAccessTokenHMACCustomClaims decodes string access token signed with HMAC into jwt.Token
+// k is the base64url encoded secret as found in the "k" member of an oct JWK.
+// The given parser options are passed on to jwt.ParseWithClaims.
+func DecodeAccessTokenHMACCustomClaims(accessToken string, k *string, customClaims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	const errMessage = "could not decode accessToken"
+	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
+
+	if k == nil {
+		return nil, errors.Wrap(errors.New("missing secret"), errMessage)
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(*k)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	token2, err := jwt.ParseWithClaims(accessToken, customClaims, func(token *jwt.Token) (interface{}, error) {
+		// Don't forget to validate the alg is what you expect:
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	}, opts...)
 
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
@@ -138,7 +237,8 @@ func DecodeAccessTokenRSACustomClaims(accessToken string, e, n *string, customCl
 }
 
 // DecodeAccessTokenECDSACustomClaims decodes string access token into jwt.Token
-func DecodeAccessTokenECDSACustomClaims(accessToken string, x, y, crv *string, customClaims jwt.Claims) (*jwt.Token, error) {
+// The given parser options are passed on to jwt.ParseWithClaims.
+func DecodeAccessTokenECDSACustomClaims(accessToken string, x, y, crv *string, customClaims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
 	const errMessage = "could not decode accessToken"
 	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
 
@@ -153,10 +253,57 @@ func DecodeAccessTokenECDSACustomClaims(accessToken string, x, y, crv *string, c
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return publicKey, nil
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, errors.Wrap(err, errMessage)
 	}
 	return token2, nil
 }
+
+var (
+	algKeyTypes = map[string]string{
+		"RS256": "RSA",
+		"RS384": "RSA",
+		"RS512": "RSA",
+		"PS256": "RSA",
+		"PS384": "RSA",
+		"PS512": "RSA",
+		"ES256": "EC",
+		"ES384": "EC",
+		"ES512": "EC",
+		"EdDSA": "OKP",
+		"HS256": "oct",
+		"HS384": "oct",
+		"HS512": "oct",
+	}
+	algCurves = map[string]string{
+		"ES256": "P-256",
+		"ES384": "P-384",
+		"ES512": "P-521",
+		"EdDSA": "Ed25519",
+	}
+)
+
+// CheckKeyAlgorithm verifies that a JWK with the given key type and curve may be used to verify tokens signed with alg.
+// keyAlg is the optional "alg" member of the JWK, which has to match alg if set.
+func CheckKeyAlgorithm(alg, keyAlg, kty, crv string) error {
+	expectedKty, ok := algKeyTypes[alg]
+	if !ok {
+		return fmt.Errorf("unsupported algorithm: %s", alg)
+	}
+
+	if keyAlg != "" && keyAlg != alg {
+		return fmt.Errorf("key is meant for algorithm %s, token is signed with %s", keyAlg, alg)
+	}
+
+	if kty != expectedKty {
+		return fmt.Errorf("algorithm %s requires key type %s, got %s", alg, expectedKty, kty)
+	}
+
+	if expectedCrv, ok := algCurves[alg]; ok && crv != expectedCrv {
+		return fmt.Errorf("algorithm %s requires curve %s, got %s", alg, expectedCrv, crv)
+	}
+
+	return nil
+}
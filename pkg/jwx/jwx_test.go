@@ -2,10 +2,13 @@ package jwx
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"encoding/base64"
 	"log"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -64,3 +67,123 @@ func TestDecodeAccessTokenECDSACustomClaims(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeAccessTokenRSAPSSCustomClaims(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, method := range []*jwt.SigningMethodRSAPSS{jwt.SigningMethodPS256, jwt.SigningMethodPS384, jwt.SigningMethodPS512} {
+		t.Run(method.Alg(), func(t *testing.T) {
+			token, err := SignClaims(claims, pk, method)
+			require.NoError(t, err)
+
+			testClaims := jwt.MapClaims{}
+			e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes())
+			n := base64.RawURLEncoding.EncodeToString(pk.N.Bytes())
+			_, err = DecodeAccessTokenRSAPSSCustomClaims(token, &e, &n, testClaims)
+			require.NoError(t, err)
+			require.Equal(t, claims, testClaims)
+
+			// PKCS#1 v1.5 signatures must not be accepted
+			token, err = SignClaims(claims, pk, jwt.SigningMethodRS256)
+			require.NoError(t, err)
+			_, err = DecodeAccessTokenRSAPSSCustomClaims(token, &e, &n, jwt.MapClaims{})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeAccessTokenEdDSACustomClaims(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	token, err := SignClaims(claims, priv, jwt.SigningMethodEdDSA)
+	require.NoError(t, err)
+
+	testClaims := jwt.MapClaims{}
+	x := base64.RawURLEncoding.EncodeToString(pub)
+	crv := "Ed25519"
+	_, err = DecodeAccessTokenEdDSACustomClaims(token, &x, &crv, testClaims)
+	require.NoError(t, err)
+	require.Equal(t, claims, testClaims)
+
+	crv = "Ed448"
+	_, err = DecodeAccessTokenEdDSACustomClaims(token, &x, &crv, jwt.MapClaims{})
+	require.Error(t, err)
+}
+
+func TestDecodeAccessTokenHMACCustomClaims(t *testing.T) {
+	secret := []byte("a-very-secret-key-of-sufficient-length")
+	token, err := SignClaims(claims, secret, jwt.SigningMethodHS256)
+	require.NoError(t, err)
+
+	testClaims := jwt.MapClaims{}
+	k := base64.RawURLEncoding.EncodeToString(secret)
+	_, err = DecodeAccessTokenHMACCustomClaims(token, &k, testClaims)
+	require.NoError(t, err)
+	require.Equal(t, claims, testClaims)
+
+	k = base64.RawURLEncoding.EncodeToString([]byte("wrong"))
+	_, err = DecodeAccessTokenHMACCustomClaims(token, &k, jwt.MapClaims{})
+	require.Error(t, err)
+}
+
+func TestCheckKeyAlgorithm(t *testing.T) {
+	tests := []struct {
+		alg, keyAlg, kty, crv string
+		valid                 bool
+	}{
+		{alg: "RS256", kty: "RSA", valid: true},
+		{alg: "PS512", keyAlg: "PS512", kty: "RSA", valid: true},
+		{alg: "ES384", kty: "EC", crv: "P-384", valid: true},
+		{alg: "EdDSA", kty: "OKP", crv: "Ed25519", valid: true},
+		{alg: "HS256", kty: "oct", valid: true},
+		{alg: "RS256", keyAlg: "PS256", kty: "RSA"},
+		{alg: "HS256", kty: "RSA"},
+		{alg: "ES256", kty: "EC", crv: "P-384"},
+		{alg: "EdDSA", kty: "OKP", crv: "Ed448"},
+		{alg: "none", kty: "oct"},
+	}
+
+	for _, tc := range tests {
+		err := CheckKeyAlgorithm(tc.alg, tc.keyAlg, tc.kty, tc.crv)
+		if tc.valid {
+			require.NoError(t, err, "%+v", tc)
+		} else {
+			require.Error(t, err, "%+v", tc)
+		}
+	}
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// example of RFC 7638 section 3.1
+	jwk := &JWK{
+		Kty: "RSA",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+	}
+	thumbprint, err := jwk.Thumbprint()
+	require.NoError(t, err)
+	require.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+}
+
+func TestPublicJWK(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, key := range []interface{}{&rsaKey.PublicKey, &ecKey.PublicKey, edKey} {
+		jwk, err := PublicJWK(key)
+		require.NoError(t, err)
+
+		decoded, err := jwk.PublicKey()
+		require.NoError(t, err)
+		require.Equal(t, key, decoded)
+	}
+
+	_, err = PublicJWK("not a key")
+	require.Error(t, err)
+}
@@ -31,6 +31,7 @@ type Claims struct {
 	ClientID          string         `json:"clientId,omitempty"`
 	ClientHost        string         `json:"clientHost,omitempty"`
 	ClientIP          string         `json:"clientAddress,omitempty"`
+	GroupMembership   []string       `json:"groups,omitempty"`
 }
 
 // Address TODO what fields does any address have?
@@ -42,18 +43,20 @@ type RealmAccess struct {
 	Roles []string `json:"roles,omitempty"`
 }
 
-// ResourceAccess holds TODO: What does it hold?
-type ResourceAccess struct {
-	RealmManagement RealmManagement `json:"realm-management,omitempty"`
-	Account         Account         `json:"account,omitempty"`
-}
+// ResourceAccess holds the roles of the user per client ID
+type ResourceAccess map[string]ClientAccess
 
-// RealmManagement holds TODO: What does it hold?
-type RealmManagement struct {
+// ClientAccess holds the roles of the user for a client
+type ClientAccess struct {
 	Roles []string `json:"roles,omitempty"`
 }
 
-// Account holds TODO: What does it hold?
-type Account struct {
-	Roles []string `json:"roles,omitempty"`
-}
+// RealmManagement holds the roles of the user for the realm-management client
+//
+// Deprecated: use ResourceAccess["realm-management"]
+type RealmManagement = ClientAccess
+
+// Account holds the roles of the user for the account client
+//
+// Deprecated: use ResourceAccess["account"]
+type Account = ClientAccess
@@ -0,0 +1,77 @@
+package jwx
+
+// Policy decides whether decoded claims are authorized.
+// Policies are composed with AllOf, AnyOf and Not, e.g.
+//
+//	AnyOf(RealmRole("admin"), AllOf(ClientRole("orders", "reader"), Scope("orders")))
+type Policy func(claims *Claims) bool
+
+// Evaluate reports whether the claims satisfy the policy. Nil claims never do.
+func (p Policy) Evaluate(claims *Claims) bool {
+	if claims == nil {
+		return false
+	}
+
+	return p(claims)
+}
+
+// RealmRole requires the given realm role
+func RealmRole(role string) Policy {
+	return func(claims *Claims) bool {
+		return claims.HasRealmRole(role)
+	}
+}
+
+// ClientRole requires the given role of the client
+func ClientRole(clientID, role string) Policy {
+	return func(claims *Claims) bool {
+		return claims.HasClientRole(clientID, role)
+	}
+}
+
+// Scope requires the given scope
+func Scope(scope string) Policy {
+	return func(claims *Claims) bool {
+		return claims.HasScope(scope)
+	}
+}
+
+// Group requires membership of the given group
+func Group(group string) Policy {
+	return func(claims *Claims) bool {
+		return claims.HasGroup(group)
+	}
+}
+
+// AllOf requires all of the policies. Without policies it is always satisfied.
+func AllOf(policies ...Policy) Policy {
+	return func(claims *Claims) bool {
+		for _, policy := range policies {
+			if !policy(claims) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// AnyOf requires at least one of the policies. Without policies it is never satisfied.
+func AnyOf(policies ...Policy) Policy {
+	return func(claims *Claims) bool {
+		for _, policy := range policies {
+			if policy(claims) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not requires the policy not to be satisfied
+func Not(policy Policy) Policy {
+	return func(claims *Claims) bool {
+		return !policy(claims)
+	}
+}
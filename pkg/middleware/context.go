@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+type contextKey struct{}
+
+// authentication is stored in the request context once the token is validated
+type authentication struct {
+	accessToken string
+	token       *jwt.Token
+	claims      *jwx.Claims
+}
+
+func fromContext(ctx context.Context) (*authentication, bool) {
+	auth, ok := ctx.Value(contextKey{}).(*authentication)
+	return auth, ok
+}
+
+// AccessTokenFromContext returns the raw access token of the request, e.g. to pass it on to other services
+func AccessTokenFromContext(ctx context.Context) (string, bool) {
+	auth, ok := fromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return auth.accessToken, true
+}
+
+// TokenFromContext returns the validated token of the request
+func TokenFromContext(ctx context.Context) (*jwt.Token, bool) {
+	auth, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	return auth.token, true
+}
+
+// ClaimsFromContext returns the claims of the validated token of the request
+func ClaimsFromContext(ctx context.Context) (*jwx.Claims, bool) {
+	auth, ok := fromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	return auth.claims, true
+}
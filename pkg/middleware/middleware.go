@@ -0,0 +1,211 @@
+// Package middleware provides a net/http middleware authenticating requests with Keycloak access tokens.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+const (
+	// ErrorInvalidRequest is returned if the request is malformed, e.g. it uses more than one way to send the token
+	ErrorInvalidRequest = "invalid_request"
+	// ErrorInvalidToken is returned if the access token is expired, revoked, malformed or invalid for other reasons
+	ErrorInvalidToken = "invalid_token"
+	// ErrorInsufficientScope is returned if the access token lacks a required scope or role
+	ErrorInsufficientScope = "insufficient_scope"
+)
+
+// Middleware authenticates requests with bearer tokens (RFC 6750) issued by a realm.
+// Tokens are validated offline with a gocloak.TokenValidator, so only the certs cache of the client is used.
+type Middleware struct {
+	realm       string
+	validator   *gocloak.TokenValidator
+	audiences   []string
+	scopes      []string
+	realmRoles  []string
+	clientRoles map[string][]string
+	policy      jwx.Policy
+}
+
+// New creates a new Middleware for the given realm.
+// Without further options every valid access token of the realm is accepted.
+func New(client gocloak.GoCloakIface, realm string, options ...func(m *Middleware)) *Middleware {
+	m := &Middleware{
+		realm:       realm,
+		clientRoles: make(map[string][]string),
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	if m.validator == nil {
+		m.validator = gocloak.NewTokenValidator(client, realm, gocloak.SetValidatorAudience(m.audiences...))
+	}
+
+	return m
+}
+
+// ==== Functional Options ===
+
+// SetAudience sets the audiences of which the token must contain at least one
+func SetAudience(audiences ...string) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.audiences = audiences
+	}
+}
+
+// SetValidator sets the validator used for the tokens, e.g. to configure the issuer or leeway.
+// The audiences set with SetAudience are ignored in favour of the configuration of the validator.
+func SetValidator(validator *gocloak.TokenValidator) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.validator = validator
+	}
+}
+
+// SetRequiredScopes sets the scopes the token must contain all of
+func SetRequiredScopes(scopes ...string) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.scopes = scopes
+	}
+}
+
+// SetRequiredRealmRoles sets the realm roles the user must have all of
+func SetRequiredRealmRoles(roles ...string) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.realmRoles = roles
+	}
+}
+
+// SetRequiredClientRoles sets the roles of the given client the user must have all of.
+// It can be used once per client.
+func SetRequiredClientRoles(clientID string, roles ...string) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.clientRoles[clientID] = roles
+	}
+}
+
+// SetPolicy sets a policy the claims must satisfy in addition to the required scopes and roles
+func SetPolicy(policy jwx.Policy) func(m *Middleware) {
+	return func(m *Middleware) {
+		m.policy = policy
+	}
+}
+
+// Handler returns a handler which calls next only for requests with a valid access token.
+// Other requests are rejected with a WWW-Authenticate challenge as defined in RFC 6750.
+// The validated token and its claims are available to next via TokenFromContext and ClaimsFromContext.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessToken, errCode := bearerToken(r)
+		if errCode != "" {
+			m.challenge(w, http.StatusBadRequest, errCode, "the access token must be sent in the authorization header")
+			return
+		}
+		if accessToken == "" {
+			m.challenge(w, http.StatusUnauthorized, "", "")
+			return
+		}
+
+		var claims jwx.Claims
+		token, err := m.validator.ValidateCustomClaims(r.Context(), accessToken, &claims)
+		var validationErr *gocloak.TokenValidationError
+		if errors.As(err, &validationErr) {
+			m.challenge(w, http.StatusUnauthorized, ErrorInvalidToken, validationErr.Error())
+			return
+		}
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if description := m.authorize(&claims); description != "" {
+			m.challenge(w, http.StatusForbidden, ErrorInsufficientScope, description)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), contextKey{}, &authentication{
+			accessToken: accessToken,
+			token:       token,
+			claims:      &claims,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authorize returns why the token is insufficient or an empty string if it has all required scopes and roles
+func (m *Middleware) authorize(claims *jwx.Claims) string {
+	for _, scope := range m.scopes {
+		if !claims.HasScope(scope) {
+			return fmt.Sprintf("missing scope %s", scope)
+		}
+	}
+
+	for _, role := range m.realmRoles {
+		if !claims.HasRealmRole(role) {
+			return fmt.Sprintf("missing realm role %s", role)
+		}
+	}
+
+	for clientID, roles := range m.clientRoles {
+		for _, role := range roles {
+			if !claims.HasClientRole(clientID, role) {
+				return fmt.Sprintf("missing role %s of client %s", role, clientID)
+			}
+		}
+	}
+
+	if m.policy != nil && !m.policy.Evaluate(claims) {
+		return "access denied by policy"
+	}
+
+	return ""
+}
+
+// challenge rejects the request with the given status and a WWW-Authenticate header as defined in RFC 6750
+func (m *Middleware) challenge(w http.ResponseWriter, status int, errCode, description string) {
+	params := []string{fmt.Sprintf("realm=%q", quotable(m.realm))}
+	if errCode != "" {
+		params = append(params, fmt.Sprintf("error=%q", errCode))
+	}
+	if description != "" {
+		params = append(params, fmt.Sprintf("error_description=%q", quotable(description)))
+	}
+	if errCode == ErrorInsufficientScope && len(m.scopes) > 0 {
+		params = append(params, fmt.Sprintf("scope=%q", quotable(strings.Join(m.scopes, " "))))
+	}
+
+	w.Header().Set("WWW-Authenticate", "Bearer "+strings.Join(params, ", "))
+	http.Error(w, http.StatusText(status), status)
+}
+
+// bearerToken returns the token of the authorization header.
+// An error code is returned if the token is sent in the query or form as well.
+func bearerToken(r *http.Request) (string, string) {
+	if r.URL.Query().Has("access_token") {
+		return "", ErrorInvalidRequest
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", ""
+	}
+
+	return strings.TrimSpace(token), ""
+}
+
+// quotable removes the characters which are not allowed in the quoted parameters of RFC 6750
+func quotable(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, value)
+}
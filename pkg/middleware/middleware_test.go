@@ -0,0 +1,271 @@
+package middleware_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+	"github.com/Nerzal/gocloak/v13/pkg/middleware"
+)
+
+const testKeyID = "test-key"
+
+type fakeRealm struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+func newFakeRealm(t *testing.T) *fakeRealm {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	f := &fakeRealm{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/test/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &[]gocloak.CertResponseKey{{
+			Kid: gocloak.StringP(testKeyID),
+			Kty: gocloak.StringP("RSA"),
+			Alg: gocloak.StringP("RS256"),
+			N:   &n,
+			E:   &e,
+		}}})
+	})
+	mux.HandleFunc("/realms/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.IssuerResponse{
+			Realm:        gocloak.StringP("test"),
+			TokenService: gocloak.StringP(f.server.URL + "/realms/test/protocol/openid-connect"),
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+func (f *fakeRealm) sign(t *testing.T, modify func(claims jwt.MapClaims)) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   f.server.URL + "/realms/test",
+		"aud":   []string{"account", "backend"},
+		"azp":   "frontend",
+		"typ":   "Bearer",
+		"sub":   "user-id",
+		"scope": "openid profile orders",
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"realm_access": map[string]interface{}{
+			"roles": []string{"user"},
+		},
+		"resource_access": map[string]interface{}{
+			"backend": map[string]interface{}{"roles": []string{"reader"}},
+		},
+	}
+	if modify != nil {
+		modify(claims)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func serve(m *middleware.Middleware, setRequest func(r *http.Request)) *httptest.ResponseRecorder {
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := middleware.ClaimsFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		_, _ = w.Write([]byte(claims.Subject))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	setRequest(req)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t)
+	client := gocloak.NewClient(realm.server.URL)
+
+	testCases := []struct {
+		Name            string
+		Options         []func(m *middleware.Middleware)
+		Authorization   string
+		Status          int
+		WWWAuthenticate string
+	}{
+		{
+			Name:   "valid token",
+			Status: http.StatusOK,
+		},
+		{
+			Name:            "no token",
+			Authorization:   "-",
+			Status:          http.StatusUnauthorized,
+			WWWAuthenticate: `Bearer realm="test"`,
+		},
+		{
+			Name:            "basic auth",
+			Authorization:   "Basic dXNlcjpwYXNz",
+			Status:          http.StatusUnauthorized,
+			WWWAuthenticate: `Bearer realm="test"`,
+		},
+		{
+			Name:    "unexpected audience",
+			Options: []func(m *middleware.Middleware){middleware.SetAudience("billing")},
+			Status:  http.StatusUnauthorized,
+		},
+		{
+			Name:    "expected audience",
+			Options: []func(m *middleware.Middleware){middleware.SetAudience("backend")},
+			Status:  http.StatusOK,
+		},
+		{
+			Name: "required scopes and roles",
+			Options: []func(m *middleware.Middleware){
+				middleware.SetRequiredScopes("orders"),
+				middleware.SetRequiredRealmRoles("user"),
+				middleware.SetRequiredClientRoles("backend", "reader"),
+			},
+			Status: http.StatusOK,
+		},
+		{
+			Name:            "missing scope",
+			Options:         []func(m *middleware.Middleware){middleware.SetRequiredScopes("orders", "billing")},
+			Status:          http.StatusForbidden,
+			WWWAuthenticate: `Bearer realm="test", error="insufficient_scope", error_description="missing scope billing", scope="orders billing"`,
+		},
+		{
+			Name:    "missing realm role",
+			Options: []func(m *middleware.Middleware){middleware.SetRequiredRealmRoles("admin")},
+			Status:  http.StatusForbidden,
+		},
+		{
+			Name: "policy",
+			Options: []func(m *middleware.Middleware){middleware.SetPolicy(jwx.AnyOf(
+				jwx.RealmRole("admin"),
+				jwx.AllOf(jwx.ClientRole("backend", "reader"), jwx.Scope("orders")),
+			))},
+			Status: http.StatusOK,
+		},
+		{
+			Name:            "denied by policy",
+			Options:         []func(m *middleware.Middleware){middleware.SetPolicy(jwx.Not(jwx.ClientRole("backend", "reader")))},
+			Status:          http.StatusForbidden,
+			WWWAuthenticate: `Bearer realm="test", error="insufficient_scope", error_description="access denied by policy"`,
+		},
+		{
+			Name:            "missing client role",
+			Options:         []func(m *middleware.Middleware){middleware.SetRequiredClientRoles("backend", "writer")},
+			Status:          http.StatusForbidden,
+			WWWAuthenticate: `Bearer realm="test", error="insufficient_scope", error_description="missing role writer of client backend"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			m := middleware.New(client, "test", testCase.Options...)
+			rec := serve(m, func(r *http.Request) {
+				switch testCase.Authorization {
+				case "":
+					r.Header.Set("Authorization", "Bearer "+realm.sign(t, nil))
+				case "-":
+				default:
+					r.Header.Set("Authorization", testCase.Authorization)
+				}
+			})
+
+			require.Equal(t, testCase.Status, rec.Code)
+			if testCase.Status == http.StatusOK {
+				require.Equal(t, "user-id", rec.Body.String())
+				return
+			}
+			if testCase.WWWAuthenticate != "" {
+				require.Equal(t, testCase.WWWAuthenticate, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestMiddleware_InvalidToken(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t)
+	m := middleware.New(gocloak.NewClient(realm.server.URL), "test")
+
+	rec := serve(m, func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer "+realm.sign(t, func(claims jwt.MapClaims) {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		}))
+	})
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Regexp(t, `^Bearer realm="test", error="invalid_token", error_description="token validation failed: exp: [^"]+"$`, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestMiddleware_TokenInQuery(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t)
+	m := middleware.New(gocloak.NewClient(realm.server.URL), "test")
+
+	rec := serve(m, func(r *http.Request) {
+		r.URL.RawQuery = "access_token=" + realm.sign(t, nil)
+	})
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Header().Get("WWW-Authenticate"), `error="invalid_request"`)
+}
+
+func TestContextAccessors(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t)
+	accessToken := realm.sign(t, nil)
+
+	var called bool
+	handler := middleware.New(gocloak.NewClient(realm.server.URL), "test").Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		token, ok := middleware.AccessTokenFromContext(r.Context())
+		require.True(t, ok)
+		require.Equal(t, accessToken, token)
+
+		parsed, ok := middleware.TokenFromContext(r.Context())
+		require.True(t, ok)
+		require.True(t, parsed.Valid)
+
+		claims, ok := middleware.ClaimsFromContext(r.Context())
+		require.True(t, ok)
+		require.Equal(t, "frontend", claims.Azp)
+		require.Equal(t, []string{"user"}, claims.RealmAccess.Roles)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.True(t, called)
+
+	_, ok := middleware.ClaimsFromContext(req.Context())
+	require.False(t, ok)
+}
@@ -0,0 +1,117 @@
+// Package prommetrics exposes the metrics of gocloak clients to Prometheus.
+package prommetrics
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// Observer is a gocloak.Observer which records the requests and certs cache events of gocloak clients
+// in Prometheus metrics. It is a prometheus.Collector, register it with the registry of the application.
+type Observer struct {
+	namespace string
+	buckets   []float64
+
+	requests   *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	retries    *prometheus.CounterVec
+	certsCache *prometheus.CounterVec
+}
+
+// Verify struct implements interfaces
+var (
+	_ gocloak.Observer     = &Observer{}
+	_ prometheus.Collector = &Observer{}
+)
+
+// New creates a new Observer. The metrics are named gocloak_requests_total, gocloak_request_duration_seconds,
+// gocloak_request_retries_total and gocloak_certs_cache_events_total unless another namespace is set.
+func New(options ...func(o *Observer)) *Observer {
+	o := &Observer{
+		namespace: "gocloak",
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, option := range options {
+		option(o)
+	}
+
+	o.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: o.namespace,
+		Name:      "requests_total",
+		Help:      "Requests to Keycloak by operation, realm, method, status code and error type.",
+	}, []string{"operation", "realm", "method", "code", "error_type"})
+	o.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: o.namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the requests to Keycloak including retries.",
+		Buckets:   o.buckets,
+	}, []string{"operation", "realm", "method"})
+	o.retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: o.namespace,
+		Name:      "request_retries_total",
+		Help:      "Retries of requests to Keycloak.",
+	}, []string{"operation", "realm", "method"})
+	o.certsCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: o.namespace,
+		Name:      "certs_cache_events_total",
+		Help:      "Hits, misses and refreshes of the certs cache by realm.",
+	}, []string{"realm", "event"})
+
+	return o
+}
+
+// ==== Functional Options ===
+
+// SetNamespace sets the namespace prepended to the metric names
+func SetNamespace(namespace string) func(o *Observer) {
+	return func(o *Observer) {
+		o.namespace = namespace
+	}
+}
+
+// SetBuckets sets the buckets of the request duration histogram in seconds
+func SetBuckets(buckets ...float64) func(o *Observer) {
+	return func(o *Observer) {
+		o.buckets = buckets
+	}
+}
+
+// ObserveRequest implements gocloak.Observer
+func (o *Observer) ObserveRequest(_ context.Context, metrics gocloak.RequestMetrics) {
+	code := ""
+	if metrics.StatusCode != 0 {
+		code = strconv.Itoa(metrics.StatusCode)
+	}
+
+	o.requests.WithLabelValues(metrics.Operation, metrics.Realm, metrics.Method, code, string(metrics.ErrorType)).Inc()
+	o.duration.WithLabelValues(metrics.Operation, metrics.Realm, metrics.Method).Observe(metrics.Duration.Seconds())
+	if metrics.Retries > 0 {
+		o.retries.WithLabelValues(metrics.Operation, metrics.Realm, metrics.Method).Add(float64(metrics.Retries))
+	}
+}
+
+// ObserveCertsCache implements gocloak.Observer
+func (o *Observer) ObserveCertsCache(_ context.Context, realm string, event gocloak.CertsCacheEvent) {
+	o.certsCache.WithLabelValues(realm, string(event)).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	o.requests.Describe(ch)
+	o.duration.Describe(ch)
+	o.retries.Describe(ch)
+	o.certsCache.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	o.requests.Collect(ch)
+	o.duration.Collect(ch)
+	o.retries.Collect(ch)
+	o.certsCache.Collect(ch)
+}
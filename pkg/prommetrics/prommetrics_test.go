@@ -0,0 +1,86 @@
+package prommetrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/Nerzal/gocloak/v13/pkg/prommetrics"
+)
+
+func TestObserver_RecordsRequests(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"User not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	observer := prommetrics.New()
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(observer))
+	client := gocloak.NewClient(server.URL, gocloak.SetObserver(observer))
+	ctx := context.Background()
+
+	_, err := client.GetUsers(ctx, "token", "realm", gocloak.GetUsersParams{})
+	require.NoError(t, err)
+	_, err = client.GetUserByID(ctx, "token", "realm", "missing")
+	require.ErrorIs(t, err, gocloak.ErrNotFound)
+
+	expected := `
+# HELP gocloak_requests_total Requests to Keycloak by operation, realm, method, status code and error type.
+# TYPE gocloak_requests_total counter
+gocloak_requests_total{code="200",error_type="",method="GET",operation="GetUsers",realm="realm"} 1
+gocloak_requests_total{code="404",error_type="not found",method="GET",operation="GetUserByID",realm="realm"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "gocloak_requests_total"))
+	require.Equal(t, 2, testutil.CollectAndCount(observer, "gocloak_request_duration_seconds"))
+}
+
+func TestObserver_RetriesAndCertsCache(t *testing.T) {
+	t.Parallel()
+	observer := prommetrics.New(prommetrics.SetNamespace("keycloak"), prommetrics.SetBuckets(0.1, 1))
+
+	observer.ObserveRequest(context.Background(), gocloak.RequestMetrics{
+		Operation: "GetToken",
+		Realm:     "realm",
+		Method:    http.MethodPost,
+		Retries:   2,
+		Duration:  500 * time.Millisecond,
+	})
+	observer.ObserveCertsCache(context.Background(), "realm", gocloak.CertsCacheHit)
+	observer.ObserveCertsCache(context.Background(), "realm", gocloak.CertsCacheHit)
+	observer.ObserveCertsCache(context.Background(), "realm", gocloak.CertsCacheMiss)
+
+	expected := `
+# HELP keycloak_certs_cache_events_total Hits, misses and refreshes of the certs cache by realm.
+# TYPE keycloak_certs_cache_events_total counter
+keycloak_certs_cache_events_total{event="hit",realm="realm"} 2
+keycloak_certs_cache_events_total{event="miss",realm="realm"} 1
+# HELP keycloak_request_duration_seconds Duration of the requests to Keycloak including retries.
+# TYPE keycloak_request_duration_seconds histogram
+keycloak_request_duration_seconds_bucket{method="POST",operation="GetToken",realm="realm",le="0.1"} 0
+keycloak_request_duration_seconds_bucket{method="POST",operation="GetToken",realm="realm",le="1"} 1
+keycloak_request_duration_seconds_bucket{method="POST",operation="GetToken",realm="realm",le="+Inf"} 1
+keycloak_request_duration_seconds_sum{method="POST",operation="GetToken",realm="realm"} 0.5
+keycloak_request_duration_seconds_count{method="POST",operation="GetToken",realm="realm"} 1
+# HELP keycloak_request_retries_total Retries of requests to Keycloak.
+# TYPE keycloak_request_retries_total counter
+keycloak_request_retries_total{method="POST",operation="GetToken",realm="realm"} 2
+`
+	require.NoError(t, testutil.CollectAndCompare(observer, strings.NewReader(expected),
+		"keycloak_certs_cache_events_total", "keycloak_request_duration_seconds", "keycloak_request_retries_total"))
+}
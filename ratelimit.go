@@ -0,0 +1,198 @@
+package gocloak
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+// EndpointClass groups the endpoints of Keycloak for rate limiting
+type EndpointClass string
+
+const (
+	// EndpointClassToken are the endpoints of a realm outside the admin API, e.g. token, userinfo, certs and UMA
+	EndpointClassToken EndpointClass = "token"
+	// EndpointClassAdmin are the endpoints of the admin REST API
+	EndpointClassAdmin EndpointClass = "admin"
+)
+
+// RateLimit limits the requests to Keycloak.
+// Requests wait for a token of a bucket refilled with RequestsPerSecond and holding up to Burst tokens,
+// which defaults to one. Retries of a request take a token as well.
+// At most MaxInFlight calls are sent at the same time, further calls wait for a free slot.
+// Waiting stops with an error if the context of the call is canceled or its deadline would pass.
+// Zero values disable the respective limit.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+	MaxInFlight       int
+}
+
+type limiter struct {
+	bucket   *rate.Limiter
+	inFlight chan struct{}
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	l := &limiter{}
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		l.bucket = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+	}
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return l
+}
+
+func (l *limiter) acquire(ctx context.Context) error {
+	if l.inFlight == nil {
+		return nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if l.bucket == nil {
+		return nil
+	}
+
+	if err := l.bucket.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// the bucket refuses to wait beyond the deadline of the context
+		return errors.WithMessage(context.DeadlineExceeded, err.Error())
+	}
+
+	return nil
+}
+
+// rateLimiter applies the limits of a GoCloak instance, its realms and endpoint classes to the requests of the resty client.
+// The in-flight slots of a call are held from its first attempt until its last attempt finished.
+type rateLimiter struct {
+	global  *limiter
+	realms  map[string]*limiter
+	classes map[EndpointClass]*limiter
+	held    sync.Map
+}
+
+func (g *GoCloak) getRateLimiter() *rateLimiter {
+	if g.hooks.rateLimiter == nil {
+		g.hooks.rateLimiter = &rateLimiter{
+			realms:  make(map[string]*limiter),
+			classes: make(map[EndpointClass]*limiter),
+		}
+	}
+
+	return g.hooks.rateLimiter
+}
+
+// limiters returns the limiters applying to the URL, always in the same order to avoid deadlocks between them
+func (r *rateLimiter) limiters(requestURL string) []*limiter {
+	var limiters []*limiter
+	if r.global != nil {
+		limiters = append(limiters, r.global)
+	}
+
+	class, realm := classifyEndpoint(requestURL)
+	if l, ok := r.classes[class]; ok {
+		limiters = append(limiters, l)
+	}
+	if l, ok := r.realms[realm]; ok && realm != "" {
+		limiters = append(limiters, l)
+	}
+
+	return limiters
+}
+
+func (r *rateLimiter) beforeRequest(_ *resty.Client, req *resty.Request) error {
+	const errMessage = "could not wait for rate limit"
+
+	ctx := req.Context()
+	limiters := r.limiters(req.URL)
+
+	if _, ok := r.held.Load(req); !ok {
+		var acquired []*limiter
+		for _, l := range limiters {
+			if err := l.acquire(ctx); err != nil {
+				releaseAll(acquired)
+				return errors.Wrap(err, errMessage)
+			}
+			acquired = append(acquired, l)
+		}
+		r.held.Store(req, acquired)
+	}
+
+	for _, l := range limiters {
+		if err := l.wait(ctx); err != nil {
+			return errors.Wrap(err, errMessage)
+		}
+	}
+
+	return nil
+}
+
+func (r *rateLimiter) releaseRequest(req *resty.Request) {
+	if req == nil {
+		return
+	}
+	if acquired, ok := r.held.LoadAndDelete(req); ok {
+		releaseAll(acquired.([]*limiter))
+	}
+}
+
+func releaseAll(limiters []*limiter) {
+	for _, l := range limiters {
+		l.release()
+	}
+}
+
+// classifyEndpoint returns the endpoint class and realm of a Keycloak URL,
+// e.g. admin and master for .../admin/realms/master/users
+func classifyEndpoint(requestURL string) (EndpointClass, string) {
+	parsedURL, err := url.Parse(requestURL)
+	if err != nil {
+		return EndpointClassToken, ""
+	}
+
+	segments := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "realms" || i+1 >= len(segments) {
+			continue
+		}
+		if i > 0 && segments[i-1] == "admin" {
+			return EndpointClassAdmin, segments[i+1]
+		}
+		return EndpointClassToken, segments[i+1]
+	}
+
+	for _, segment := range segments {
+		if segment == "admin" {
+			return EndpointClassAdmin, ""
+		}
+	}
+
+	return EndpointClassToken, ""
+}
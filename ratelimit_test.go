@@ -0,0 +1,200 @@
+package gocloak_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// limitedServer answers admin and userinfo requests, holding requests to the realm "blocked" until unblock is closed
+func limitedServer(t *testing.T) (server *fakeServer, maxInFlight *int32, unblock chan struct{}) {
+	var inFlight, max int32
+	unblock = make(chan struct{})
+	server = newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&max)
+			if current <= seen || atomic.CompareAndSwapInt32(&max, seen, current) {
+				break
+			}
+		}
+
+		if strings.Contains(r.URL.Path, "/realms/blocked/") {
+			<-unblock
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/users") {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sub":"user"}`))
+	})
+
+	return server, &max, unblock
+}
+
+func TestRateLimit_MaxInFlight(t *testing.T) {
+	t.Parallel()
+	server, maxInFlight, _ := limitedServer(t)
+	client := gocloak.NewClient(server.URL, gocloak.SetRateLimit(gocloak.RateLimit{MaxInFlight: 2}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), atomic.LoadInt32(maxInFlight))
+}
+
+func TestRateLimit_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+	server, _, _ := limitedServer(t)
+	client := gocloak.NewClient(server.URL, gocloak.SetRateLimit(gocloak.RateLimit{RequestsPerSecond: 20}))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.GetUserInfo(context.Background(), "token", "realm")
+		require.NoError(t, err)
+	}
+
+	// the first request takes the initial token, the others wait 50ms each
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestRateLimit_WaitingStopsWithContext(t *testing.T) {
+	t.Parallel()
+	server, _, unblock := limitedServer(t)
+	client := gocloak.NewClient(server.URL, gocloak.SetRateLimit(gocloak.RateLimit{MaxInFlight: 1}))
+
+	done := make(chan error)
+	go func() {
+		_, err := client.GetUsers(context.Background(), "token", "blocked", gocloak.GetUsersParams{})
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetUsers(ctx, "token", "realm", gocloak.GetUsersParams{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(unblock)
+	require.NoError(t, <-done)
+
+	// the slot is free again
+	_, err = client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+	require.NoError(t, err)
+}
+
+func TestRateLimit_RequestsPerSecondBeyondDeadline(t *testing.T) {
+	t.Parallel()
+	server, _, _ := limitedServer(t)
+	client := gocloak.NewClient(server.URL, gocloak.SetRateLimit(gocloak.RateLimit{RequestsPerSecond: 0.1}))
+
+	_, err := client.GetUserInfo(context.Background(), "token", "realm")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = client.GetUserInfo(ctx, "token", "realm")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRateLimit_PerRealmAndEndpointClass(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name    string
+		Option  func(g *gocloak.GoCloak)
+		Blocked func(ctx context.Context, client *gocloak.GoCloak) error
+		Other   func(ctx context.Context, client *gocloak.GoCloak) error
+	}{
+		{
+			Name:   "realm",
+			Option: gocloak.SetRealmRateLimit("blocked", gocloak.RateLimit{MaxInFlight: 1}),
+			Blocked: func(ctx context.Context, client *gocloak.GoCloak) error {
+				_, err := client.GetUsers(ctx, "token", "blocked", gocloak.GetUsersParams{})
+				return err
+			},
+			Other: func(ctx context.Context, client *gocloak.GoCloak) error {
+				_, err := client.GetUsers(ctx, "token", "realm", gocloak.GetUsersParams{})
+				return err
+			},
+		},
+		{
+			Name:   "endpoint class",
+			Option: gocloak.SetEndpointRateLimit(gocloak.EndpointClassAdmin, gocloak.RateLimit{MaxInFlight: 1}),
+			Blocked: func(ctx context.Context, client *gocloak.GoCloak) error {
+				_, err := client.GetUsers(ctx, "token", "blocked", gocloak.GetUsersParams{})
+				return err
+			},
+			Other: func(ctx context.Context, client *gocloak.GoCloak) error {
+				_, err := client.GetUserInfo(ctx, "token", "realm")
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			server, _, unblock := limitedServer(t)
+			client := gocloak.NewClient(server.URL, tc.Option)
+
+			done := make(chan error)
+			go func() {
+				done <- tc.Blocked(context.Background(), client)
+			}()
+			time.Sleep(50 * time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			require.ErrorIs(t, tc.Blocked(ctx, client), context.DeadlineExceeded)
+			require.NoError(t, tc.Other(context.Background(), client))
+
+			close(unblock)
+			require.NoError(t, <-done)
+		})
+	}
+}
+
+func TestRateLimit_ReleasesSlotsAfterRetries(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 4, http.StatusServiceUnavailable, nil)
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetRateLimit(gocloak.RateLimit{MaxInFlight: 1}),
+		gocloak.SetRetryPolicy(gocloak.RetryPolicy{MaxRetries: 1, WaitTime: time.Millisecond}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		_, err := client.GetServerInfo(ctx, "token")
+		require.Error(t, err)
+	}
+	_, err := client.GetServerInfo(ctx, "token")
+	require.NoError(t, err)
+	require.Len(t, server.recorded(""), 5)
+}
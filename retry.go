@@ -0,0 +1,144 @@
+package gocloak
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultRetryMaxRetries  = 3
+	defaultRetryWaitTime    = 100 * time.Millisecond
+	defaultRetryMaxWaitTime = 30 * time.Second
+)
+
+// defaultRetryStatusCodes are the transient responses of Keycloak, e.g. during rolling restarts
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures the retries of failed requests.
+// Requests are retried after transport errors and responses with one of the StatusCodes,
+// waiting an exponentially growing time with jitter starting at WaitTime and limited to MaxWaitTime.
+// A Retry-After header of 429 and 503 responses is honored instead, but the request is not retried
+// if the server asks to wait longer than MaxWaitTime or the deadline of the context would pass.
+// Only idempotent methods are retried unless RetryNonIdempotent is set, which includes the POST requests
+// of the token endpoint. Zero values are replaced with three retries, 100ms wait time, 30s max wait time
+// and the status codes 429, 502, 503 and 504.
+type RetryPolicy struct {
+	MaxRetries         int
+	WaitTime           time.Duration
+	MaxWaitTime        time.Duration
+	StatusCodes        []int
+	RetryNonIdempotent bool
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries <= 0 {
+		p.MaxRetries = defaultRetryMaxRetries
+	}
+	if p.WaitTime <= 0 {
+		p.WaitTime = defaultRetryWaitTime
+	}
+	if p.MaxWaitTime <= 0 {
+		p.MaxWaitTime = defaultRetryMaxWaitTime
+	}
+	if len(p.StatusCodes) == 0 {
+		p.StatusCodes = defaultRetryStatusCodes
+	}
+	return p
+}
+
+// apply configures the retry count and wait times of the resty client
+func (p RetryPolicy) apply(client *resty.Client) {
+	client.SetRetryCount(p.MaxRetries).
+		SetRetryWaitTime(p.WaitTime).
+		SetRetryMaxWaitTime(p.MaxWaitTime).
+		SetRetryAfter(p.retryAfter)
+}
+
+// shouldRetry reports whether the request can be retried after the response or error
+func (p RetryPolicy) shouldRetry(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(resp.Request.Method) {
+		return false
+	}
+
+	ctx := resp.Request.Context()
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	if !containsInt(p.StatusCodes, resp.StatusCode()) {
+		return false
+	}
+
+	wait, ok := parseRetryAfter(resp, time.Now())
+	if !ok {
+		return true
+	}
+	if wait > p.MaxWaitTime {
+		return false
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	return !hasDeadline || time.Now().Add(wait).Before(deadline)
+}
+
+// retryAfter returns the wait time requested by the server or zero for the exponential backoff with jitter
+func (p RetryPolicy) retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	wait, _ := parseRetryAfter(resp, time.Now())
+	return wait, nil
+}
+
+// parseRetryAfter parses the Retry-After header of 429 and 503 responses, given in seconds or as HTTP date
+func parseRetryAfter(resp *resty.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode() != http.StatusTooManyRequests && resp.StatusCode() != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	value := resp.Header().Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
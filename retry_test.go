@@ -0,0 +1,180 @@
+package gocloak_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// flakyServer responds with the given status and headers to the first failures requests
+func flakyServer(t *testing.T, failures int, status int, header http.Header) *fakeServer {
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		if len(server.recorded("")) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","systemInfo":{"version":"26.0.0"}}`))
+	})
+
+	return server
+}
+
+func TestRetryPolicy_RetriesIdempotentRequests(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}))
+
+	serverInfo, err := client.GetServerInfo(context.Background(), "token")
+	require.NoError(t, err)
+	require.Equal(t, "26.0.0", gocloak.PString(serverInfo.SystemInfo.Version))
+	require.Equal(t, 3, len(server.recorded("")))
+}
+
+func TestRetryPolicy_StopsAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 10, http.StatusBadGateway, nil)
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{MaxRetries: 2, WaitTime: time.Millisecond}))
+
+	_, err := client.GetServerInfo(context.Background(), "token")
+	require.Error(t, err)
+	require.Equal(t, 3, len(server.recorded("")))
+}
+
+func TestRetryPolicy_DoesNotRetryUnlistedStatus(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 1, http.StatusInternalServerError, nil)
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}))
+
+	_, err := client.GetServerInfo(context.Background(), "token")
+	require.Error(t, err)
+	require.Equal(t, 1, len(server.recorded("")))
+}
+
+func TestRetryPolicy_NonIdempotentRequests(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name               string
+		RetryNonIdempotent bool
+		Calls              int
+	}{
+		{"not retried by default", false, 1},
+		{"retried if enabled", true, 2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			server := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+			client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{
+				WaitTime:           time.Millisecond,
+				RetryNonIdempotent: tc.RetryNonIdempotent,
+			}))
+
+			_, err := client.LoginClient(context.Background(), "client", "secret", "realm")
+			require.Equal(t, tc.RetryNonIdempotent, err == nil, err)
+			require.Equal(t, tc.Calls, len(server.recorded("")))
+		})
+	}
+}
+
+func TestRetryPolicy_HonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}))
+
+	start := time.Now()
+	_, err := client.GetServerInfo(context.Background(), "token")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+	require.Equal(t, 2, len(server.recorded("")))
+}
+
+func TestRetryPolicy_RetryAfterBeyondLimits(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Name    string
+		Policy  gocloak.RetryPolicy
+		Timeout time.Duration
+	}{
+		{"beyond max wait time", gocloak.RetryPolicy{MaxWaitTime: time.Minute}, 0},
+		{"beyond context deadline", gocloak.RetryPolicy{}, 5 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			server := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+			client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(tc.Policy))
+
+			ctx := context.Background()
+			if tc.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.Timeout)
+				defer cancel()
+			}
+
+			_, err := client.GetServerInfo(ctx, "token")
+			require.ErrorIs(t, err, gocloak.ErrRateLimited)
+			require.Equal(t, 1, len(server.recorded("")))
+		})
+	}
+}
+
+func TestRetryPolicy_RespectsContextDeadline(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 100, http.StatusServiceUnavailable, nil)
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{
+		MaxRetries:  50,
+		WaitTime:    50 * time.Millisecond,
+		MaxWaitTime: 50 * time.Millisecond,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetServerInfo(ctx, "token")
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 2*time.Second)
+	require.Less(t, len(server.recorded("")), 51)
+}
+
+func TestRetryPolicy_TracesRetries(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	client := gocloak.NewClient(server.URL, gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}))
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("keycloak")
+	ctx := opentracing.ContextWithSpan(context.Background(), span)
+
+	_, err := client.GetServerInfo(ctx, "token")
+	require.NoError(t, err)
+	span.Finish()
+
+	var attempts []string
+	for _, record := range tracer.FinishedSpans()[0].Logs() {
+		fields := map[string]string{}
+		for _, field := range record.Fields {
+			fields[field.Key] = field.ValueString
+		}
+		require.Equal(t, "retry", fields["event"])
+		require.Equal(t, "503", fields["http.status_code"])
+		attempts = append(attempts, fields["http.retry_count"])
+	}
+	require.Equal(t, []string{"1", "2"}, attempts)
+}
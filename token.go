@@ -11,4 +11,5 @@ type JWT struct {
 	NotBeforePolicy  int    `json:"not-before-policy"`
 	SessionState     string `json:"session_state"`
 	Scope            string `json:"scope"`
+	IssuedTokenType  string `json:"issued_token_type"`
 }
@@ -0,0 +1,65 @@
+package gocloak
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// GrantTypeTokenExchange is the grant type of the token exchange (RFC 8693)
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// TokenTypeAccessToken identifies an OAuth 2.0 access token
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	// TokenTypeRefreshToken identifies an OAuth 2.0 refresh token
+	TokenTypeRefreshToken = "urn:ietf:params:oauth:token-type:refresh_token"
+	// TokenTypeIDToken identifies an OpenID Connect ID token
+	TokenTypeIDToken = "urn:ietf:params:oauth:token-type:id_token"
+	// TokenTypeJWT identifies a JWT, e.g. a token issued by an external identity provider
+	TokenTypeJWT = "urn:ietf:params:oauth:token-type:jwt"
+	// TokenTypeSAML2 identifies a base64url-encoded SAML 2.0 assertion
+	TokenTypeSAML2 = "urn:ietf:params:oauth:token-type:saml2"
+)
+
+// ExchangeToken exchanges a token at the token endpoint of the realm (RFC 8693).
+// The type of the issued token is returned as IssuedTokenType, the token itself as AccessToken.
+// Requires token exchange to be enabled: https://www.keycloak.org/securing-apps/token-exchange
+func (g *GoCloak) ExchangeToken(ctx context.Context, realm string, options TokenExchangeOptions) (*JWT, error) {
+	const errMessage = "could not exchange token"
+
+	ctx, span := g.startSpan(ctx, "ExchangeToken")
+	defer span.End()
+
+	if NilOrEmpty(options.SubjectToken) && NilOrEmpty(options.RequestedSubject) {
+		return nil, errors.New(errMessage + ": subject token or requested subject is required")
+	}
+	if !NilOrEmpty(options.ActorToken) && NilOrEmpty(options.ActorTokenType) {
+		return nil, errors.New(errMessage + ": actor token type is required")
+	}
+	if !NilOrEmpty(options.SubjectIssuer) && NilOrEmpty(options.SubjectToken) {
+		return nil, errors.New(errMessage + ": subject issuer requires a subject token")
+	}
+
+	tokenURL, err := g.getTokenURL(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	req, err := g.getClientAuthRequest(ctx, realm, PString(options.ClientID), PString(options.ClientSecret), options.ClientAuthenticator)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	var token JWT
+	resp, err := req.SetFormData(options.FormData()).
+		SetFormDataFromValues(url.Values{"audience": PStringSlice(options.Audiences)}).
+		SetResult(&token).
+		Post(tokenURL)
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,157 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+func newFakeTokenExchangeServer(t *testing.T) *fakeServer {
+	server := newFakeServer(t)
+	server.handle(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		issuedTokenType := r.PostForm.Get("requested_token_type")
+		if issuedTokenType == "" {
+			issuedTokenType = gocloak.TokenTypeAccessToken
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{
+			AccessToken:     "exchanged",
+			TokenType:       "Bearer",
+			ExpiresIn:       300,
+			IssuedTokenType: issuedTokenType,
+		})
+	})
+
+	return server
+}
+
+// exchangedForm returns the form of the last token exchange request, which the client authenticated as gateway
+func exchangedForm(t *testing.T, server *fakeServer) url.Values {
+	request := server.last(t, tokenPath)
+	clientID, clientSecret, ok := request.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "gateway", clientID)
+	require.Equal(t, "secret", clientSecret)
+	require.Equal(t, gocloak.GrantTypeTokenExchange, request.Form.Get("grant_type"))
+
+	return request.Form
+}
+
+func TestExchangeToken(t *testing.T) {
+	t.Parallel()
+	server := newFakeTokenExchangeServer(t)
+	client := gocloak.NewClient(server.URL)
+
+	token, err := client.ExchangeToken(context.Background(), "test", gocloak.TokenExchangeOptions{
+		ClientID:       gocloak.StringP("gateway"),
+		ClientSecret:   gocloak.StringP("secret"),
+		SubjectToken:   gocloak.StringP("subject"),
+		ActorToken:     gocloak.StringP("actor"),
+		ActorTokenType: gocloak.StringP(gocloak.TokenTypeJWT),
+		Audiences:      &[]string{"backend", "billing"},
+		Scopes:         &[]string{"openid", "profile"},
+	})
+	require.NoError(t, err)
+	form := exchangedForm(t, server)
+	require.Equal(t, "subject", form.Get("subject_token"))
+	require.Equal(t, gocloak.TokenTypeAccessToken, form.Get("subject_token_type"))
+	require.Equal(t, []string{"backend", "billing"}, form["audience"])
+	require.Equal(t, "openid profile", form.Get("scope"))
+	require.Equal(t, "actor", form.Get("actor_token"))
+	require.Equal(t, gocloak.TokenTypeJWT, form.Get("actor_token_type"))
+	require.Empty(t, form.Get("requested_subject"))
+	require.Equal(t, "exchanged", token.AccessToken)
+	require.Equal(t, gocloak.TokenTypeAccessToken, token.IssuedTokenType)
+}
+
+func TestExchangeToken_ExternalToInternal(t *testing.T) {
+	t.Parallel()
+	server := newFakeTokenExchangeServer(t)
+	client := gocloak.NewClient(server.URL)
+
+	token, err := client.ExchangeToken(context.Background(), "test", gocloak.TokenExchangeOptions{
+		ClientID:           gocloak.StringP("gateway"),
+		ClientSecret:       gocloak.StringP("secret"),
+		SubjectToken:       gocloak.StringP("external"),
+		SubjectTokenType:   gocloak.StringP(gocloak.TokenTypeJWT),
+		SubjectIssuer:      gocloak.StringP("github"),
+		RequestedTokenType: gocloak.StringP(gocloak.TokenTypeRefreshToken),
+	})
+	require.NoError(t, err)
+	form := exchangedForm(t, server)
+	require.Equal(t, "external", form.Get("subject_token"))
+	require.Equal(t, gocloak.TokenTypeJWT, form.Get("subject_token_type"))
+	require.Equal(t, "github", form.Get("subject_issuer"))
+	require.Equal(t, gocloak.TokenTypeRefreshToken, token.IssuedTokenType)
+}
+
+func TestExchangeToken_InvalidOptions(t *testing.T) {
+	t.Parallel()
+	client := gocloak.NewClient("http://localhost")
+
+	testCases := []struct {
+		Name    string
+		Options gocloak.TokenExchangeOptions
+	}{
+		{
+			Name:    "no subject",
+			Options: gocloak.TokenExchangeOptions{ClientID: gocloak.StringP("gateway")},
+		},
+		{
+			Name: "actor token without type",
+			Options: gocloak.TokenExchangeOptions{
+				SubjectToken: gocloak.StringP("subject"),
+				ActorToken:   gocloak.StringP("actor"),
+			},
+		},
+		{
+			Name: "subject issuer without subject token",
+			Options: gocloak.TokenExchangeOptions{
+				RequestedSubject: gocloak.StringP("user"),
+				SubjectIssuer:    gocloak.StringP("github"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := client.ExchangeToken(context.Background(), "test", testCase.Options)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoginClientTokenExchange_Wrapper(t *testing.T) {
+	t.Parallel()
+	server := newFakeTokenExchangeServer(t)
+	client := gocloak.NewClient(server.URL)
+
+	token, err := client.LoginClientTokenExchange(context.Background(), "gateway", "subject", "secret", "test", "backend", "user-id")
+	require.NoError(t, err)
+	form := exchangedForm(t, server)
+	require.Equal(t, "subject", form.Get("subject_token"))
+	require.Equal(t, gocloak.TokenTypeRefreshToken, form.Get("requested_token_type"))
+	require.Equal(t, []string{"backend"}, form["audience"])
+	require.Equal(t, "user-id", form.Get("requested_subject"))
+	require.Equal(t, gocloak.TokenTypeRefreshToken, token.IssuedTokenType)
+}
+
+func TestDirectNakedImpersonationTokenExchange_Wrapper(t *testing.T) {
+	t.Parallel()
+	server := newFakeTokenExchangeServer(t)
+	client := gocloak.NewClient(server.URL)
+
+	_, err := client.DirectNakedImpersonationTokenExchange(context.Background(), "gateway", "secret", "test", "user-id")
+	require.NoError(t, err)
+	form := exchangedForm(t, server)
+	require.Empty(t, form.Get("subject_token"))
+	require.Empty(t, form.Get("subject_token_type"))
+	require.Equal(t, "user-id", form.Get("requested_subject"))
+}
@@ -0,0 +1,107 @@
+package gocloak
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultTokenPollInterval is used if the authorization response contains no interval
+	defaultTokenPollInterval = 5 * time.Second
+	// tokenSlowDownInterval is added to the polling interval on every slow_down response
+	tokenSlowDownInterval = 5 * time.Second
+)
+
+// TokenPollError is returned by the token endpoint while polling for the token of a
+// decoupled grant, i.e. the Device Authorization Grant or CIBA.
+// Compare it against ErrAuthorizationPending, ErrSlowDown, ErrAuthorizationExpired and ErrAccessDenied with errors.Is.
+type TokenPollError struct {
+	Code        string
+	Description string
+}
+
+// Error stringifies the TokenPollError
+func (e *TokenPollError) Error() string {
+	if e.Description == "" {
+		return "authorization failed: " + e.Code
+	}
+	return "authorization failed: " + e.Code + ": " + e.Description
+}
+
+// Is reports whether target is a TokenPollError with the same code
+func (e *TokenPollError) Is(target error) bool {
+	t, ok := target.(*TokenPollError)
+	return ok && t.Code == e.Code
+}
+
+var (
+	// ErrAuthorizationPending is returned while the user has not completed the authorization yet
+	ErrAuthorizationPending = &TokenPollError{Code: "authorization_pending"}
+	// ErrSlowDown is returned if the token endpoint is polled too frequently
+	ErrSlowDown = &TokenPollError{Code: "slow_down"}
+	// ErrAuthorizationExpired is returned if the device code or the backchannel authentication expired before the user completed it
+	ErrAuthorizationExpired = &TokenPollError{Code: "expired_token"}
+	// ErrAccessDenied is returned if the user denied the authorization
+	ErrAccessDenied = &TokenPollError{Code: "access_denied"}
+)
+
+// pollToken polls the token endpoint every interval seconds until the user completed the authorization,
+// the authorization expired after expiresIn seconds or ctx is done.
+func (g *GoCloak) pollToken(ctx context.Context, realm string, options TokenOptions, interval, expiresIn int) (*JWT, error) {
+	wait := time.Duration(interval) * time.Second
+	if wait <= 0 {
+		wait = defaultTokenPollInterval
+	}
+
+	var expired <-chan time.Time
+	if expiresIn > 0 {
+		expiry := time.NewTimer(time.Duration(expiresIn) * time.Second)
+		defer expiry.Stop()
+		expired = expiry.C
+	}
+
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-expired:
+			timer.Stop()
+			return nil, ErrAuthorizationExpired
+		case <-timer.C:
+		}
+
+		token, err := g.getPolledToken(ctx, realm, options)
+		switch {
+		case errors.Is(err, ErrAuthorizationPending):
+		case errors.Is(err, ErrSlowDown):
+			wait += tokenSlowDownInterval
+		case err != nil:
+			return nil, err
+		default:
+			return token, nil
+		}
+	}
+}
+
+// getPolledToken asks the token endpoint once for the token of a decoupled grant.
+// Error responses of the token endpoint are returned as *TokenPollError.
+func (g *GoCloak) getPolledToken(ctx context.Context, realm string, options TokenOptions) (*JWT, error) {
+	const errMessage = "could not get token"
+
+	token, resp, err := g.postToken(ctx, realm, options)
+	if err == nil && resp != nil && resp.IsError() {
+		if e, ok := resp.Error().(*HTTPErrorResponse); ok && e.Error != "" {
+			return nil, &TokenPollError{Code: e.Error, Description: e.Description}
+		}
+	}
+
+	if err := checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,254 @@
+package gocloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// TokenSource caches a JWT obtained via GetToken and renews it ahead of its expiry.
+// A cached refresh token is used for renewal as long as it is valid, otherwise
+// a full login is performed. A TokenSource is safe for concurrent use and makes
+// sure that only one renewal is in flight at a time.
+type TokenSource struct {
+	client  GoCloakIface
+	realm   string
+	options TokenOptions
+	login   func(ctx context.Context) (*JWT, error)
+	skew    time.Duration
+	now     func() time.Time
+
+	mu            sync.Mutex
+	token         *JWT
+	expiry        time.Time
+	refreshExpiry time.Time
+	inflight      *tokenCall
+}
+
+type tokenCall struct {
+	done  chan struct{}
+	token *JWT
+	err   error
+}
+
+// NewTokenSource creates a TokenSource which logs in using the given TokenOptions
+func NewTokenSource(client GoCloakIface, realm string, options TokenOptions, opts ...func(*TokenSource)) *TokenSource {
+	ts := &TokenSource{
+		client:  client,
+		realm:   realm,
+		options: options,
+		skew:    10 * time.Second,
+		now:     time.Now,
+	}
+	ts.login = func(ctx context.Context) (*JWT, error) {
+		return ts.client.GetToken(ctx, ts.realm, ts.options)
+	}
+
+	for _, opt := range opts {
+		opt(ts)
+	}
+
+	return ts
+}
+
+// NewClientTokenSource creates a TokenSource which logs in with client credentials
+func NewClientTokenSource(client GoCloakIface, clientID, clientSecret, realm string, opts ...func(*TokenSource)) *TokenSource {
+	return NewTokenSource(client, realm, TokenOptions{
+		ClientID:     &clientID,
+		ClientSecret: &clientSecret,
+		GrantType:    StringP("client_credentials"),
+	}, opts...)
+}
+
+// NewAdminTokenSource creates a TokenSource which logs in with the admin client
+func NewAdminTokenSource(client GoCloakIface, username, password, realm string, opts ...func(*TokenSource)) *TokenSource {
+	return NewTokenSource(client, realm, TokenOptions{
+		ClientID:  StringP(adminClientID),
+		GrantType: StringP("password"),
+		Username:  &username,
+		Password:  &password,
+	}, opts...)
+}
+
+// NewPasswordTokenSource creates a TokenSource which logs in with user credentials and a client
+func NewPasswordTokenSource(client GoCloakIface, clientID, clientSecret, username, password, realm string, opts ...func(*TokenSource)) *TokenSource {
+	return NewTokenSource(client, realm, TokenOptions{
+		ClientID:     &clientID,
+		ClientSecret: &clientSecret,
+		GrantType:    StringP("password"),
+		Username:     &username,
+		Password:     &password,
+	}, opts...)
+}
+
+// NewSignedJWTTokenSource creates a TokenSource which logs in with client credentials and signed jwt claims.
+// Each login and refresh signs a new assertion which expires after the given lifetime.
+func NewSignedJWTTokenSource(
+	client GoCloakIface,
+	clientID,
+	realm string,
+	key interface{},
+	signedMethod jwt.SigningMethod,
+	lifetime time.Duration,
+	opts ...func(*TokenSource),
+) *TokenSource {
+	ts := NewTokenSource(client, realm, TokenOptions{
+		ClientID:  &clientID,
+		GrantType: StringP("client_credentials"),
+		ClientAuthenticator: PrivateKeyJWT{
+			Key:           key,
+			SigningMethod: signedMethod,
+			Lifetime:      lifetime,
+		},
+	}, opts...)
+	ts.login = func(ctx context.Context) (*JWT, error) {
+		expiresAt := jwt.NewNumericDate(ts.now().Add(lifetime))
+		return ts.client.LoginClientSignedJWT(ctx, clientID, realm, key, signedMethod, expiresAt)
+	}
+
+	return ts
+}
+
+// SetTokenSourceExpirySkew sets how long before its expiry a token is renewed
+func SetTokenSourceExpirySkew(skew time.Duration) func(ts *TokenSource) {
+	return func(ts *TokenSource) {
+		ts.skew = skew
+	}
+}
+
+// Token returns a valid token, renewing the cached token if required.
+func (ts *TokenSource) Token(ctx context.Context) (*JWT, error) {
+	ts.mu.Lock()
+	if ts.token != nil && ts.now().Add(ts.skew).Before(ts.expiry) {
+		token := ts.token
+		ts.mu.Unlock()
+		return token, nil
+	}
+
+	call := ts.inflight
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		ts.inflight = call
+		// the renewal is shared by all waiting callers, so it must not be
+		// aborted when the context of the caller that started it is done
+		go ts.renew(context.WithoutCancel(ctx), call)
+	}
+	ts.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AccessToken returns a valid access token, renewing the cached token if required.
+func (ts *TokenSource) AccessToken(ctx context.Context) (string, error) {
+	token, err := ts.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// Invalidate drops the cached token, so that the next call to Token performs a full login.
+func (ts *TokenSource) Invalidate() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.reset()
+}
+
+// InvalidateToken drops the cached token if it still holds the given access token.
+// Unlike Invalidate it keeps a token which was renewed after accessToken was handed out,
+// so callers can safely report the token a server rejected.
+func (ts *TokenSource) InvalidateToken(accessToken string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == accessToken {
+		ts.reset()
+	}
+}
+
+// reset has to be called with ts.mu held
+func (ts *TokenSource) reset() {
+	ts.token = nil
+	ts.expiry = time.Time{}
+	ts.refreshExpiry = time.Time{}
+}
+
+func (ts *TokenSource) renew(ctx context.Context, call *tokenCall) {
+	const errMessage = "could not renew token"
+
+	ts.mu.Lock()
+	current := ts.token
+	refreshable := ts.refreshable()
+	ts.mu.Unlock()
+
+	issuedAt := ts.now()
+
+	var token *JWT
+	var err error
+	if refreshable {
+		token, err = ts.client.GetToken(ctx, ts.realm, ts.refreshOptions(current.RefreshToken))
+	}
+	if token == nil {
+		// the refresh token is missing, expired or was rejected
+		token, err = ts.login(ctx)
+	}
+
+	ts.mu.Lock()
+	if err == nil {
+		ts.store(token, issuedAt)
+	} else {
+		err = errors.Wrap(err, errMessage)
+	}
+	ts.inflight = nil
+	ts.mu.Unlock()
+
+	call.token, call.err = token, err
+	close(call.done)
+}
+
+// refreshable has to be called with ts.mu held
+func (ts *TokenSource) refreshable() bool {
+	if ts.token == nil || ts.token.RefreshToken == "" {
+		return false
+	}
+
+	// offline tokens have no refresh expiration
+	if ts.refreshExpiry.IsZero() {
+		return true
+	}
+
+	return ts.now().Add(ts.skew).Before(ts.refreshExpiry)
+}
+
+// store has to be called with ts.mu held
+func (ts *TokenSource) store(token *JWT, issuedAt time.Time) {
+	ts.token = token
+	ts.expiry = issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second)
+	ts.refreshExpiry = time.Time{}
+	if token.RefreshExpiresIn > 0 {
+		ts.refreshExpiry = issuedAt.Add(time.Duration(token.RefreshExpiresIn) * time.Second)
+	}
+}
+
+// refreshOptions authenticates the client like the login does
+func (ts *TokenSource) refreshOptions(refreshToken string) TokenOptions {
+	return TokenOptions{
+		ClientID:            ts.options.ClientID,
+		ClientSecret:        ts.options.ClientSecret,
+		ClientAssertionType: ts.options.ClientAssertionType,
+		ClientAssertion:     ts.options.ClientAssertion,
+		ClientAuthenticator: ts.options.ClientAuthenticator,
+		GrantType:           StringP("refresh_token"),
+		RefreshToken:        &refreshToken,
+	}
+}
@@ -0,0 +1,283 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+type fakeTokenEndpoint struct {
+	logins    int32
+	refreshes int32
+	jwt       gocloak.JWT
+	delay     time.Duration
+	rejectRT  bool
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	time.Sleep(f.delay)
+
+	token := f.jwt
+	switch r.PostForm.Get("grant_type") {
+	case "refresh_token":
+		n := atomic.AddInt32(&f.refreshes, 1)
+		if f.rejectRT {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Token is not active"}`))
+			return
+		}
+		token.AccessToken = "refreshed-" + strconv.Itoa(int(n))
+	default:
+		n := atomic.AddInt32(&f.logins, 1)
+		token.AccessToken = "login-" + strconv.Itoa(int(n))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(token)
+}
+
+func newFakeTokenSourceClient(t *testing.T, endpoint *fakeTokenEndpoint) *gocloak.GoCloak {
+	server := httptest.NewServer(endpoint)
+	t.Cleanup(server.Close)
+	return gocloak.NewClient(server.URL)
+}
+
+func TestTokenSource_CachesToken(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{jwt: gocloak.JWT{ExpiresIn: 300}}
+	client := newFakeTokenSourceClient(t, endpoint)
+
+	ts := gocloak.NewClientTokenSource(client, "client", "secret", "realm")
+	for i := 0; i < 3; i++ {
+		token, err := ts.AccessToken(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "login-1", token)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&endpoint.logins))
+}
+
+func TestTokenSource_RefreshesAheadOfExpiry(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{jwt: gocloak.JWT{
+		ExpiresIn:        30,
+		RefreshExpiresIn: 1800,
+		RefreshToken:     "refresh-token",
+	}}
+	client := newFakeTokenSourceClient(t, endpoint)
+
+	ts := gocloak.NewAdminTokenSource(client, "admin", "secret", "master",
+		gocloak.SetTokenSourceExpirySkew(time.Minute))
+
+	token, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "login-1", token)
+
+	// the token expires within the skew, so it is refreshed right away
+	token, err = ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "refreshed-1", token)
+	require.EqualValues(t, 1, atomic.LoadInt32(&endpoint.logins))
+}
+
+func TestTokenSource_RefreshesWithSignedJWT(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{jwt: gocloak.JWT{
+		ExpiresIn:        30,
+		RefreshExpiresIn: 1800,
+		RefreshToken:     "refresh-token",
+	}}
+	server := newFakeServer(t)
+	server.handle("/", endpoint.ServeHTTP)
+	client := gocloak.NewClient(server.URL)
+
+	ts := gocloak.NewSignedJWTTokenSource(client, "client", "realm", []byte("secret"), jwt.SigningMethodHS256, time.Minute,
+		gocloak.SetTokenSourceExpirySkew(time.Minute))
+
+	token, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "login-1", token)
+
+	token, err = ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "refreshed-1", token)
+	var assertions []string
+	for _, request := range server.recorded("") {
+		require.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", request.Form.Get("client_assertion_type"))
+		require.NotEmpty(t, request.Form.Get("client_assertion"), request.Form.Get("grant_type"))
+		assertions = append(assertions, request.Form.Get("client_assertion"))
+	}
+	require.Len(t, assertions, 2)
+	require.NotEqual(t, assertions[0], assertions[1])
+}
+
+func TestTokenSource_FallsBackToLogin(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{jwt: gocloak.JWT{
+		ExpiresIn:        30,
+		RefreshExpiresIn: 30,
+		RefreshToken:     "refresh-token",
+	}}
+	client := newFakeTokenSourceClient(t, endpoint)
+
+	ts := gocloak.NewAdminTokenSource(client, "admin", "secret", "master",
+		gocloak.SetTokenSourceExpirySkew(time.Minute))
+
+	_, err := ts.Token(context.Background())
+	require.NoError(t, err)
+
+	// the refresh token is expired as well, a new login is required
+	token, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "login-2", token)
+	require.EqualValues(t, 0, atomic.LoadInt32(&endpoint.refreshes))
+
+	endpoint.jwt.RefreshExpiresIn = 1800
+	endpoint.rejectRT = true
+	ts.Invalidate()
+	_, err = ts.Token(context.Background())
+	require.NoError(t, err)
+
+	// the refresh token is rejected by the server
+	token, err = ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "login-4", token)
+	require.EqualValues(t, 1, atomic.LoadInt32(&endpoint.refreshes))
+}
+
+func TestTokenSource_SingleRenewalInFlight(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{
+		jwt:   gocloak.JWT{ExpiresIn: 300},
+		delay: 50 * time.Millisecond,
+	}
+	client := newFakeTokenSourceClient(t, endpoint)
+
+	ts := gocloak.NewClientTokenSource(client, "client", "secret", "realm")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := ts.AccessToken(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, "login-1", token)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, atomic.LoadInt32(&endpoint.logins))
+}
+
+func TestTokenSource_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{
+		jwt:   gocloak.JWT{ExpiresIn: 300},
+		delay: 200 * time.Millisecond,
+	}
+	client := newFakeTokenSourceClient(t, endpoint)
+
+	ts := gocloak.NewClientTokenSource(client, "client", "secret", "realm")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := ts.Token(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the renewal started by the canceled caller still completes
+	token, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "login-1", token)
+}
+
+func TestTokenSource_InvalidateToken(t *testing.T) {
+	t.Parallel()
+	endpoint := &fakeTokenEndpoint{jwt: gocloak.JWT{ExpiresIn: 300}}
+	client := newFakeTokenSourceClient(t, endpoint)
+	ctx := context.Background()
+
+	ts := gocloak.NewClientTokenSource(client, "client", "secret", "realm")
+	first, err := ts.AccessToken(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "login-1", first)
+
+	ts.InvalidateToken(first)
+	second, err := ts.AccessToken(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "login-2", second)
+
+	// a rejection of the stale token doesn't drop the renewed one
+	ts.InvalidateToken(first)
+	token, err := ts.AccessToken(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "login-2", token)
+	require.EqualValues(t, 2, atomic.LoadInt32(&endpoint.logins))
+}
+
+// newTokenServer answers every request with a token
+func newTokenServer(t *testing.T) *fakeServer {
+	server := newFakeServer(t)
+	server.handle("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.JWT{AccessToken: "token", ExpiresIn: 300})
+	})
+
+	return server
+}
+
+func TestNewPasswordTokenSource(t *testing.T) {
+	t.Parallel()
+	server := newTokenServer(t)
+
+	ts := gocloak.NewPasswordTokenSource(gocloak.NewClient(server.URL), "client", "secret", "user", "password", "realm")
+	token, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "token", token)
+
+	request := server.last(t, "")
+	require.Equal(t, "password", request.Form.Get("grant_type"))
+	require.Equal(t, "user", request.Form.Get("username"))
+	require.Equal(t, "password", request.Form.Get("password"))
+	require.Equal(t, "client", request.Form.Get("client_id"))
+	require.Equal(t, basicAuth("client", "secret"), request.Header.Get("Authorization"))
+}
+
+func TestNewSignedJWTTokenSource(t *testing.T) {
+	t.Parallel()
+	server := newTokenServer(t)
+	key := []byte("secret")
+
+	ts := gocloak.NewSignedJWTTokenSource(gocloak.NewClient(server.URL), "client", "realm", key, jwt.SigningMethodHS256, 30*time.Second)
+	_, err := ts.AccessToken(context.Background())
+	require.NoError(t, err)
+
+	form := server.last(t, "").Form
+	require.Equal(t, "client_credentials", form.Get("grant_type"))
+	require.Equal(t, "client", form.Get("client_id"))
+	require.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", form.Get("client_assertion_type"))
+
+	claims := jwt.RegisteredClaims{}
+	_, err = jwt.ParseWithClaims(form.Get("client_assertion"), &claims, func(*jwt.Token) (interface{}, error) {
+		return key, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "client", claims.Issuer)
+	require.Equal(t, "client", claims.Subject)
+	require.NotEmpty(t, claims.ID)
+	require.WithinDuration(t, time.Now().Add(30*time.Second), claims.ExpiresAt.Time, 5*time.Second)
+}
@@ -0,0 +1,305 @@
+package gocloak
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/Nerzal/gocloak/v13/pkg/jwx"
+)
+
+// TokenValidationCheck names a check performed by the TokenValidator
+type TokenValidationCheck string
+
+const (
+	// TokenValidationCheckMalformed fails if the token can't be parsed
+	TokenValidationCheckMalformed TokenValidationCheck = "malformed"
+	// TokenValidationCheckSignature fails if the signature can't be verified against the realm keys
+	TokenValidationCheckSignature TokenValidationCheck = "signature"
+	// TokenValidationCheckRealm fails if the token was issued by another realm
+	TokenValidationCheckRealm TokenValidationCheck = "realm"
+	// TokenValidationCheckIssuer fails if the iss claim doesn't match the realm issuer
+	TokenValidationCheckIssuer TokenValidationCheck = "issuer"
+	// TokenValidationCheckAudience fails if the aud claim contains none of the expected audiences
+	TokenValidationCheckAudience TokenValidationCheck = "audience"
+	// TokenValidationCheckAuthorizedParty fails if the azp claim is not one of the expected clients
+	TokenValidationCheckAuthorizedParty TokenValidationCheck = "azp"
+	// TokenValidationCheckType fails if the typ claim is not one of the expected token types
+	TokenValidationCheckType TokenValidationCheck = "typ"
+	// TokenValidationCheckNotBefore fails if the token is not valid yet
+	TokenValidationCheckNotBefore TokenValidationCheck = "nbf"
+	// TokenValidationCheckIssuedAt fails if the token was issued in the future
+	TokenValidationCheckIssuedAt TokenValidationCheck = "iat"
+	// TokenValidationCheckExpiry fails if the token is expired
+	TokenValidationCheckExpiry TokenValidationCheck = "exp"
+	// TokenValidationCheckDPoP fails if the DPoP proof is invalid or doesn't match the cnf.jkt claim of the token
+	TokenValidationCheckDPoP TokenValidationCheck = "dpop"
+	// TokenValidationCheckCertificate fails if the cnf.x5t#S256 claim of the token doesn't match the client certificate
+	TokenValidationCheckCertificate TokenValidationCheck = "x5t#S256"
+)
+
+// TokenValidationError is returned by the TokenValidator if a token fails a check
+type TokenValidationError struct {
+	Check   TokenValidationCheck
+	Message string
+	Err     error
+}
+
+// Error stringifies the TokenValidationError
+func (e *TokenValidationError) Error() string {
+	msg := fmt.Sprintf("token validation failed: %s: %s", e.Check, e.Message)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error
+func (e *TokenValidationError) Unwrap() error {
+	return e.Err
+}
+
+func validationError(check TokenValidationCheck, err error, format string, args ...interface{}) *TokenValidationError {
+	return &TokenValidationError{
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
+
+// validatedClaims holds the claims checked by the TokenValidator
+type validatedClaims struct {
+	jwt.RegisteredClaims
+	Typ string `json:"typ,omitempty"`
+	Azp string `json:"azp,omitempty"`
+}
+
+// TokenValidator validates access tokens of a realm offline.
+// The realm keys and issuer are fetched on first use, afterwards only the certs cache of the client is used.
+type TokenValidator struct {
+	client            GoCloakIface
+	realm             string
+	issuer            string
+	audiences         []string
+	authorizedParties []string
+	tokenTypes        []string
+	leeway            time.Duration
+	dpopProofMaxAge   time.Duration
+	now               func() time.Time
+
+	issuerLock sync.Mutex
+
+	dpopLock sync.Mutex
+	// dpopProofIDs holds the jti of every accepted DPoP proof until it is too old to be accepted again
+	dpopProofIDs map[string]time.Time
+}
+
+// NewTokenValidator creates a new TokenValidator for the given realm.
+// By default the issuer is taken from GetIssuer, tokens must be of type "Bearer"
+// and a clock skew of 30 seconds is tolerated. Audience and azp are only checked if configured.
+// DPoP proofs are accepted for one minute after they were issued.
+func NewTokenValidator(client GoCloakIface, realm string, options ...func(*TokenValidator)) *TokenValidator {
+	v := &TokenValidator{
+		client:          client,
+		realm:           realm,
+		tokenTypes:      []string{"Bearer"},
+		leeway:          30 * time.Second,
+		dpopProofMaxAge: time.Minute,
+		now:             time.Now,
+		dpopProofIDs:    make(map[string]time.Time),
+	}
+
+	for _, option := range options {
+		option(v)
+	}
+
+	return v
+}
+
+// ==== Functional Options ===
+
+// SetValidatorIssuer sets the expected issuer instead of fetching it via GetIssuer
+func SetValidatorIssuer(issuer string) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.issuer = issuer
+	}
+}
+
+// SetValidatorAudience sets the audiences of which the token must contain at least one
+func SetValidatorAudience(audiences ...string) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.audiences = audiences
+	}
+}
+
+// SetValidatorAuthorizedParty sets the clients which are allowed as azp of the token
+func SetValidatorAuthorizedParty(clientIDs ...string) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.authorizedParties = clientIDs
+	}
+}
+
+// SetValidatorTokenType sets the allowed values of the typ claim. No values disable the check.
+func SetValidatorTokenType(tokenTypes ...string) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.tokenTypes = tokenTypes
+	}
+}
+
+// SetValidatorLeeway sets the tolerated clock skew for the exp, nbf and iat checks
+func SetValidatorLeeway(leeway time.Duration) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.leeway = leeway
+	}
+}
+
+// SetValidatorDPoPProofMaxAge sets how long DPoP proofs are accepted after they were issued
+func SetValidatorDPoPProofMaxAge(maxAge time.Duration) func(v *TokenValidator) {
+	return func(v *TokenValidator) {
+		v.dpopProofMaxAge = maxAge
+	}
+}
+
+// Validate validates the accessToken and returns its claims
+func (v *TokenValidator) Validate(ctx context.Context, accessToken string) (*jwt.Token, *jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := v.ValidateCustomClaims(ctx, accessToken, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	return token, &claims, nil
+}
+
+// ValidateCustomClaims validates the accessToken and writes its claims into the given claims.
+// A *TokenValidationError is returned if the token fails any of the checks.
+func (v *TokenValidator) ValidateCustomClaims(ctx context.Context, accessToken string, claims jwt.Claims) (*jwt.Token, error) {
+	const errMessage = "could not validate access token"
+	accessToken = strings.Replace(accessToken, "Bearer ", "", 1)
+
+	decodedHeader, err := jwx.DecodeAccessTokenHeader(accessToken)
+	if err != nil {
+		return nil, validationError(TokenValidationCheckMalformed, err, "invalid header")
+	}
+
+	usedKey, err := v.client.GetCertKey(ctx, v.realm, decodedHeader.Kid)
+	if errors.Is(err, errCertKeyNotFound) {
+		return nil, validationError(TokenValidationCheckSignature, nil, "unknown key id %q", decodedHeader.Kid)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	token, err := decodeAccessTokenWithKey(accessToken, decodedHeader.Alg, usedKey, claims, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, validationError(TokenValidationCheckSignature, err, "invalid signature")
+	}
+
+	var checked validatedClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, &checked); err != nil {
+		return nil, validationError(TokenValidationCheckMalformed, err, "invalid claims")
+	}
+
+	issuer, err := v.getIssuer(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errMessage)
+	}
+
+	if err := v.validateClaims(&checked, issuer); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (v *TokenValidator) validateClaims(claims *validatedClaims, issuer string) error {
+	if realm := realmFromIssuer(claims.Issuer); realm != v.realm {
+		return validationError(TokenValidationCheckRealm, nil, "token was issued by realm %q", realm)
+	}
+
+	if claims.Issuer != issuer {
+		return validationError(TokenValidationCheckIssuer, nil, "unexpected issuer %q", claims.Issuer)
+	}
+
+	if len(v.audiences) > 0 && !containsAny(claims.Audience, v.audiences) {
+		return validationError(TokenValidationCheckAudience, nil, "none of the audiences %v is present", v.audiences)
+	}
+
+	if len(v.authorizedParties) > 0 && !containsAny([]string{claims.Azp}, v.authorizedParties) {
+		return validationError(TokenValidationCheckAuthorizedParty, nil, "unexpected authorized party %q", claims.Azp)
+	}
+
+	if len(v.tokenTypes) > 0 && !containsAny([]string{claims.Typ}, v.tokenTypes) {
+		return validationError(TokenValidationCheckType, nil, "unexpected token type %q", claims.Typ)
+	}
+
+	return v.validateTimes(claims)
+}
+
+func (v *TokenValidator) validateTimes(claims *validatedClaims) error {
+	now := v.now()
+
+	if claims.NotBefore != nil && now.Add(v.leeway).Before(claims.NotBefore.Time) {
+		return validationError(TokenValidationCheckNotBefore, jwt.ErrTokenNotValidYet, "token is valid from %s", claims.NotBefore.Time)
+	}
+
+	if claims.IssuedAt != nil && now.Add(v.leeway).Before(claims.IssuedAt.Time) {
+		return validationError(TokenValidationCheckIssuedAt, jwt.ErrTokenUsedBeforeIssued, "token was issued at %s", claims.IssuedAt.Time)
+	}
+
+	if claims.ExpiresAt == nil {
+		return validationError(TokenValidationCheckExpiry, jwt.ErrTokenRequiredClaimMissing, "token has no expiration")
+	}
+	if !now.Add(-v.leeway).Before(claims.ExpiresAt.Time) {
+		return validationError(TokenValidationCheckExpiry, jwt.ErrTokenExpired, "token expired at %s", claims.ExpiresAt.Time)
+	}
+
+	return nil
+}
+
+// getIssuer returns the configured issuer or fetches it via GetIssuer
+func (v *TokenValidator) getIssuer(ctx context.Context) (string, error) {
+	v.issuerLock.Lock()
+	defer v.issuerLock.Unlock()
+
+	if v.issuer != "" {
+		return v.issuer, nil
+	}
+
+	issuer, err := v.client.GetIssuer(ctx, v.realm)
+	if err != nil {
+		return "", err
+	}
+	if NilOrEmpty(issuer.TokenService) {
+		return "", errors.New("issuer response has no token service")
+	}
+
+	v.issuer = strings.TrimSuffix(PString(issuer.TokenService), urlSeparator+makeURL("protocol", "openid-connect"))
+
+	return v.issuer, nil
+}
+
+func realmFromIssuer(issuer string) string {
+	idx := strings.LastIndex(issuer, urlSeparator+"realms"+urlSeparator)
+	if idx < 0 {
+		return ""
+	}
+
+	return issuer[idx+len("/realms/"):]
+}
+
+func containsAny(values, expected []string) bool {
+	for _, value := range values {
+		for _, e := range expected {
+			if value == e {
+				return true
+			}
+		}
+	}
+
+	return false
+}
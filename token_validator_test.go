@@ -0,0 +1,231 @@
+package gocloak_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+const testKeyID = "test-key"
+
+type fakeRealm struct {
+	server *fakeServer
+	key    *rsa.PrivateKey
+	realm  string
+}
+
+func newFakeRealm(t *testing.T, realm string) *fakeRealm {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	f := &fakeRealm{server: newFakeServer(t), key: key, realm: realm}
+	f.server.handle("/realms/"+realm+"/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &[]gocloak.CertResponseKey{{
+			Kid: gocloak.StringP(testKeyID),
+			Kty: gocloak.StringP("RSA"),
+			Alg: gocloak.StringP("RS256"),
+			N:   &n,
+			E:   &e,
+		}}})
+	})
+	f.server.handle("/realms/"+realm, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.IssuerResponse{
+			Realm:        gocloak.StringP(realm),
+			TokenService: gocloak.StringP(f.issuer() + "/protocol/openid-connect"),
+		})
+	})
+	f.server.handle("/realms/"+realm+"/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.OpenIDConfiguration{Issuer: gocloak.StringP(f.issuer())})
+	})
+
+	return f
+}
+
+func (f *fakeRealm) issuer() string {
+	return f.server.URL + "/realms/" + f.realm
+}
+
+func (f *fakeRealm) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss": f.issuer(),
+		"aud": []string{"account", "backend"},
+		"azp": "frontend",
+		"typ": "Bearer",
+		"sub": "user-id",
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+func (f *fakeRealm) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestTokenValidator_Valid(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "test")
+	client := gocloak.NewClient(realm.server.URL)
+
+	validator := gocloak.NewTokenValidator(client, "test",
+		gocloak.SetValidatorAudience("backend"),
+		gocloak.SetValidatorAuthorizedParty("frontend"))
+
+	_, claims, err := validator.Validate(context.Background(), realm.sign(t, realm.claims()))
+	require.NoError(t, err)
+	require.Equal(t, "user-id", (*claims)["sub"])
+}
+
+func TestTokenValidator_Checks(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "test")
+	client := gocloak.NewClient(realm.server.URL)
+
+	validator := gocloak.NewTokenValidator(client, "test",
+		gocloak.SetValidatorAudience("backend"),
+		gocloak.SetValidatorAuthorizedParty("frontend"),
+		gocloak.SetValidatorLeeway(time.Minute))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name   string
+		Token  func() string
+		Check  gocloak.TokenValidationCheck
+		Reason error
+	}{
+		{
+			Name:  "malformed",
+			Token: func() string { return "not a token" },
+			Check: gocloak.TokenValidationCheckMalformed,
+		},
+		{
+			Name: "signature",
+			Token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, realm.claims())
+				token.Header["kid"] = testKeyID
+				signed, err := token.SignedString(otherKey)
+				require.NoError(t, err)
+				return signed
+			},
+			Check: gocloak.TokenValidationCheckSignature,
+		},
+		{
+			Name: "realm",
+			Token: func() string {
+				claims := realm.claims()
+				claims["iss"] = realm.server.URL + "/realms/other"
+				return realm.sign(t, claims)
+			},
+			Check: gocloak.TokenValidationCheckRealm,
+		},
+		{
+			Name: "issuer",
+			Token: func() string {
+				claims := realm.claims()
+				claims["iss"] = "https://elsewhere/realms/test"
+				return realm.sign(t, claims)
+			},
+			Check: gocloak.TokenValidationCheckIssuer,
+		},
+		{
+			Name: "audience",
+			Token: func() string {
+				claims := realm.claims()
+				claims["aud"] = "account"
+				return realm.sign(t, claims)
+			},
+			Check: gocloak.TokenValidationCheckAudience,
+		},
+		{
+			Name: "azp",
+			Token: func() string {
+				claims := realm.claims()
+				claims["azp"] = "other"
+				return realm.sign(t, claims)
+			},
+			Check: gocloak.TokenValidationCheckAuthorizedParty,
+		},
+		{
+			Name: "typ",
+			Token: func() string {
+				claims := realm.claims()
+				claims["typ"] = "Refresh"
+				return realm.sign(t, claims)
+			},
+			Check: gocloak.TokenValidationCheckType,
+		},
+		{
+			Name: "nbf",
+			Token: func() string {
+				claims := realm.claims()
+				claims["nbf"] = time.Now().Add(2 * time.Minute).Unix()
+				return realm.sign(t, claims)
+			},
+			Check:  gocloak.TokenValidationCheckNotBefore,
+			Reason: jwt.ErrTokenNotValidYet,
+		},
+		{
+			Name: "exp",
+			Token: func() string {
+				claims := realm.claims()
+				claims["exp"] = time.Now().Add(-2 * time.Minute).Unix()
+				return realm.sign(t, claims)
+			},
+			Check:  gocloak.TokenValidationCheckExpiry,
+			Reason: jwt.ErrTokenExpired,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, _, err := validator.Validate(context.Background(), testCase.Token())
+			var validationErr *gocloak.TokenValidationError
+			require.True(t, errors.As(err, &validationErr), "unexpected error: %v", err)
+			require.Equal(t, testCase.Check, validationErr.Check)
+			if testCase.Reason != nil {
+				require.ErrorIs(t, err, testCase.Reason)
+			}
+		})
+	}
+}
+
+func TestTokenValidator_Leeway(t *testing.T) {
+	t.Parallel()
+	realm := newFakeRealm(t, "test")
+	client := gocloak.NewClient(realm.server.URL)
+
+	claims := realm.claims()
+	claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+	token := realm.sign(t, claims)
+
+	_, _, err := gocloak.NewTokenValidator(client, "test").Validate(context.Background(), token)
+	require.NoError(t, err)
+
+	_, _, err = gocloak.NewTokenValidator(client, "test", gocloak.SetValidatorLeeway(0)).
+		Validate(context.Background(), token)
+	require.ErrorIs(t, err, jwt.ErrTokenExpired)
+}
@@ -0,0 +1,120 @@
+package gocloak
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the name of the OpenTelemetry tracer of gocloak
+	instrumentationName = "github.com/Nerzal/gocloak/v13"
+	// spanPrefix is prepended to the operation in the span names, e.g. gocloak.GetUsers
+	spanPrefix = "gocloak."
+)
+
+// realmKey is the span attribute of the Keycloak realm a request was sent to
+var realmKey = attribute.Key("keycloak.realm")
+
+// startSpan starts the span of a gocloak call named after the operation.
+// The operation is kept in the context for the metrics of the requests, see Observer.
+func (g *GoCloak) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	provider := g.Config.tracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return provider.Tracer(instrumentationName).Start(withOperation(ctx, operation), spanPrefix+operation, trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// injectTraceContext propagates the span of the context to Keycloak, by default as W3C trace context
+func (g *GoCloak) injectTraceContext(ctx context.Context, req *resty.Request) *resty.Request {
+	propagator := g.Config.propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return injectTracingHeaders(ctx, req)
+}
+
+// traceResponse records the request, its response and the resulting error on the span of the request
+func traceResponse(resp *resty.Response, apiError *APIError) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(resp.Request.Context())
+	if !span.IsRecording() {
+		return
+	}
+
+	attributes := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(resp.Request.Method)}
+	if _, realm := classifyEndpoint(resp.Request.URL); realm != "" {
+		attributes = append(attributes, realmKey.String(realm))
+	}
+	if resp.RawResponse != nil {
+		attributes = append(attributes, semconv.HTTPResponseStatusCode(resp.StatusCode()))
+	}
+	if apiError != nil {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(string(apiError.Type)))
+		span.RecordError(apiError)
+		span.SetStatus(codes.Error, apiError.Message)
+	}
+	span.SetAttributes(attributes...)
+}
+
+// injectTracingHeaders is the compatibility adapter for opentracing, see WithTracer
+func injectTracingHeaders(ctx context.Context, req *resty.Request) *resty.Request {
+	// look for span in context, do nothing if span is not found
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return req
+	}
+
+	// look for tracer in context, use global tracer if not found
+	tracer, ok := ctx.Value(tracerContextKey).(opentracing.Tracer)
+	if !ok || tracer == nil {
+		tracer = opentracing.GlobalTracer()
+	}
+
+	// inject tracing header into request
+	err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+	if err != nil {
+		return req
+	}
+
+	return req
+}
+
+// traceRetry adds a retry event to the span of the request before the request is sent again
+func traceRetry(client *resty.Client) resty.OnRetryFunc {
+	return func(resp *resty.Response, err error) {
+		if resp == nil || resp.Request == nil || resp.Request.Attempt > client.RetryCount {
+			return
+		}
+
+		attributes := []attribute.KeyValue{semconv.HTTPRequestResendCount(resp.Request.Attempt)}
+		fields := []interface{}{"event", "retry", "http.retry_count", resp.Request.Attempt}
+		if err != nil {
+			attributes = append(attributes, semconv.ExceptionMessage(err.Error()))
+			fields = append(fields, "error", err.Error())
+		} else {
+			attributes = append(attributes, semconv.HTTPResponseStatusCode(resp.StatusCode()))
+			fields = append(fields, "http.status_code", resp.StatusCode())
+		}
+
+		ctx := resp.Request.Context()
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(attributes...))
+		if span := opentracing.SpanFromContext(ctx); span != nil {
+			span.LogKV(fields...)
+		}
+	}
+}
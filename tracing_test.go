@@ -0,0 +1,231 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// tracedServer records the headers of the requests and responds with status to the users endpoint
+func tracedServer(t *testing.T, status int) (*httptest.Server, func() http.Header) {
+	var lock sync.Mutex
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		header = r.Header.Clone()
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if r.URL.Path == "/realms/realm/protocol/openid-connect/token" {
+			_, _ = w.Write([]byte(`{"access_token":"token"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() http.Header {
+		lock.Lock()
+		defer lock.Unlock()
+		return header
+	}
+}
+
+// spanRecorder is a tracer provider recording the ended spans, so the tests don't depend on the otel SDK
+type spanRecorder struct {
+	noop.TracerProvider
+
+	ids   uint64
+	lock  sync.Mutex
+	ended []*recordedSpan
+}
+
+func (r *spanRecorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{recorder: r}
+}
+
+// Ended returns the ended spans in the order they ended
+func (r *spanRecorder) Ended() []*recordedSpan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*recordedSpan(nil), r.ended...)
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	recorder *spanRecorder
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	id := atomic.AddUint64(&t.recorder.ids, 1)
+	parent := trace.SpanContextFromContext(ctx)
+
+	config := trace.SpanContextConfig{TraceID: parent.TraceID(), TraceFlags: trace.FlagsSampled}
+	if !config.TraceID.IsValid() {
+		binary.BigEndian.PutUint64(config.TraceID[8:], id)
+	}
+	binary.BigEndian.PutUint64(config.SpanID[:], id)
+
+	startConfig := trace.NewSpanStartConfig(options...)
+	span := &recordedSpan{
+		recorder:    t.recorder,
+		name:        name,
+		parent:      parent,
+		spanContext: trace.NewSpanContext(config),
+		attributes:  startConfig.Attributes(),
+	}
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordedSpan struct {
+	noop.Span
+	recorder    *spanRecorder
+	name        string
+	parent      trace.SpanContext
+	spanContext trace.SpanContext
+	attributes  []attribute.KeyValue
+	status      codes.Code
+	events      []string
+}
+
+func (s *recordedSpan) SpanContext() trace.SpanContext { return s.spanContext }
+
+func (s *recordedSpan) IsRecording() bool { return true }
+
+func (s *recordedSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordedSpan) AddEvent(name string, _ ...trace.EventOption) {
+	s.events = append(s.events, name)
+}
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.recorder.lock.Lock()
+	defer s.recorder.lock.Unlock()
+	s.recorder.ended = append(s.recorder.ended, s)
+}
+
+func spanAttributes(span *recordedSpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestTracing_SpanPerCall(t *testing.T) {
+	t.Parallel()
+	server, lastHeader := tracedServer(t, http.StatusOK)
+	recorder := &spanRecorder{}
+	client := gocloak.NewClient(server.URL, gocloak.SetTracerProvider(recorder))
+
+	_, err := client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "gocloak.GetUsers", spans[0].name)
+	require.Equal(t, codes.Unset, spans[0].status)
+
+	attributes := spanAttributes(spans[0])
+	require.Equal(t, "realm", attributes["keycloak.realm"].AsString())
+	require.Equal(t, http.MethodGet, attributes["http.request.method"].AsString())
+	require.Equal(t, int64(http.StatusOK), attributes["http.response.status_code"].AsInt64())
+
+	// the span is propagated as W3C trace context
+	carrier := propagation.HeaderCarrier(lastHeader())
+	remote := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	require.Equal(t, spans[0].spanContext.TraceID(), remote.TraceID())
+	require.Equal(t, spans[0].spanContext.SpanID(), remote.SpanID())
+}
+
+func TestTracing_RecordsAPIErrors(t *testing.T) {
+	t.Parallel()
+	server, _ := tracedServer(t, http.StatusNotFound)
+	recorder := &spanRecorder{}
+	client := gocloak.NewClient(server.URL, gocloak.SetTracerProvider(recorder))
+
+	_, err := client.GetUsers(context.Background(), "token", "realm", gocloak.GetUsersParams{})
+	require.ErrorIs(t, err, gocloak.ErrNotFound)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].status)
+
+	attributes := spanAttributes(spans[0])
+	require.Equal(t, int64(http.StatusNotFound), attributes["http.response.status_code"].AsInt64())
+	require.Equal(t, string(gocloak.APIErrTypeNotFound), attributes["error.type"].AsString())
+}
+
+func TestTracing_NestedCallsAndParentSpan(t *testing.T) {
+	t.Parallel()
+	server, _ := tracedServer(t, http.StatusOK)
+	recorder := &spanRecorder{}
+	client := gocloak.NewClient(server.URL, gocloak.SetTracerProvider(recorder))
+
+	ctx, parent := recorder.Tracer("test").Start(context.Background(), "provisioning")
+	_, err := client.LoginClient(ctx, "client", "secret", "realm")
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "gocloak.GetToken", spans[0].name)
+	require.Equal(t, "gocloak.LoginClient", spans[1].name)
+	require.Equal(t, spans[1].spanContext.SpanID(), spans[0].parent.SpanID())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[1].parent.SpanID())
+	require.Equal(t, http.MethodPost, spanAttributes(spans[0])["http.request.method"].AsString())
+}
+
+func TestTracing_RetryEvents(t *testing.T) {
+	t.Parallel()
+	server := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+	recorder := &spanRecorder{}
+	client := gocloak.NewClient(server.URL,
+		gocloak.SetTracerProvider(recorder),
+		gocloak.SetRetryPolicy(gocloak.RetryPolicy{WaitTime: time.Millisecond}),
+	)
+
+	_, err := client.GetServerInfo(context.Background(), "token")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, []string{"retry"}, spans[0].events)
+}
+
+func TestTracing_OpentracingAdapter(t *testing.T) {
+	t.Parallel()
+	server, lastHeader := tracedServer(t, http.StatusOK)
+	client := gocloak.NewClient(server.URL)
+
+	tracer := mocktracer.New()
+	span := tracer.StartSpan("keycloak")
+	ctx := gocloak.WithTracer(context.Background(), tracer)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	_, err := client.GetUsers(ctx, "token", "realm", gocloak.GetUsersParams{})
+	require.NoError(t, err)
+	require.NotEmpty(t, lastHeader().Get("Mockpfx-Ids-Traceid"))
+}
@@ -145,7 +145,10 @@ func NilOrEmptySlice(value *[]string) bool {
 	return value == nil || len(*value) == 0
 }
 
-// WithTracer generates a context that has a tracer attached
+// WithTracer generates a context that has a tracer attached.
+// The tracer injects the opentracing span of the context into the requests to Keycloak.
+//
+// Deprecated: opentracing is archived, use SetTracerProvider for OpenTelemetry instead.
 func WithTracer(ctx context.Context, tracer opentracing.Tracer) context.Context {
 	return context.WithValue(ctx, tracerContextKey, tracer)
 }